@@ -0,0 +1,226 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrBulkSkipped is the error recorded for entities that were not started
+// because an earlier operation failed in StopOnError mode.
+var ErrBulkSkipped = errors.New("iland: skipped after an earlier failure")
+
+const defaultBulkConcurrency = 10
+
+// BulkOperation starts an operation on one entity and returns its task.
+// Context methods such as VirtualMachineService.PowerOffContext can be used
+// directly.
+type BulkOperation func(ctx context.Context, entityID string) (Task, error)
+
+// BulkMode controls what happens after an operation fails.
+type BulkMode int
+
+const (
+	// ContinueOnError runs the operation on every entity regardless of
+	// failures.
+	ContinueOnError BulkMode = iota
+	// StopOnError starts no further operations after the first failure.
+	// Operations already started are still tracked to completion.
+	StopOnError
+)
+
+// BulkOptions configures a BulkExecutor.
+type BulkOptions struct {
+	// Concurrency is the number of entities processed at once, counting
+	// both starting the operation and tracking its task. It defaults to 10.
+	Concurrency int
+	Mode        BulkMode
+	// SkipTracking returns as soon as each task has been started, rather
+	// than waiting for it to complete.
+	SkipTracking bool
+	// Wait controls how tasks are tracked. Its OnProgress is never called
+	// concurrently.
+	Wait WaitOptions
+	// OnProgress, if set, is called after each entity finishes. Calls are
+	// never concurrent.
+	OnProgress func(BulkProgress)
+}
+
+// BulkResult is the outcome of the operation on one entity.
+type BulkResult struct {
+	EntityID string
+	Task     Task
+	Err      error
+}
+
+// BulkProgress reports the outcome for one entity along with running
+// totals.
+type BulkProgress struct {
+	Result    BulkResult
+	Completed int
+	Failed    int
+	Total     int
+}
+
+// BulkResults holds one result per entity, in the order the entities were
+// given.
+type BulkResults []BulkResult
+
+// Succeeded returns the results without an error.
+func (r BulkResults) Succeeded() BulkResults {
+	results := BulkResults{}
+	for _, result := range r {
+		if result.Err == nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Failed returns the results with an error, including skipped entities.
+func (r BulkResults) Failed() BulkResults {
+	results := BulkResults{}
+	for _, result := range r {
+		if result.Err != nil {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Err returns a *BulkError if any entity failed, otherwise nil.
+func (r BulkResults) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BulkError{Total: len(r), Failures: failed}
+}
+
+// BulkError aggregates the failures of a bulk operation. errors.Is and
+// errors.As match against each entity's error.
+type BulkError struct {
+	Total    int
+	Failures BulkResults
+}
+
+func (e *BulkError) Error() string {
+	const maxListed = 3
+	parts := []string{}
+	for i, failure := range e.Failures {
+		if i == maxListed {
+			parts = append(parts, fmt.Sprintf("and %d more", len(e.Failures)-maxListed))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s: %v", failure.EntityID, failure.Err))
+	}
+	return fmt.Sprintf("iland: %d of %d bulk operations failed: %s", len(e.Failures), e.Total, strings.Join(parts, "; "))
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// BulkExecutor runs an operation across many entities with bounded
+// concurrency and tracks the resulting tasks.
+type BulkExecutor struct {
+	tasks TaskService
+	opts  BulkOptions
+}
+
+// NewBulkExecutor returns an executor that tracks tasks with tasks.
+func NewBulkExecutor(tasks TaskService, opts BulkOptions) *BulkExecutor {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultBulkConcurrency
+	}
+	opts.Wait.OnProgress = serialize(opts.Wait.OnProgress)
+	return &BulkExecutor{tasks: tasks, opts: opts}
+}
+
+// Run applies op to every entity and returns a result per entity together
+// with the aggregated error from BulkResults.Err. Entities not started
+// because ctx was cancelled record ctx's error.
+func (e *BulkExecutor) Run(ctx context.Context, entityIDs []string, op BulkOperation) (BulkResults, error) {
+	results := make(BulkResults, len(entityIDs))
+	for i, entityID := range entityIDs {
+		results[i] = BulkResult{EntityID: entityID}
+	}
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		stopped   bool
+		completed int
+		failed    int
+	)
+	finish := func(i int, result BulkResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[i] = result
+		completed++
+		if result.Err != nil {
+			failed++
+			if e.opts.Mode == StopOnError {
+				stopped = true
+			}
+		}
+		if e.opts.OnProgress != nil {
+			e.opts.OnProgress(BulkProgress{Result: result, Completed: completed, Failed: failed, Total: len(entityIDs)})
+		}
+	}
+	sem := make(chan struct{}, e.opts.Concurrency)
+	for i, entityID := range entityIDs {
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		mu.Lock()
+		skip := stopped
+		mu.Unlock()
+		if skip || ctx.Err() != nil {
+			if acquired {
+				<-sem
+			}
+			err := ErrBulkSkipped
+			if !skip {
+				err = ctx.Err()
+			}
+			finish(i, BulkResult{EntityID: entityID, Err: err})
+			continue
+		}
+		wg.Add(1)
+		go func(i int, entityID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			finish(i, e.runOne(ctx, entityID, op))
+		}(i, entityID)
+	}
+	wg.Wait()
+	return results, results.Err()
+}
+
+func (e *BulkExecutor) runOne(ctx context.Context, entityID string, op BulkOperation) BulkResult {
+	result := BulkResult{EntityID: entityID}
+	task, err := op(ctx, entityID)
+	result.Task = task
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	if e.opts.SkipTracking {
+		return result
+	}
+	task, err = e.tasks.Wait(ctx, task.ID, e.opts.Wait)
+	if task.ID != "" {
+		result.Task = task
+	}
+	result.Err = err
+	return result
+}
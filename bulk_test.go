@@ -0,0 +1,180 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBoom = errors.New("boom")
+
+func TestBulkExecutorRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		entities    int
+		concurrency int
+		mode        BulkMode
+		// fail is the index of the entity whose operation fails, or -1.
+		fail int
+		// cancel is the index of the entity whose operation cancels the
+		// run's context and fails with its error, or -1.
+		cancel int
+		// wantErrs holds the error each entity must record, nil for success.
+		wantErrs []error
+	}{
+		{
+			name: "all succeed", entities: 20, concurrency: 3, fail: -1, cancel: -1,
+			wantErrs: make([]error, 20),
+		},
+		{
+			name: "continue on error", entities: 4, concurrency: 2, fail: 1, cancel: -1,
+			wantErrs: []error{nil, errBoom, nil, nil},
+		},
+		{
+			name: "stop on error", entities: 4, concurrency: 1, mode: StopOnError, fail: 1, cancel: -1,
+			wantErrs: []error{nil, errBoom, ErrBulkSkipped, ErrBulkSkipped},
+		},
+		{
+			name: "cancelled", entities: 4, concurrency: 1, fail: -1, cancel: 1,
+			wantErrs: []error{nil, context.Canceled, context.Canceled, context.Canceled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			entityIDs := make([]string, tt.entities)
+			for i := range entityIDs {
+				entityIDs[i] = fmt.Sprintf("entity-%d", i)
+			}
+			var inFlight, maxInFlight atomic.Int32
+			op := func(ctx context.Context, entityID string) (Task, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					peak := maxInFlight.Load()
+					if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				switch entityID {
+				case fmt.Sprintf("entity-%d", tt.fail):
+					return Task{}, errBoom
+				case fmt.Sprintf("entity-%d", tt.cancel):
+					cancel()
+					return Task{}, ctx.Err()
+				}
+				return Task{ID: "task-" + entityID}, nil
+			}
+			progress := []BulkProgress{}
+			executor := NewBulkExecutor(nil, BulkOptions{
+				Concurrency:  tt.concurrency,
+				Mode:         tt.mode,
+				SkipTracking: true,
+				OnProgress:   func(p BulkProgress) { progress = append(progress, p) },
+			})
+
+			results, err := executor.Run(ctx, entityIDs, op)
+
+			if got := maxInFlight.Load(); got > int32(tt.concurrency) {
+				t.Errorf("%d operations ran at once, want at most %d", got, tt.concurrency)
+			}
+			if len(results) != tt.entities {
+				t.Fatalf("got %d results, want %d", len(results), tt.entities)
+			}
+			failed := 0
+			for i, result := range results {
+				if result.EntityID != entityIDs[i] {
+					t.Errorf("result %d is for %s, want %s", i, result.EntityID, entityIDs[i])
+				}
+				want := tt.wantErrs[i]
+				switch {
+				case want == nil && result.Err != nil:
+					t.Errorf("%s: err = %v, want nil", result.EntityID, result.Err)
+				case want == nil && result.Task.ID != "task-"+result.EntityID:
+					t.Errorf("%s: task = %q", result.EntityID, result.Task.ID)
+				case want != nil && !errors.Is(result.Err, want):
+					t.Errorf("%s: err = %v, want %v", result.EntityID, result.Err, want)
+				}
+				if want != nil {
+					failed++
+				}
+			}
+
+			if failed == 0 {
+				if err != nil {
+					t.Errorf("err = %v, want nil", err)
+				}
+			} else {
+				var bulkErr *BulkError
+				if !errors.As(err, &bulkErr) {
+					t.Fatalf("err = %v, want *BulkError", err)
+				}
+				if bulkErr.Total != tt.entities || len(bulkErr.Failures) != failed {
+					t.Errorf("BulkError has %d of %d failures, want %d of %d", len(bulkErr.Failures), bulkErr.Total, failed, tt.entities)
+				}
+				for _, want := range tt.wantErrs {
+					if want != nil && !errors.Is(err, want) {
+						t.Errorf("errors.Is(err, %v) = false", want)
+					}
+				}
+			}
+
+			if len(progress) != tt.entities {
+				t.Fatalf("OnProgress called %d times, want %d", len(progress), tt.entities)
+			}
+			for i, p := range progress {
+				if p.Completed != i+1 || p.Total != tt.entities {
+					t.Errorf("progress %d = %d/%d, want %d/%d", i, p.Completed, p.Total, i+1, tt.entities)
+				}
+			}
+			if last := progress[len(progress)-1]; last.Failed != failed {
+				t.Errorf("final Failed = %d, want %d", last.Failed, failed)
+			}
+		})
+	}
+}
+
+func TestBulkExecutorTracksTasks(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/tasks/")
+		status := Success
+		if id == "task-bad" {
+			status = Error
+		}
+		json.NewEncoder(w).Encode(Task{ID: id, Status: status, Synced: true})
+	}), WithRetryPolicy(NoRetryPolicy))
+	var mu sync.Mutex
+	started := []string{}
+	op := func(ctx context.Context, entityID string) (Task, error) {
+		mu.Lock()
+		started = append(started, entityID)
+		mu.Unlock()
+		return Task{ID: "task-" + entityID}, nil
+	}
+	executor := NewBulkExecutor(c.Task(), BulkOptions{Wait: WaitOptions{PollInterval: time.Millisecond}})
+
+	results, err := executor.Run(context.Background(), []string{"good", "bad"}, op)
+
+	if len(started) != 2 {
+		t.Errorf("started %d operations, want 2", len(started))
+	}
+	if results[0].Err != nil || results[0].Task.Status != Success {
+		t.Errorf("good: task %+v, err %v", results[0].Task, results[0].Err)
+	}
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) || taskErr.Task.ID != "task-bad" {
+		t.Fatalf("err = %v, want *TaskError for task-bad", err)
+	}
+	if !errors.As(results[1].Err, &taskErr) {
+		t.Errorf("bad: err = %v, want *TaskError", results[1].Err)
+	}
+}
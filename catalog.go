@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 type Catalog struct {
@@ -25,8 +27,13 @@ type catalogService struct {
 }
 
 func (s *catalogService) Get(catalogID string) (Catalog, error) {
+	return s.GetContext(context.Background(), catalogID)
+}
+
+func (s *catalogService) GetContext(ctx context.Context, catalogID string) (Catalog, error) {
+	ctx = withOperation(ctx, "Catalog.Get", catalogID)
 	catalog := Catalog{}
-	err := s.client.getObject(fmt.Sprintf("/v1/catalogs/%s", catalogID), &catalog)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/catalogs/%s", catalogID), &catalog)
 	if err != nil {
 		return Catalog{}, err
 	}
@@ -39,11 +46,16 @@ type UpdateCatalogParams struct {
 }
 
 func (s *catalogService) Update(catalogID string, params UpdateCatalogParams) (Task, error) {
+	return s.UpdateContext(context.Background(), catalogID, params)
+}
+
+func (s *catalogService) UpdateContext(ctx context.Context, catalogID string, params UpdateCatalogParams) (Task, error) {
+	ctx = withOperation(ctx, "Catalog.Update", catalogID)
 	data, err := json.Marshal(params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/catalogs/%s", catalogID), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/catalogs/%s", catalogID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -56,27 +68,47 @@ func (s *catalogService) Update(catalogID string, params UpdateCatalogParams) (T
 }
 
 func (s *catalogService) GetVAppTemplates(catalogID string) ([]VAppTemplate, error) {
+	return s.GetVAppTemplatesContext(context.Background(), catalogID)
+}
+
+func (s *catalogService) GetVAppTemplatesContext(ctx context.Context, catalogID string) ([]VAppTemplate, error) {
+	ctx = withOperation(ctx, "Catalog.GetVAppTemplates", catalogID)
 	schema := struct {
 		VAppTemplates []VAppTemplate `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/catalogs/%s/vapp-templates", catalogID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/catalogs/%s/vapp-templates", catalogID), &schema)
 	if err != nil {
 		return []VAppTemplate{}, err
 	}
 	return schema.VAppTemplates, nil
 }
 
+func (s *catalogService) ListVAppTemplates(ctx context.Context, catalogID string, opts ListOptions) iter.Seq2[VAppTemplate, error] {
+	ctx = withOperation(ctx, "Catalog.ListVAppTemplates", catalogID)
+	return listAll[VAppTemplate](ctx, s.client, fmt.Sprintf("/v1/catalogs/%s/vapp-templates", catalogID), opts)
+}
+
 func (s *catalogService) GetMedia(catalogID string) ([]Media, error) {
+	return s.GetMediaContext(context.Background(), catalogID)
+}
+
+func (s *catalogService) GetMediaContext(ctx context.Context, catalogID string) ([]Media, error) {
+	ctx = withOperation(ctx, "Catalog.GetMedia", catalogID)
 	schema := struct {
 		Media []Media `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/catalogs/%s/media", catalogID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/catalogs/%s/media", catalogID), &schema)
 	if err != nil {
 		return []Media{}, err
 	}
 	return schema.Media, nil
 }
 
+func (s *catalogService) ListMedia(ctx context.Context, catalogID string, opts ListOptions) iter.Seq2[Media, error] {
+	ctx = withOperation(ctx, "Catalog.ListMedia", catalogID)
+	return listAll[Media](ctx, s.client, fmt.Sprintf("/v1/catalogs/%s/media", catalogID), opts)
+}
+
 type CreateVAppTemplateParams struct {
 	VAppID      string `json:"vapp_uuid"`
 	Name        string `json:"name"`
@@ -84,11 +116,16 @@ type CreateVAppTemplateParams struct {
 }
 
 func (s *catalogService) CreateVAppTemplate(catalogID string, params CreateVAppTemplateParams) (Task, error) {
+	return s.CreateVAppTemplateContext(context.Background(), catalogID, params)
+}
+
+func (s *catalogService) CreateVAppTemplateContext(ctx context.Context, catalogID string, params CreateVAppTemplateParams) (Task, error) {
+	ctx = withOperation(ctx, "Catalog.CreateVAppTemplate", catalogID)
 	data, err := json.Marshal(params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Post(fmt.Sprintf("/v1/catalogs/%s/actions/add-vapp-template-from-vapp", catalogID), data)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/catalogs/%s/actions/add-vapp-template-from-vapp", catalogID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -101,7 +138,12 @@ func (s *catalogService) CreateVAppTemplate(catalogID string, params CreateVAppT
 }
 
 func (s *catalogService) SyncSubscription(catalogID string) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/catalogs/%s/actions/sync", catalogID), []byte{})
+	return s.SyncSubscriptionContext(context.Background(), catalogID)
+}
+
+func (s *catalogService) SyncSubscriptionContext(ctx context.Context, catalogID string) (Task, error) {
+	ctx = withOperation(ctx, "Catalog.SyncSubscription", catalogID)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/catalogs/%s/actions/sync", catalogID), []byte{})
 	if err != nil {
 		return Task{}, err
 	}
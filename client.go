@@ -1,44 +1,101 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 type client struct {
-	username        string
-	password        string
-	clientID        string
-	clientSecret    string
-	Token           Token
-	tokenExpiration time.Time
-	http            *http.Client
-}
-
-func InitClient(c *http.Client, accessToken string, expiration time.Time) (ConsoleService, error) {
-	return &client{
-		Token: Token{
-			AccessToken: accessToken,
-			ExpiresIn:   expiration.Unix(),
-		},
-		tokenExpiration: expiration,
-		http:            c,
-	}, nil
-}
-
-func NewClient(Username, Password, clientID, clientSecret string) (ConsoleService, error) {
-	return &client{
-		username:     Username,
-		password:     Password,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		http:         &http.Client{},
-	}, nil
+	username          string
+	password          string
+	clientID          string
+	clientSecret      string
+	tokenSource       TokenSource
+	tokenMu           sync.Mutex
+	token             Token
+	tokenExpiration   time.Time
+	tokenCall         *tokenCall
+	http              *http.Client
+	baseURL           string
+	authURL           string
+	eventStreamURL    string
+	userAgent         string
+	apiVersion        string
+	retryPolicy       RetryPolicy
+	fanOutConcurrency int
+	middleware        []Middleware
+	handler           Handler
+	locationsMu       sync.Mutex
+	locations         []Location
+	locationsErr      error
+	locationsExpiry   time.Time
+	locationsFetch    chan struct{}
+}
+
+func InitClient(c *http.Client, accessToken string, expiration time.Time, opts ...Option) (ConsoleService, error) {
+	token := Token{
+		AccessToken: accessToken,
+		ExpiresIn:   expiration.Unix(),
+		Expiry:      expiration,
+	}
+	opts = append([]Option{WithHTTPClient(c), WithTokenSource(StaticTokenSource(&token))}, opts...)
+	cl, err := newClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+func NewClient(Username, Password, clientID, clientSecret string, opts ...Option) (ConsoleService, error) {
+	cl, err := newClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	cl.username = Username
+	cl.password = Password
+	cl.clientID = clientID
+	cl.clientSecret = clientSecret
+	if cl.tokenSource == nil {
+		cl.tokenSource = PasswordTokenSource(cl.tokenConfig(), Username, Password)
+	}
+	return cl, nil
+}
+
+// NewClientWithTokenSource returns a client that obtains its access tokens
+// from source. Use WithUsername for calls scoped to the current user.
+func NewClientWithTokenSource(source TokenSource, opts ...Option) (ConsoleService, error) {
+	cl, err := newClient(append([]Option{WithTokenSource(source)}, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+func newClient(opts []Option) (*client, error) {
+	c := &client{
+		http:           &http.Client{},
+		baseURL:        defaultBaseURL,
+		authURL:        defaultAuthURL,
+		eventStreamURL: defaultEventStreamURL,
+		apiVersion:     defaultAPIVersion,
+		retryPolicy:    DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.buildHandler()
+	return c, nil
 }
 
 func (c *client) Location() LocationService {
@@ -110,30 +167,40 @@ func (c *client) Task() TaskService {
 
 }
 
+func (c *client) Event() EventService {
+	return &eventService{c}
+}
+
 func (c *client) GetOperatingSystems() ([]OperatingSystem, error) {
+	return c.GetOperatingSystemsContext(context.Background())
+}
+
+func (c *client) GetOperatingSystemsContext(ctx context.Context) ([]OperatingSystem, error) {
+	ctx = withOperation(ctx, "Console.GetOperatingSystems", "")
 	schema := struct {
 		OperatingSystems []OperatingSystem `json:"data"`
 	}{}
-	err := c.getObject("/v1/constants/operating-systems", &schema)
+	err := c.getObject(ctx, "/v1/constants/operating-systems", &schema)
 	return schema.OperatingSystems, err
 }
 
+// GetLocations returns the location catalogue, falling back to
+// DefaultLocations if it cannot be fetched.
 func (c *client) GetLocations() []Location {
-	locations := []Location{}
-	for _, locationID := range LocationIDs {
-		location := Location{
-			ID: locationID,
-		}
-		locations = append(locations, location)
-	}
+	locations, _ := c.GetLocationsContext(context.Background())
 	return locations
 }
 
 func (c *client) GetCompanies() ([]Company, error) {
+	return c.GetCompaniesContext(context.Background())
+}
+
+func (c *client) GetCompaniesContext(ctx context.Context) ([]Company, error) {
+	ctx = withOperation(ctx, "Console.GetCompanies", "")
 	schema := struct {
 		Companies []Company `json:"data"`
 	}{}
-	err := c.getObject(fmt.Sprintf("/v1/users/%s/companies", c.username), &schema)
+	err := c.getObject(ctx, fmt.Sprintf("/v1/users/%s/companies", c.username), &schema)
 	if err != nil {
 		return []Company{}, err
 	}
@@ -144,10 +211,15 @@ func (c *client) GetCompanies() ([]Company, error) {
 }
 
 func (c *client) GetOrgs() ([]Org, error) {
+	return c.GetOrgsContext(context.Background())
+}
+
+func (c *client) GetOrgsContext(ctx context.Context) ([]Org, error) {
+	ctx = withOperation(ctx, "Console.GetOrgs", "")
 	schema := struct {
 		Orgs []Org `json:"data"`
 	}{}
-	err := c.getObject(fmt.Sprintf("/v1/users/%s/orgs", c.username), &schema)
+	err := c.getObject(ctx, fmt.Sprintf("/v1/users/%s/orgs", c.username), &schema)
 	if err != nil {
 		return []Org{}, err
 	}
@@ -160,70 +232,89 @@ type SocketData struct {
 }
 
 func (c *client) StreamEvents(companyID string) (chan Event, error) {
-	events := make(chan Event, 100)
-	conn, err := c.dialEventStream(companyID)
+	return c.StreamEventsContext(context.Background(), companyID)
+}
+
+// StreamEventsContext returns the events of companyID until ctx is done,
+// when the channel is closed. OpenEventStream offers control over the
+// connection, errors and filtering.
+func (c *client) StreamEventsContext(ctx context.Context, companyID string) (chan Event, error) {
+	ctx = withOperation(ctx, "Console.StreamEvents", companyID)
+	stream, err := c.OpenEventStream(ctx, companyID, EventStreamOptions{})
 	if err != nil {
 		return nil, err
 	}
-	go c.streamEvents(companyID, conn, events)
+	events := make(chan Event, 100)
+	go func() {
+		defer close(events)
+		for event := range stream.Events() {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}()
 	return events, nil
 }
 
-func (c *client) dialEventStream(companyID string) (*websocket.Conn, error) {
-	err := c.RefreshTokenIfNecessary()
+// eventStreamHandshakeTimeout bounds the authorization handshake of an event
+// stream when ctx has no deadline.
+const eventStreamHandshakeTimeout = 30 * time.Second
+
+// dialEventStream connects and authorizes an event stream, returning the
+// access token it used. The connection is closed if ctx is done before the
+// handshake completes.
+func (c *client) dialEventStream(ctx context.Context, companyID string) (*websocket.Conn, string, error) {
+	accessToken, err := c.accessToken(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	header := http.Header{}
+	if c.userAgent != "" {
+		header.Set("User-Agent", c.userAgent)
 	}
-	conn, _, err := websocket.DefaultDialer.Dial("wss://api.ilandcloud.com/v1/event-websocket", nil)
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.eventStreamURL, header)
 	if err != nil {
-		return nil, err
+		return nil, accessToken, err
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	if !hasDeadline {
+		deadline = time.Now().Add(eventStreamHandshakeTimeout)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	fail := func(err error) (*websocket.Conn, string, error) {
+		stop()
+		conn.Close()
+		var netErr net.Error
+		switch {
+		case ctx.Err() != nil:
+			return nil, accessToken, ctx.Err()
+		case hasDeadline && errors.As(err, &netErr) && netErr.Timeout():
+			return nil, accessToken, context.DeadlineExceeded
+		}
+		return nil, accessToken, err
 	}
+	conn.SetReadDeadline(deadline)
+	conn.SetWriteDeadline(deadline)
 	_, message, err := conn.ReadMessage()
 	if err != nil {
-		return nil, err
+		return fail(err)
 	}
-	auth := fmt.Sprintf("Bearer %s", c.Token.AccessToken)
+	auth := fmt.Sprintf("Bearer %s", accessToken)
 	if companyID != "" {
-		auth = fmt.Sprintf("companyId=%s,Bearer %s", companyID, c.Token.AccessToken)
+		auth = fmt.Sprintf("companyId=%s,Bearer %s", companyID, accessToken)
 	}
-	if string(message) == "AUTHORIZATION" {
-		err = conn.WriteMessage(websocket.TextMessage, []byte(auth))
-		if err != nil {
-			return nil, err
-		}
-		return conn, nil
+	if string(message) != "AUTHORIZATION" {
+		return fail(fmt.Errorf("%w: %s", errEventStreamRejected, message))
 	}
-	return nil, errors.New(string(message))
-}
-
-func (c *client) streamEvents(companyID string, conn *websocket.Conn, events chan Event) {
-	defer conn.Close()
-	for {
-		_, message, err := conn.ReadMessage()
-		if err != nil {
-			break
-		}
-		msg := SocketData{}
-		err = json.Unmarshal(message, &msg)
-		if err != nil {
-			break
-		}
-		switch msg.Type {
-		case "EVENT":
-			event := Event{}
-			err = json.Unmarshal(msg.Data, &event)
-			if err == nil {
-				events <- event
-			}
-		}
+	err = conn.WriteMessage(websocket.TextMessage, []byte(auth))
+	if err != nil {
+		return fail(err)
 	}
-	for {
-		time.Sleep(time.Second * 5)
-		newConn, err := c.dialEventStream(companyID)
-		if err != nil {
-			continue
-		}
-		go c.streamEvents(companyID, newConn, events)
-		return
+	if !stop() {
+		return fail(ctx.Err())
 	}
+	conn.SetReadDeadline(time.Time{})
+	conn.SetWriteDeadline(time.Time{})
+	return conn, accessToken, nil
 }
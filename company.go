@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 type Company struct {
@@ -21,8 +23,13 @@ type companyService struct {
 }
 
 func (s *companyService) Get(companyID string) (Company, error) {
+	return s.GetContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetContext(ctx context.Context, companyID string) (Company, error) {
+	ctx = withOperation(ctx, "Company.Get", companyID)
 	company := Company{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s", companyID), &company)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s", companyID), &company)
 	if err != nil {
 		return Company{}, err
 	}
@@ -30,16 +37,26 @@ func (s *companyService) Get(companyID string) (Company, error) {
 }
 
 func (s *companyService) GetUsers(companyID string) ([]User, error) {
+	return s.GetUsersContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetUsersContext(ctx context.Context, companyID string) ([]User, error) {
+	ctx = withOperation(ctx, "Company.GetUsers", companyID)
 	schema := struct {
 		Users []User `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/users", companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/users", companyID), &schema)
 	if err != nil {
 		return []User{}, err
 	}
 	return schema.Users, nil
 }
 
+func (s *companyService) ListUsers(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[User, error] {
+	ctx = withOperation(ctx, "Company.ListUsers", companyID)
+	return listAll[User](ctx, s.client, fmt.Sprintf("/v1/companies/%s/users", companyID), opts)
+}
+
 type CreateUserParams struct {
 	Username string `json:"username"`
 	FullName string `json:"fullname"`
@@ -49,11 +66,16 @@ type CreateUserParams struct {
 }
 
 func (s *companyService) CreateUser(companyID string, params CreateUserParams) (User, error) {
+	return s.CreateUserContext(context.Background(), companyID, params)
+}
+
+func (s *companyService) CreateUserContext(ctx context.Context, companyID string, params CreateUserParams) (User, error) {
+	ctx = withOperation(ctx, "Company.CreateUser", companyID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return User{}, err
 	}
-	resp, err := s.client.Post(fmt.Sprintf("/v1/companies/%s/users", companyID), data)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/companies/%s/users", companyID), data)
 	if err != nil {
 		return User{}, err
 	}
@@ -66,19 +88,34 @@ func (s *companyService) CreateUser(companyID string, params CreateUserParams) (
 }
 
 func (s *companyService) GetRoles(companyID string) ([]Role, error) {
+	return s.GetRolesContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetRolesContext(ctx context.Context, companyID string) ([]Role, error) {
+	ctx = withOperation(ctx, "Company.GetRoles", companyID)
 	schema := struct {
 		Roles []Role `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/roles", companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/roles", companyID), &schema)
 	if err != nil {
 		return []Role{}, err
 	}
 	return schema.Roles, nil
 }
 
+func (s *companyService) ListRoles(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[Role, error] {
+	ctx = withOperation(ctx, "Company.ListRoles", companyID)
+	return listAll[Role](ctx, s.client, fmt.Sprintf("/v1/companies/%s/roles", companyID), opts)
+}
+
 func (s *companyService) GetRole(companyID, roleID string) (Role, error) {
+	return s.GetRoleContext(context.Background(), companyID, roleID)
+}
+
+func (s *companyService) GetRoleContext(ctx context.Context, companyID, roleID string) (Role, error) {
+	ctx = withOperation(ctx, "Company.GetRole", companyID)
 	role := Role{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/roles/%s", companyID, roleID), &role)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/roles/%s", companyID, roleID), &role)
 	if err != nil {
 		return Role{}, err
 	}
@@ -86,64 +123,102 @@ func (s *companyService) GetRole(companyID, roleID string) (Role, error) {
 }
 
 func (s *companyService) GetOrgs(companyID string) ([]Org, error) {
-	orgs := []Org{}
-	for _, locationID := range LocationIDs {
-		schema := struct {
-			Orgs []Org `json:"data"`
-		}{}
-		err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/location/%s/orgs", companyID, locationID), &schema)
-		if err != nil {
-			return []Org{}, err
-		}
-		orgs = append(orgs, schema.Orgs...)
-	}
-	return orgs, nil
+	return s.GetOrgsContext(context.Background(), companyID)
+}
+
+// GetOrgsContext queries every IaaS location concurrently. If some
+// locations fail, the orgs of the others are returned with a *PartialError.
+func (s *companyService) GetOrgsContext(ctx context.Context, companyID string) ([]Org, error) {
+	ctx = withOperation(ctx, "Company.GetOrgs", companyID)
+	return fanOut(ctx, "Company.GetOrgs", s.client.iaasLocationIDs(ctx), s.client.fanOutConcurrency, func(ctx context.Context, locationID string) ([]Org, error) {
+		return s.GetLocationOrgsContext(ctx, companyID, locationID)
+	})
 }
 
 func (s *companyService) GetLocationOrgs(companyID, locationID string) ([]Org, error) {
+	return s.GetLocationOrgsContext(context.Background(), companyID, locationID)
+}
+
+func (s *companyService) GetLocationOrgsContext(ctx context.Context, companyID, locationID string) ([]Org, error) {
+	ctx = withOperation(ctx, "Company.GetLocationOrgs", companyID)
 	schema := struct {
 		Orgs []Org `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/location/%s/orgs", companyID, locationID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/location/%s/orgs", companyID, locationID), &schema)
 	if err != nil {
 		return []Org{}, err
 	}
 	return schema.Orgs, nil
 }
 
+func (s *companyService) ListLocationOrgs(ctx context.Context, companyID, locationID string, opts ListOptions) iter.Seq2[Org, error] {
+	ctx = withOperation(ctx, "Company.ListLocationOrgs", companyID)
+	return listAll[Org](ctx, s.client, fmt.Sprintf("/v1/companies/%s/location/%s/orgs", companyID, locationID), opts)
+}
+
 func (s *companyService) GetVCCBackupTenants(companyID string) ([]VCCBackupTenant, error) {
+	return s.GetVCCBackupTenantsContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetVCCBackupTenantsContext(ctx context.Context, companyID string) ([]VCCBackupTenant, error) {
+	ctx = withOperation(ctx, "Company.GetVCCBackupTenants", companyID)
 	schema := struct {
 		VCCBackupTenants []VCCBackupTenant `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/vcc-backup-tenants", companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/vcc-backup-tenants", companyID), &schema)
 	if err != nil {
 		return []VCCBackupTenant{}, err
 	}
 	return schema.VCCBackupTenants, nil
 }
 
+func (s *companyService) ListVCCBackupTenants(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[VCCBackupTenant, error] {
+	ctx = withOperation(ctx, "Company.ListVCCBackupTenants", companyID)
+	return listAll[VCCBackupTenant](ctx, s.client, fmt.Sprintf("/v1/companies/%s/vcc-backup-tenants", companyID), opts)
+}
+
 func (s *companyService) GetLocationVacTenants(companyID, location string) ([]VacTenant, error) {
+	return s.GetLocationVacTenantsContext(context.Background(), companyID, location)
+}
+
+func (s *companyService) GetLocationVacTenantsContext(ctx context.Context, companyID, location string) ([]VacTenant, error) {
+	ctx = withOperation(ctx, "Company.GetLocationVacTenants", companyID)
 	schema := struct {
 		Tenants []VacTenant `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/location/%s/vac-companies", companyID, location), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/location/%s/vac-companies", companyID, location), &schema)
 	if err != nil {
 		return []VacTenant{}, err
 	}
 	return schema.Tenants, nil
 }
 
+func (s *companyService) ListLocationVacTenants(ctx context.Context, companyID, location string, opts ListOptions) iter.Seq2[VacTenant, error] {
+	ctx = withOperation(ctx, "Company.ListLocationVacTenants", companyID)
+	return listAll[VacTenant](ctx, s.client, fmt.Sprintf("/v1/companies/%s/location/%s/vac-companies", companyID, location), opts)
+}
+
 func (s *companyService) GetVacTenants(companyID string) ([]VacTenant, error) {
+	return s.GetVacTenantsContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetVacTenantsContext(ctx context.Context, companyID string) ([]VacTenant, error) {
+	ctx = withOperation(ctx, "Company.GetVacTenants", companyID)
 	schema := struct {
 		Tenants []VacTenant `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/companies/%s/vac-companies", companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/companies/%s/vac-companies", companyID), &schema)
 	if err != nil {
 		return []VacTenant{}, err
 	}
 	return schema.Tenants, nil
 }
 
+func (s *companyService) ListVacTenants(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[VacTenant, error] {
+	ctx = withOperation(ctx, "Company.ListVacTenants", companyID)
+	return listAll[VacTenant](ctx, s.client, fmt.Sprintf("/v1/companies/%s/vac-companies", companyID), opts)
+}
+
 type CompanyInventory struct {
 	CompanyID   string    `json:"company_id"`
 	CompanyName string    `json:"company_name"`
@@ -186,11 +261,16 @@ type InventoryItem struct {
 }
 
 func (s *companyService) GetInventory(companyID string) (CompanyInventory, error) {
+	return s.GetInventoryContext(context.Background(), companyID)
+}
+
+func (s *companyService) GetInventoryContext(ctx context.Context, companyID string) (CompanyInventory, error) {
+	ctx = withOperation(ctx, "Company.GetInventory", companyID)
 	schema := struct {
 		Username  string             `json:"username"`
 		Inventory []CompanyInventory `json:"inventory"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s/inventory?company=%s", s.client.username, companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s/inventory?company=%s", s.client.username, companyID), &schema)
 	if err != nil {
 		return CompanyInventory{}, err
 	}
@@ -199,3 +279,51 @@ func (s *companyService) GetInventory(companyID string) (CompanyInventory, error
 	}
 	return schema.Inventory[0], nil
 }
+
+func (s *companyService) GetLocations(companyID string) ([]Location, error) {
+	return s.GetLocationsContext(context.Background(), companyID)
+}
+
+// GetLocationsContext returns the locations where the company has IaaS,
+// VCC, object storage or O365 resources, according to its inventory. The
+// service flags are those the company uses there, not every service the
+// location offers. Name, region, country and timezone come from the
+// catalogue, so locations missing from it have only an ID and flags.
+func (s *companyService) GetLocationsContext(ctx context.Context, companyID string) ([]Location, error) {
+	ctx = withOperation(ctx, "Company.GetLocations", companyID)
+	inventory, err := s.GetInventoryContext(ctx, companyID)
+	if err != nil {
+		return []Location{}, err
+	}
+	catalogue := map[string]Location{}
+	all, _ := s.client.GetLocationsContext(ctx)
+	for _, location := range all {
+		catalogue[location.ID] = location
+	}
+	locations := []Location{}
+	index := map[string]int{}
+	add := func(items []InventoryItem, mark func(*Location)) {
+		for _, item := range items {
+			i, ok := index[item.UUID]
+			if !ok {
+				location := Location{ID: item.UUID}
+				if known, ok := catalogue[item.UUID]; ok {
+					location.Name = known.Name
+					location.Region = known.Region
+					location.Country = known.Country
+					location.Timezone = known.Timezone
+				}
+				i = len(locations)
+				index[item.UUID] = i
+				locations = append(locations, location)
+			}
+			mark(&locations[i])
+		}
+	}
+	entities := inventory.Entities
+	add(entities.IaasLocations, func(l *Location) { l.HasIAAS = true })
+	add(entities.VCCBackupLocations, func(l *Location) { l.HasVCC = true })
+	add(entities.ObjectStorageLocations, func(l *Location) { l.HasObjectStorage = true })
+	add(entities.O365Locations, func(l *Location) { l.HasO365 = true })
+	return locations, nil
+}
@@ -1,9 +1,10 @@
 package iland
 
 const (
-	apiHostname = "api.ilandcloud.com"
-	accessURL   = "https://console.ilandcloud.com/auth/realms/iland-core/protocol/openid-connect/token"
-	refreshURL  = "https://console.ilandcloud.com/auth/realms/iland-core/protocol/openid-connect/token"
+	defaultBaseURL        = "https://api.ilandcloud.com"
+	defaultAuthURL        = "https://console.ilandcloud.com/auth/realms/iland-core/protocol/openid-connect/token"
+	defaultEventStreamURL = "wss://api.ilandcloud.com/v1/event-websocket"
+	defaultAPIVersion     = "1.0"
 
 	IPTranslation  = "ipTranslation"
 	PortForwarding = "portForwarding"
@@ -22,32 +23,24 @@ const (
 	EntityIaasEdge         = "IAAS_EDGE"
 	EntityIaasVApp         = "IAAS_VAPP"
 	EntityIaasVm           = "IAAS_VM"
-)
 
-var LocationIDs = []string{
-	"res01.ilandcloud.com",
-	"lax01.ilandcloud.com",
-	"man01.ilandcloud.com",
-	"man03.ilandcloud.com",
-	"lon02.ilandcloud.com",
-	"lon03.ilandcloud.com",
-	"dal02.ilandcloud.com",
-	"dal06.ilandcloud.com",
-	"dal22.ilandcloud.com",
-	"dal23.ilandcloud.com",
-	"dal25.ilandcloud.com",
-	"sin01.ilandcloud.com",
-	"ams01.ilandcloud.com",
-	"ams02.ilandcloud.com",
-	"ams03.ilandcloud.com",
-	"ams04.ilandcloud.com",
-	"syd02.ilandcloud.com",
-	"syd03.ilandcloud.com",
-	"syd04.ilandcloud.com",
-	"mel02.ilandcloud.com",
-	"mel03.ilandcloud.com",
-	"mel04.ilandcloud.com",
-	"str02.ilandcloud.com",
-	"str03.ilandcloud.com",
-	"str05.ilandcloud.com",
-}
+	EntityIaasLocation          = "IAAS_LOCATION"
+	EntityIaasProduct           = "IAAS_PRODUCT"
+	EntityIaasCatalog           = "IAAS_CATALOG"
+	EntityIaasMedia             = "IAAS_MEDIA"
+	EntityIaasInternalNetwork   = "IAAS_INTERNAL_NETWORK"
+	EntityIaasVAppNetwork       = "IAAS_VAPP_NETWORK"
+	EntityIaasVAppTemplate      = "IAAS_VAPP_TEMPLATE"
+	EntityIaasVpg               = "IAAS_VPG"
+	EntityIaasVccFailoverPlan   = "IAAS_VCC_FAILOVER_PLAN"
+	EntityVccBackupLocation     = "VCC_BACKUP_LOCATION"
+	EntityVccBackupProduct      = "VCC_BACKUP_PRODUCT"
+	EntityVccBackupTenant       = "VCC_BACKUP_TENANT"
+	EntityVacBackupJob          = "VAC_BACKUP_JOB"
+	EntityObjectStorageProduct  = "OBJECT_STORAGE_PRODUCT"
+	EntityObjectStorageLocation = "OBJECT_STORAGE_LOCATION"
+	EntityO365Product           = "O365_PRODUCT"
+	EntityO365Location          = "O365_LOCATION"
+	EntityO365Organization      = "O365_ORGANIZATION"
+	EntityO365Job               = "O365_JOB"
+)
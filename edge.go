@@ -1,6 +1,7 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -39,8 +40,13 @@ type edgeService struct {
 }
 
 func (s *edgeService) Get(edgeID string) (Edge, error) {
+	return s.GetContext(context.Background(), edgeID)
+}
+
+func (s *edgeService) GetContext(ctx context.Context, edgeID string) (Edge, error) {
+	ctx = withOperation(ctx, "Edge.Get", edgeID)
 	edge := Edge{}
-	err := s.client.getObject(fmt.Sprintf("/v1/edges/%s", edgeID), &edge)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/edges/%s", edgeID), &edge)
 	if err != nil {
 		return Edge{}, err
 	}
@@ -170,8 +176,13 @@ type EdgeFirewallRuleService struct {
 }
 
 func (s *edgeService) GetFirewall(edgeID string) (EdgeFirewall, error) {
+	return s.GetFirewallContext(context.Background(), edgeID)
+}
+
+func (s *edgeService) GetFirewallContext(ctx context.Context, edgeID string) (EdgeFirewall, error) {
+	ctx = withOperation(ctx, "Edge.GetFirewall", edgeID)
 	firewall := EdgeFirewall{}
-	err := s.client.getObject(fmt.Sprintf("/v1/edge-gateways/%s/firewall", edgeID), &firewall)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/edge-gateways/%s/firewall", edgeID), &firewall)
 	if err != nil {
 		return EdgeFirewall{}, err
 	}
@@ -179,11 +190,16 @@ func (s *edgeService) GetFirewall(edgeID string) (EdgeFirewall, error) {
 }
 
 func (s *edgeService) UpdateFirewall(edgeID string, firewall EdgeFirewall) (EdgeFirewall, error) {
+	return s.UpdateFirewallContext(context.Background(), edgeID, firewall)
+}
+
+func (s *edgeService) UpdateFirewallContext(ctx context.Context, edgeID string, firewall EdgeFirewall) (EdgeFirewall, error) {
+	ctx = withOperation(ctx, "Edge.UpdateFirewall", edgeID)
 	data, err := json.Marshal(&firewall)
 	if err != nil {
 		return EdgeFirewall{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/edge-gateways/%s/firewall", edgeID), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/edge-gateways/%s/firewall", edgeID), data)
 	if err != nil {
 		return EdgeFirewall{}, err
 	}
@@ -196,8 +212,13 @@ func (s *edgeService) UpdateFirewall(edgeID string, firewall EdgeFirewall) (Edge
 }
 
 func (s *edgeService) GetNAT(edgeID string) (EdgeNAT, error) {
+	return s.GetNATContext(context.Background(), edgeID)
+}
+
+func (s *edgeService) GetNATContext(ctx context.Context, edgeID string) (EdgeNAT, error) {
+	ctx = withOperation(ctx, "Edge.GetNAT", edgeID)
 	nat := EdgeNAT{}
-	err := s.client.getObject(fmt.Sprintf("/v1/edge-gateways/%s/nat", edgeID), &nat)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/edge-gateways/%s/nat", edgeID), &nat)
 	if err != nil {
 		return EdgeNAT{}, err
 	}
@@ -205,11 +226,16 @@ func (s *edgeService) GetNAT(edgeID string) (EdgeNAT, error) {
 }
 
 func (s *edgeService) UpdateNAT(edgeID string, nat EdgeNAT) (EdgeNAT, error) {
+	return s.UpdateNATContext(context.Background(), edgeID, nat)
+}
+
+func (s *edgeService) UpdateNATContext(ctx context.Context, edgeID string, nat EdgeNAT) (EdgeNAT, error) {
+	ctx = withOperation(ctx, "Edge.UpdateNAT", edgeID)
 	data, err := json.Marshal(&nat)
 	if err != nil {
 		return EdgeNAT{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/edge-gateways/%s/nat", edgeID), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/edge-gateways/%s/nat", edgeID), data)
 	if err != nil {
 		return EdgeNAT{}, err
 	}
@@ -0,0 +1,196 @@
+package iland
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *APIError through errors.Is.
+var (
+	ErrBadRequest   = errors.New("iland: bad request")
+	ErrUnauthorized = errors.New("iland: unauthorized")
+	ErrForbidden    = errors.New("iland: forbidden")
+	ErrNotFound     = errors.New("iland: not found")
+	ErrConflict     = errors.New("iland: conflict")
+	ErrRateLimited  = errors.New("iland: rate limited")
+	ErrServer       = errors.New("iland: server error")
+)
+
+// APIError is returned for every non-successful response from the iland
+// cloud API or its token endpoint.
+type APIError struct {
+	StatusCode    int           `json:"-"`
+	Method        string        `json:"-"`
+	Path          string        `json:"-"`
+	RequestID     string        `json:"-"`
+	RetryAfter    time.Duration `json:"-"`
+	Code          string        `json:"error"`
+	Message       string        `json:"message"`
+	DetailMessage string        `json:"detail_message"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("iland: %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	} else if e.Code != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Code)
+	}
+	if e.DetailMessage != "" && e.DetailMessage != e.Message {
+		msg = fmt.Sprintf("%s (%s)", msg, e.DetailMessage)
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the package sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// newAPIError builds an *APIError from a failed response. The response body
+// is consumed but not closed.
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil || len(data) == 0 {
+		return apiErr
+	}
+	schema := struct {
+		APIError
+		Description string `json:"error_description"`
+	}{}
+	err = json.Unmarshal(data, &schema)
+	if err != nil {
+		apiErr.Message = strings.TrimSpace(string(data))
+		return apiErr
+	}
+	apiErr.Code = schema.Code
+	apiErr.Message = schema.Message
+	apiErr.DetailMessage = schema.DetailMessage
+	if apiErr.Message == "" {
+		apiErr.Message = schema.Description
+	}
+	return apiErr
+}
+
+// Sentinel errors matched by *TaskError through errors.Is.
+var (
+	ErrTaskFailed    = errors.New("iland: task failed")
+	ErrTaskCancelled = errors.New("iland: task cancelled")
+)
+
+// TaskError is returned when a waited-for task ends in error or is
+// cancelled.
+type TaskError struct {
+	Task Task
+}
+
+func (e *TaskError) Error() string {
+	msg := fmt.Sprintf("iland: task %s (%s) on %s ended %s", e.Task.ID, e.Task.Operation, e.Task.EntityID, e.Task.Status)
+	if e.Task.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Task.Message)
+	}
+	return msg
+}
+
+// Is reports whether the error matches ErrTaskFailed or ErrTaskCancelled.
+func (e *TaskError) Is(target error) bool {
+	switch target {
+	case ErrTaskFailed:
+		return e.Task.Status == Error
+	case ErrTaskCancelled:
+		return e.Task.Status == Cancelled
+	}
+	return false
+}
+
+// PartialError is returned by calls that query several locations,
+// companies or orgs when some of them fail. The results of the others are
+// returned with it. errors.Is and errors.As match against each failure.
+type PartialError struct {
+	Op    string
+	Total int
+	// Errors maps the ID of each failed location, company, org or entity to
+	// its error.
+	Errors map[string]error
+}
+
+func (e *PartialError) Error() string {
+	const maxListed = 3
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for i, key := range keys {
+		if i == maxListed {
+			parts = append(parts, fmt.Sprintf("and %d more", len(keys)-maxListed))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s: %v", key, e.Errors[key]))
+	}
+	return fmt.Sprintf("iland: %s: %d of %d failed: %s", e.Op, len(e.Errors), e.Total, strings.Join(parts, "; "))
+}
+
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// ErrAmbiguousName is matched by *AmbiguousNameError through errors.Is.
+var ErrAmbiguousName = errors.New("iland: ambiguous name")
+
+// AmbiguousNameError is returned by a Resolver when a name matches more
+// than one entity.
+type AmbiguousNameError struct {
+	Ref     string
+	Name    string
+	Matches []InventoryItem
+}
+
+func (e *AmbiguousNameError) Error() string {
+	matches := []string{}
+	for _, match := range e.Matches {
+		matches = append(matches, fmt.Sprintf("%s %s", match.Type, match.UUID))
+	}
+	return fmt.Sprintf("iland: %q in %q matches %d entities: %s", e.Name, e.Ref, len(e.Matches), strings.Join(matches, ", "))
+}
+
+// Is reports whether target is ErrAmbiguousName.
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguousName
+}
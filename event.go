@@ -1,5 +1,11 @@
 package iland
 
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
 type Event struct {
 	ID              string `json:"uuid"`
 	Details         string `json:"details"`
@@ -14,3 +20,22 @@ type Event struct {
 	InitiatedByName string `json:"initiated_by_full_name"`
 	Timestamp       int    `json:"timestamp"`
 }
+
+type eventService struct {
+	client *client
+}
+
+// Search returns every event matching query, oldest first. Events are
+// fetched a page at a time as the iterator advances, opts.PageSize at once.
+func (s *eventService) Search(ctx context.Context, query EventQuery, opts ListOptions) iter.Seq2[Event, error] {
+	ctx = withOperation(ctx, "Event.Search", query.companyID)
+	if query.companyID == "" {
+		return func(yield func(Event, error) bool) {
+			yield(Event{}, errors.New("iland: event query requires a company"))
+		}
+	}
+	if opts.Sort == "" {
+		opts.Sort = "timestamp"
+	}
+	return paginate[Event](ctx, s.client, query.endpoint, opts)
+}
@@ -0,0 +1,265 @@
+package iland
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy controls what an EventBus does when a subscriber's buffer
+// is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDrop discards events that do not fit in the buffer and counts
+	// them in Subscription.Dropped.
+	OverflowDrop OverflowPolicy = iota
+	// OverflowBlock waits for the subscriber to make room, delaying every
+	// other subscriber of the same company meanwhile.
+	OverflowBlock
+)
+
+// EventBusOptions configures an EventBus.
+type EventBusOptions struct {
+	// Stream configures the event streams opened by the bus. Its Filter
+	// applies to every subscriber.
+	Stream EventStreamOptions
+}
+
+// SubscribeOptions configures a Subscription.
+type SubscribeOptions struct {
+	Filter EventFilter
+	// BufferSize is the capacity of the Events channel. It defaults to 100.
+	BufferSize int
+	Overflow   OverflowPolicy
+}
+
+// EventBus shares one EventStream per company among any number of
+// subscribers. Streams are opened by the first subscription to a company
+// and closed with the last.
+type EventBus struct {
+	console ConsoleService
+	opts    EventBusOptions
+
+	mu      sync.Mutex
+	streams map[string]*busStream
+	// opening holds a channel for each company whose stream is being
+	// opened, closed once the attempt ends.
+	opening map[string]chan struct{}
+}
+
+type busStream struct {
+	stream *EventStream
+	subs   map[*Subscription]struct{}
+}
+
+// NewEventBus returns a bus that opens its streams through console.
+func NewEventBus(console ConsoleService, opts EventBusOptions) *EventBus {
+	return &EventBus{
+		console: console,
+		opts:    opts,
+		streams: map[string]*busStream{},
+		opening: map[string]chan struct{}{},
+	}
+}
+
+// Subscribe returns a subscription to the events of companyID (every
+// company the user can see if empty), opening the shared stream if needed.
+// ctx only bounds opening the stream. Subscriptions made while the stream
+// of their company is being opened wait for that attempt, and make their
+// own if it fails.
+func (b *EventBus) Subscribe(ctx context.Context, companyID string, opts SubscribeOptions) (*Subscription, error) {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 100
+	}
+	sub := &Subscription{
+		bus:       b,
+		companyID: companyID,
+		opts:      opts,
+		events:    make(chan Event, opts.BufferSize),
+		done:      make(chan struct{}),
+	}
+	for {
+		b.mu.Lock()
+		if bs, ok := b.streams[companyID]; ok {
+			sub.stream = bs.stream
+			bs.subs[sub] = struct{}{}
+			b.mu.Unlock()
+			return sub, nil
+		}
+		if opening, ok := b.opening[companyID]; ok {
+			b.mu.Unlock()
+			select {
+			case <-opening:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		opening := make(chan struct{})
+		b.opening[companyID] = opening
+		b.mu.Unlock()
+
+		stream, err := b.openStream(ctx, companyID)
+		b.mu.Lock()
+		delete(b.opening, companyID)
+		close(opening)
+		if err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+		sub.stream = stream
+		bs := &busStream{stream: stream, subs: map[*Subscription]struct{}{sub: {}}}
+		b.streams[companyID] = bs
+		b.mu.Unlock()
+		go b.pump(companyID, bs)
+		return sub, nil
+	}
+}
+
+// openStream opens the stream of companyID. ctx bounds the first
+// connection only: the stream runs until its last subscriber leaves.
+func (b *EventBus) openStream(ctx context.Context, companyID string) (*EventStream, error) {
+	streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, cancel)
+	stream, err := b.console.OpenEventStream(streamCtx, companyID, b.opts.Stream)
+	if !stop() {
+		if err == nil {
+			stream.Close()
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Close closes every subscription and stream.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	subs := []*Subscription{}
+	for _, bs := range b.streams {
+		for sub := range bs.subs {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.Unlock()
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// pump fans the events of one stream out to its subscribers until the
+// stream closes, then closes any subscriptions left.
+func (b *EventBus) pump(companyID string, bs *busStream) {
+	for event := range bs.stream.Events() {
+		b.mu.Lock()
+		subs := make([]*Subscription, 0, len(bs.subs))
+		for sub := range bs.subs {
+			subs = append(subs, sub)
+		}
+		b.mu.Unlock()
+		for _, sub := range subs {
+			sub.send(event)
+		}
+	}
+	b.mu.Lock()
+	if b.streams[companyID] == bs {
+		delete(b.streams, companyID)
+	}
+	subs := []*Subscription{}
+	for sub := range bs.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
+
+// remove detaches sub, closing its stream if it was the last subscriber.
+func (b *EventBus) remove(sub *Subscription) {
+	b.mu.Lock()
+	bs, ok := b.streams[sub.companyID]
+	if !ok || bs.stream != sub.stream {
+		b.mu.Unlock()
+		return
+	}
+	delete(bs.subs, sub)
+	if len(bs.subs) > 0 {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.streams, sub.companyID)
+	b.mu.Unlock()
+	bs.stream.Close()
+}
+
+// Subscription receives the events of one company from an EventBus.
+type Subscription struct {
+	bus       *EventBus
+	companyID string
+	opts      SubscribeOptions
+	stream    *EventStream
+	events    chan Event
+	done      chan struct{}
+	dropped   atomic.Int64
+	once      sync.Once
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Events returns the channel of events that pass the subscription's
+// filter. It is closed when the subscription or its stream closes.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events discarded by OverflowDrop.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Err returns the error that closed the shared stream, if any.
+func (s *Subscription) Err() error {
+	return s.stream.Err()
+}
+
+// Close unsubscribes. The shared stream is closed with its last
+// subscription.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.bus.remove(s)
+		s.mu.Lock()
+		s.closed = true
+		close(s.events)
+		s.mu.Unlock()
+	})
+}
+
+func (s *Subscription) send(event Event) {
+	if !s.opts.Filter.Match(event) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	if s.opts.Overflow == OverflowBlock {
+		select {
+		case s.events <- event:
+		case <-s.done:
+		}
+		return
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
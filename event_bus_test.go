@@ -0,0 +1,114 @@
+package iland_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+func TestEventBusSubscribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		subscribers int
+		// slowOpen delays the stream's connection, and the first subscriber
+		// gives up before it completes.
+		slowOpen bool
+	}{
+		{name: "one subscriber", subscribers: 1},
+		{name: "shared stream", subscribers: 5},
+		{name: "subscriber gives up while opening", subscribers: 3, slowOpen: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ilandtest.NewServer()
+			defer srv.Close()
+			company := srv.AddCompany(iland.Company{})
+			c, err := srv.NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			bus := iland.NewEventBus(c, iland.EventBusOptions{})
+			defer bus.Close()
+			if tt.slowOpen {
+				srv.InjectFault(ilandtest.Fault{Path: "/v1/event-websocket", Times: 1, Delay: 200 * time.Millisecond})
+			}
+
+			subs := make([]*iland.Subscription, tt.subscribers)
+			errs := make([]error, tt.subscribers)
+			var wg sync.WaitGroup
+			for i := range subs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					ctx := context.Background()
+					if tt.slowOpen && i == 0 {
+						var cancel context.CancelFunc
+						ctx, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+						defer cancel()
+					}
+					subs[i], errs[i] = bus.Subscribe(ctx, company.ID, iland.SubscribeOptions{})
+				}(i)
+			}
+			wg.Wait()
+			if tt.slowOpen {
+				if !errors.Is(errs[0], context.DeadlineExceeded) {
+					t.Fatalf("first subscriber err = %v, want %v", errs[0], context.DeadlineExceeded)
+				}
+				subs, errs = subs[1:], errs[1:]
+			}
+			for _, err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			waitFor(t, "one stream", func() bool { return srv.EventStreams() == 1 })
+
+			srv.PublishEvent(iland.Event{OwnerID: company.ID, Type: iland.EventVmPowerOn})
+			for i, sub := range subs {
+				select {
+				case event := <-sub.Events():
+					if event.Type != iland.EventVmPowerOn {
+						t.Errorf("subscriber %d got %q", i, event.Type)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("subscriber %d got no event", i)
+				}
+			}
+
+			for i, sub := range subs {
+				sub.Close()
+				if _, ok := <-sub.Events(); ok {
+					t.Errorf("subscriber %d still open after Close", i)
+				}
+				if i < len(subs)-1 && srv.EventStreams() != 1 {
+					t.Fatalf("stream closed with %d subscribers left", len(subs)-1-i)
+				}
+			}
+			waitFor(t, "stream to close", func() bool { return srv.EventStreams() == 0 })
+
+			// A later subscription opens a new stream.
+			sub, err := bus.Subscribe(context.Background(), company.ID, iland.SubscribeOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sub.Close()
+			waitFor(t, "new stream", func() bool { return srv.EventStreams() == 1 })
+		})
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
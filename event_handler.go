@@ -0,0 +1,102 @@
+package iland
+
+import (
+	"context"
+	"sync"
+)
+
+// MessageEvent is the SocketData type of event messages. Messages of other
+// types are passed to EventStreamOptions.OnMessage.
+const MessageEvent = "EVENT"
+
+// EventHandlers dispatches events to callbacks registered by event type. It
+// is safe for concurrent use; callbacks run on the dispatching goroutine.
+type EventHandlers struct {
+	mu        sync.RWMutex
+	handlers  map[string][]func(Event)
+	unhandled []func(Event)
+	messages  map[string][]func(SocketData)
+}
+
+// NewEventHandlers returns an empty registry.
+func NewEventHandlers() *EventHandlers {
+	return &EventHandlers{
+		handlers: map[string][]func(Event){},
+		messages: map[string][]func(SocketData){},
+	}
+}
+
+// On registers fn for events of the given types, such as EventVmPowerOn.
+func (h *EventHandlers) On(fn func(Event), eventTypes ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, eventType := range eventTypes {
+		h.handlers[eventType] = append(h.handlers[eventType], fn)
+	}
+}
+
+// OnEntity registers fn for every known event type of entityType, as listed
+// in EventTypes.
+func (h *EventHandlers) OnEntity(fn func(Event), entityType string) {
+	h.On(fn, EventTypes[entityType]...)
+}
+
+// OnUnhandled registers fn for events with no handler for their type.
+func (h *EventHandlers) OnUnhandled(fn func(Event)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.unhandled = append(h.unhandled, fn)
+}
+
+// OnMessage registers fn for socket messages of messageType other than
+// MessageEvent.
+func (h *EventHandlers) OnMessage(fn func(SocketData), messageType string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.messages[messageType] = append(h.messages[messageType], fn)
+}
+
+// Dispatch calls the handlers for event's type, or the unhandled handlers
+// if there are none. It reports whether a type handler was called.
+func (h *EventHandlers) Dispatch(event Event) bool {
+	h.mu.RLock()
+	handlers := h.handlers[event.Type]
+	unhandled := h.unhandled
+	h.mu.RUnlock()
+	if len(handlers) == 0 {
+		for _, fn := range unhandled {
+			fn(event)
+		}
+		return false
+	}
+	for _, fn := range handlers {
+		fn(event)
+	}
+	return true
+}
+
+// DispatchMessage calls the handlers for msg's type. It can be used as
+// EventStreamOptions.OnMessage.
+func (h *EventHandlers) DispatchMessage(msg SocketData) {
+	h.mu.RLock()
+	handlers := h.messages[msg.Type]
+	h.mu.RUnlock()
+	for _, fn := range handlers {
+		fn(msg)
+	}
+}
+
+// Run dispatches events until the channel is closed or ctx is done.
+func (h *EventHandlers) Run(ctx context.Context, events <-chan Event) {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			h.Dispatch(event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package iland
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// EventQuery selects historical events for EventService.Search. Like
+// TaskQuery it is built by chaining methods on a zero EventQuery:
+//
+//	q := iland.EventQuery{}.
+//		Company(companyID).
+//		Entity(vmID, iland.EntityIaasVm).
+//		Since(time.Now().Add(-24 * time.Hour))
+type EventQuery struct {
+	companyID  string
+	entityID   string
+	entityType string
+	types      []string
+	since      time.Time
+	until      time.Time
+}
+
+// Company sets the company whose events are searched. It is required.
+func (q EventQuery) Company(companyID string) EventQuery {
+	q.companyID = companyID
+	return q
+}
+
+// Entity restricts the query to events on one entity.
+func (q EventQuery) Entity(entityID, entityType string) EventQuery {
+	q.entityID = entityID
+	q.entityType = entityType
+	return q
+}
+
+// Type restricts the query to events of one of the types.
+func (q EventQuery) Type(types ...string) EventQuery {
+	q.types = append(append([]string{}, q.types...), types...)
+	return q
+}
+
+// Since restricts the query to events at or after t.
+func (q EventQuery) Since(t time.Time) EventQuery {
+	q.since = t
+	return q
+}
+
+// Until restricts the query to events before t.
+func (q EventQuery) Until(t time.Time) EventQuery {
+	q.until = t
+	return q
+}
+
+// endpoint returns the URL of one page of the query's events.
+func (q EventQuery) endpoint(page, pageSize int) string {
+	query := url.Values{}
+	if q.entityID != "" {
+		query.Set("entityUuid", q.entityID)
+	}
+	if q.entityType != "" {
+		query.Set("entityType", q.entityType)
+	}
+	for _, eventType := range q.types {
+		query.Add("type", eventType)
+	}
+	if !q.since.IsZero() {
+		query.Set("timestampAfter", strconv.FormatInt(q.since.UnixMilli(), 10))
+	}
+	if !q.until.IsZero() {
+		query.Set("timestampBefore", strconv.FormatInt(q.until.UnixMilli(), 10))
+	}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("pageSize", strconv.Itoa(pageSize))
+	return fmt.Sprintf("/v1/companies/%s/events?%s", q.companyID, query.Encode())
+}
@@ -0,0 +1,472 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errEventStreamRejected is returned when the event stream refuses the
+// authorization handshake.
+var errEventStreamRejected = errors.New("iland: event stream rejected authorization")
+
+// EventStreamState is the connection state of an EventStream.
+type EventStreamState int
+
+const (
+	StreamConnecting EventStreamState = iota
+	StreamConnected
+	StreamReconnecting
+	// StreamAuthFailed means the stream rejected the access token. The
+	// stream fetches a new token and keeps reconnecting, unless the token
+	// itself could not be obtained, in which case it closes.
+	StreamAuthFailed
+	StreamClosed
+)
+
+func (s EventStreamState) String() string {
+	switch s {
+	case StreamConnecting:
+		return "connecting"
+	case StreamConnected:
+		return "connected"
+	case StreamReconnecting:
+		return "reconnecting"
+	case StreamAuthFailed:
+		return "auth failed"
+	case StreamClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("EventStreamState(%d)", int(s))
+}
+
+// EventFilter selects events on the client side. Each non-empty field must
+// contain the corresponding event value; empty fields match everything.
+type EventFilter struct {
+	EntityTypes []string
+	EntityIDs   []string
+	Types       []string
+	OwnerIDs    []string
+}
+
+// Match reports whether event passes the filter.
+func (f EventFilter) Match(event Event) bool {
+	return matchAny(f.EntityTypes, event.EntityType) &&
+		matchAny(f.EntityIDs, event.EntityID) &&
+		matchAny(f.Types, event.Type) &&
+		matchAny(f.OwnerIDs, event.OwnerID)
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// backfillOverlap is subtracted from the start of a backfill to allow
+	// for clock skew; the duplicates it causes are dropped by event ID.
+	backfillOverlap = 5 * time.Second
+	// recentEventIDs is how many event IDs are remembered for dropping
+	// duplicates.
+	recentEventIDs = 1000
+)
+
+// DefaultReconnectPolicy is the reconnect backoff used by event streams:
+// 1s doubling to 1m with 20% jitter, retried forever.
+var DefaultReconnectPolicy = RetryPolicy{
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// EventStreamOptions configures OpenEventStream.
+type EventStreamOptions struct {
+	Filter EventFilter
+	// Reconnect sets the backoff between reconnect attempts. MaxAttempts,
+	// if positive, closes the stream after that many consecutive failures.
+	// It defaults to DefaultReconnectPolicy.
+	Reconnect *RetryPolicy
+	// BufferSize is the capacity of the Events channel. It defaults to 100.
+	BufferSize int
+	// DisableBackfill turns off replaying, after each reconnect, the events
+	// missed while the stream was disconnected.
+	DisableBackfill bool
+	// OnMessage, if set, is called with socket messages other than events.
+	// It runs on the stream's goroutine and must not block.
+	OnMessage func(SocketData)
+}
+
+// EventStream is a live connection to the event websocket that reconnects
+// until it is closed. After a reconnect it queries the events emitted while
+// it was disconnected and replays them before resuming, dropping those it
+// has already delivered by Event.ID. Delivery is therefore at least once:
+// events without an ID, or older than the last 1000 delivered, may repeat.
+type EventStream struct {
+	client    *client
+	ctx       context.Context
+	cancel    context.CancelFunc
+	companyID string
+	opts      EventStreamOptions
+	reconnect RetryPolicy
+	onState   func(EventStreamState)
+
+	// seen and lastSeen are only used by the run goroutine.
+	seen     *recentIDs
+	lastSeen time.Time
+
+	events chan Event
+	errors chan error
+	states chan EventStreamState
+	done   chan struct{}
+
+	mu    sync.Mutex
+	state EventStreamState
+	err   error
+}
+
+// OpenEventStream connects to the event stream of companyID (every company
+// the user can see if empty). It fails if the first connection cannot be
+// made; afterwards the stream reconnects until ctx is done or it is
+// closed.
+func (c *client) OpenEventStream(ctx context.Context, companyID string, opts EventStreamOptions) (*EventStream, error) {
+	ctx = withOperation(ctx, "Console.OpenEventStream", companyID)
+	s := c.newEventStream(ctx, companyID, opts)
+	conn, accessToken, err := c.dialEventStream(s.ctx, companyID)
+	if err != nil {
+		s.cancel()
+		return nil, err
+	}
+	go s.run(conn, accessToken)
+	return s, nil
+}
+
+func (c *client) newEventStream(ctx context.Context, companyID string, opts EventStreamOptions) *EventStream {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 100
+	}
+	reconnect := DefaultReconnectPolicy
+	if opts.Reconnect != nil {
+		reconnect = *opts.Reconnect
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &EventStream{
+		client:    c,
+		ctx:       ctx,
+		cancel:    cancel,
+		companyID: companyID,
+		opts:      opts,
+		reconnect: reconnect,
+		events:    make(chan Event, opts.BufferSize),
+		errors:    make(chan error, 16),
+		states:    make(chan EventStreamState, 16),
+		done:      make(chan struct{}),
+		state:     StreamConnecting,
+		seen:      newRecentIDs(recentEventIDs),
+		lastSeen:  time.Now(),
+	}
+}
+
+// Events returns the channel of events that pass the filter. It is closed
+// when the stream closes.
+func (s *EventStream) Events() <-chan Event {
+	return s.events
+}
+
+// Errors returns the channel of connection, authorization and decoding
+// errors. Errors are dropped if the channel is not drained. It is closed
+// when the stream closes.
+func (s *EventStream) Errors() <-chan error {
+	return s.errors
+}
+
+// States returns the channel of state changes. Changes are dropped if the
+// channel is not drained. It is closed when the stream closes.
+func (s *EventStream) States() <-chan EventStreamState {
+	return s.states
+}
+
+// State returns the current state.
+func (s *EventStream) State() EventStreamState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Err returns the error that closed the stream, or nil if it is open or was
+// closed by Close or its context.
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close disconnects the stream and waits for its goroutine to exit.
+func (s *EventStream) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// run follows the stream until it closes. A nil conn is dialled first.
+func (s *EventStream) run(conn *websocket.Conn, accessToken string) {
+	defer close(s.done)
+	defer close(s.states)
+	defer close(s.errors)
+	defer close(s.events)
+	defer s.setState(StreamClosed)
+	failures := 0
+	reconnected := false
+	for {
+		if conn == nil {
+			var err error
+			conn, accessToken, err = s.client.dialEventStream(s.ctx, s.companyID)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				var apiErr *APIError
+				if errors.As(err, &apiErr) && (errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrBadRequest)) {
+					// The token endpoint rejected the credentials.
+					s.setState(StreamAuthFailed)
+					s.fail(err)
+					return
+				}
+				if errors.Is(err, errEventStreamRejected) {
+					s.client.invalidateToken(accessToken)
+					s.setState(StreamAuthFailed)
+				}
+				failures++
+				if !s.retry(failures, err) {
+					return
+				}
+				continue
+			}
+		}
+		s.setState(StreamConnected)
+		if reconnected && !s.opts.DisableBackfill {
+			s.backfill()
+		}
+		reconnected = true
+		received, err := s.read(conn)
+		conn = nil
+		if s.ctx.Err() != nil {
+			return
+		}
+		if received {
+			failures = 0
+		}
+		failures++
+		if websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+			s.client.invalidateToken(accessToken)
+			s.setState(StreamAuthFailed)
+			err = fmt.Errorf("%w: %v", errEventStreamRejected, err)
+		} else {
+			s.setState(StreamReconnecting)
+		}
+		if !s.retry(failures, err) {
+			return
+		}
+	}
+}
+
+// retry reports err and sleeps before the next attempt. It returns false
+// if the stream should stop.
+func (s *EventStream) retry(failures int, err error) bool {
+	s.report(fmt.Errorf("iland: event stream: %w", err))
+	if s.reconnect.MaxAttempts > 0 && failures >= s.reconnect.MaxAttempts {
+		s.fail(err)
+		return false
+	}
+	delay, _ := s.reconnect.delay(failures, nil)
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+// read passes events from conn to the Events channel until the connection
+// fails or the stream is closed. received reports whether any message
+// arrived.
+func (s *EventStream) read(conn *websocket.Conn) (received bool, err error) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		msg := SocketData{}
+		err = json.Unmarshal(message, &msg)
+		if err != nil {
+			s.report(fmt.Errorf("iland: event stream: decoding message: %w", err))
+			continue
+		}
+		if msg.Type != MessageEvent {
+			if s.opts.OnMessage != nil {
+				s.opts.OnMessage(msg)
+			}
+			continue
+		}
+		event := Event{}
+		err = json.Unmarshal(msg.Data, &event)
+		if err != nil {
+			s.report(fmt.Errorf("iland: event stream: decoding event: %w", err))
+			continue
+		}
+		if !s.deliver(event) {
+			return received, s.ctx.Err()
+		}
+	}
+}
+
+// backfill replays the events emitted since the last one delivered, or since
+// the stream opened, for every company the stream follows, oldest first.
+// Companies are queried concurrently; failures are reported and the live
+// stream resumes regardless.
+func (s *EventStream) backfill() {
+	since := s.lastSeen.Add(-backfillOverlap)
+	companyIDs, err := s.companyIDs()
+	if err != nil {
+		s.report(fmt.Errorf("iland: event stream: backfill: %w", err))
+		return
+	}
+	events, err := fanOut(s.ctx, "EventStream.backfill", companyIDs, s.client.fanOutConcurrency, func(ctx context.Context, companyID string) ([]Event, error) {
+		query := EventQuery{}.Company(companyID).Since(since)
+		if len(s.opts.Filter.Types) > 0 {
+			query = query.Type(s.opts.Filter.Types...)
+		}
+		return Collect(s.client.Event().Search(ctx, query, ListOptions{}))
+	})
+	if err != nil && s.ctx.Err() == nil {
+		s.report(fmt.Errorf("iland: event stream: backfill: %w", err))
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+	for _, event := range events {
+		if !s.deliver(event) {
+			return
+		}
+	}
+}
+
+// companyIDs returns the companies the stream follows: its own, those in
+// the owner filter, or otherwise every company of the user.
+func (s *EventStream) companyIDs() ([]string, error) {
+	if s.companyID != "" {
+		return []string{s.companyID}, nil
+	}
+	if len(s.opts.Filter.OwnerIDs) > 0 {
+		return s.opts.Filter.OwnerIDs, nil
+	}
+	companies, err := s.client.GetCompaniesContext(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	companyIDs := make([]string, len(companies))
+	for i, company := range companies {
+		companyIDs[i] = company.ID
+	}
+	return companyIDs, nil
+}
+
+// deliver sends event to the Events channel unless it is a duplicate or
+// filtered out. It returns false if the stream was closed.
+func (s *EventStream) deliver(event Event) bool {
+	if event.ID != "" && !s.seen.add(event.ID) {
+		return true
+	}
+	if t := time.UnixMilli(int64(event.Timestamp)); event.Timestamp > 0 && t.After(s.lastSeen) {
+		s.lastSeen = t
+	}
+	if !s.opts.Filter.Match(event) {
+		return true
+	}
+	select {
+	case s.events <- event:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func (s *EventStream) setState(state EventStreamState) {
+	s.mu.Lock()
+	if s.state == state {
+		s.mu.Unlock()
+		return
+	}
+	s.state = state
+	onState := s.onState
+	s.mu.Unlock()
+	if onState != nil {
+		onState(state)
+	}
+	select {
+	case s.states <- state:
+	default:
+	}
+}
+
+func (s *EventStream) report(err error) {
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+func (s *EventStream) fail(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+	s.report(err)
+}
+
+// recentIDs is a bounded set of the most recently added IDs.
+type recentIDs struct {
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+func newRecentIDs(size int) *recentIDs {
+	return &recentIDs{ids: make(map[string]struct{}, size), order: make([]string, size)}
+}
+
+// add records id, evicting the oldest if full. It returns false if id was
+// already present.
+func (r *recentIDs) add(id string) bool {
+	if _, ok := r.ids[id]; ok {
+		return false
+	}
+	if old := r.order[r.next]; old != "" {
+		delete(r.ids, old)
+	}
+	r.order[r.next] = id
+	r.next = (r.next + 1) % len(r.order)
+	r.ids[id] = struct{}{}
+	return true
+}
@@ -0,0 +1,145 @@
+package iland
+
+import (
+	"encoding/json"
+)
+
+// Event types, as sent in Event.Type, grouped by the entity type of the
+// events.
+const (
+	// EntityCompany
+	EventUserLogin       = "USER_LOGIN"
+	EventUserLoginFailed = "USER_LOGIN_FAILED"
+	EventUserCreated     = "USER_CREATED"
+	EventUserDeleted     = "USER_DELETED"
+	EventRoleUpdated     = "ROLE_UPDATED"
+
+	// EntityIaasOrganization
+	EventOrgUpdated = "ORG_UPDATED"
+
+	// EntityIaasVdc
+	EventVdcUpdated               = "VDC_UPDATED"
+	EventOrgVdcNetworkCreated     = "ORG_VDC_NETWORK_CREATED"
+	EventOrgVdcNetworkDeleted     = "ORG_VDC_NETWORK_DELETED"
+	EventOrgVdcNetworkUpdated     = "ORG_VDC_NETWORK_UPDATED"
+	EventVdcStorageProfileUpdated = "VDC_STORAGE_PROFILE_UPDATED"
+
+	// EntityIaasEdge
+	EventEdgeFirewallUpdated = "EDGE_FIREWALL_UPDATED"
+	EventEdgeNatUpdated      = "EDGE_NAT_UPDATED"
+	EventEdgeIPsecVPNUpdated = "EDGE_IPSEC_VPN_UPDATED"
+	EventEdgeRedeployed      = "EDGE_REDEPLOYED"
+
+	// EntityIaasVApp
+	EventVAppCreated  = "VAPP_CREATED"
+	EventVAppDeleted  = "VAPP_DELETED"
+	EventVAppRenamed  = "VAPP_RENAMED"
+	EventVAppPowerOn  = "VAPP_POWER_ON"
+	EventVAppPowerOff = "VAPP_POWER_OFF"
+	EventVAppShutdown = "VAPP_SHUTDOWN"
+	EventVAppSuspend  = "VAPP_SUSPEND"
+
+	// EntityIaasVm
+	EventVmCreated            = "VM_CREATED"
+	EventVmDeleted            = "VM_DELETED"
+	EventVmRenamed            = "VM_RENAMED"
+	EventVmDescriptionUpdated = "VM_DESCRIPTION_UPDATED"
+	EventVmPowerOn            = "VM_POWER_ON"
+	EventVmPowerOff           = "VM_POWER_OFF"
+	EventVmSuspend            = "VM_SUSPEND"
+	EventVmReset              = "VM_RESET"
+	EventVmReboot             = "VM_REBOOT"
+	EventVmShutdown           = "VM_SHUTDOWN"
+	EventVmResized            = "VM_RESIZED"
+	EventVmSnapshotCreated    = "VM_SNAPSHOT_CREATED"
+	EventVmSnapshotRestored   = "VM_SNAPSHOT_RESTORED"
+	EventVmSnapshotDeleted    = "VM_SNAPSHOT_DELETED"
+)
+
+// EventTypes lists the known event types of each entity type.
+var EventTypes = map[string][]string{
+	EntityCompany: {
+		EventUserLogin, EventUserLoginFailed, EventUserCreated, EventUserDeleted, EventRoleUpdated,
+	},
+	EntityIaasOrganization: {
+		EventOrgUpdated,
+	},
+	EntityIaasVdc: {
+		EventVdcUpdated, EventOrgVdcNetworkCreated, EventOrgVdcNetworkDeleted, EventOrgVdcNetworkUpdated,
+		EventVdcStorageProfileUpdated,
+	},
+	EntityIaasEdge: {
+		EventEdgeFirewallUpdated, EventEdgeNatUpdated, EventEdgeIPsecVPNUpdated, EventEdgeRedeployed,
+	},
+	EntityIaasVApp: {
+		EventVAppCreated, EventVAppDeleted, EventVAppRenamed, EventVAppPowerOn, EventVAppPowerOff,
+		EventVAppShutdown, EventVAppSuspend,
+	},
+	EntityIaasVm: {
+		EventVmCreated, EventVmDeleted, EventVmRenamed, EventVmDescriptionUpdated, EventVmPowerOn,
+		EventVmPowerOff, EventVmSuspend, EventVmReset, EventVmReboot, EventVmShutdown, EventVmResized,
+		EventVmSnapshotCreated, EventVmSnapshotRestored, EventVmSnapshotDeleted,
+	},
+}
+
+// LoginEventDetails are the details of EventUserLogin and
+// EventUserLoginFailed.
+type LoginEventDetails struct {
+	IPAddress string `json:"ip_address"`
+	UserAgent string `json:"user_agent"`
+	Reason    string `json:"reason"`
+}
+
+// RenameEventDetails are the details of EventVmRenamed and EventVAppRenamed.
+type RenameEventDetails struct {
+	OldName string `json:"old_name"`
+	NewName string `json:"new_name"`
+}
+
+// ResizeEventDetails are the details of EventVmResized.
+type ResizeEventDetails struct {
+	CPUs     int `json:"cpus_number"`
+	MemoryMB int `json:"memory_size"`
+}
+
+// SnapshotEventDetails are the details of the VM snapshot events.
+type SnapshotEventDetails struct {
+	Name    string `json:"name"`
+	Memory  bool   `json:"memory"`
+	Quiesce bool   `json:"quiesce"`
+}
+
+// FirewallEventDetails are the details of EventEdgeFirewallUpdated.
+type FirewallEventDetails struct {
+	Enabled   bool `json:"enabled"`
+	RuleCount int  `json:"rule_count"`
+}
+
+var eventDetails = map[string]func() interface{}{
+	EventUserLogin:           func() interface{} { return &LoginEventDetails{} },
+	EventUserLoginFailed:     func() interface{} { return &LoginEventDetails{} },
+	EventVmRenamed:           func() interface{} { return &RenameEventDetails{} },
+	EventVAppRenamed:         func() interface{} { return &RenameEventDetails{} },
+	EventVmResized:           func() interface{} { return &ResizeEventDetails{} },
+	EventVmSnapshotCreated:   func() interface{} { return &SnapshotEventDetails{} },
+	EventVmSnapshotRestored:  func() interface{} { return &SnapshotEventDetails{} },
+	EventVmSnapshotDeleted:   func() interface{} { return &SnapshotEventDetails{} },
+	EventEdgeFirewallUpdated: func() interface{} { return &FirewallEventDetails{} },
+}
+
+// DecodeDetails decodes the JSON details of event types that have them into
+// a pointer to the matching struct, such as *RenameEventDetails for
+// EventVmRenamed. It returns nil for other types, whose Details are free
+// text, and for events without details.
+func (e Event) DecodeDetails() (interface{}, error) {
+	newDetails, ok := eventDetails[e.Type]
+	if !ok || e.Details == "" {
+		return nil, nil
+	}
+	details := newDetails()
+	err := json.Unmarshal([]byte(e.Details), details)
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
@@ -0,0 +1,65 @@
+package iland_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+func TestTaskCompletionEvents(t *testing.T) {
+	srv := ilandtest.NewServer(ilandtest.WithTaskDuration(10 * time.Millisecond))
+	defer srv.Close()
+	company := srv.AddCompany(iland.Company{})
+	org := srv.AddOrg(iland.Org{CompanyID: company.ID, LocationID: "dal02.ilandcloud.com"})
+	vapp := srv.AddVApp(iland.VApp{VdcID: srv.AddVdc(iland.Vdc{OrgID: org.ID}).ID})
+	vm := srv.AddVirtualMachine(iland.VirtualMachine{VAppID: vapp.ID})
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		entityID string
+		run      func(ctx context.Context) (iland.Task, error)
+		want     string
+	}{
+		{name: "vm power on", entityID: vm.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VirtualMachine().PowerOnContext(ctx, vm.ID) }, want: iland.EventVmPowerOn},
+		{name: "vm power off", entityID: vm.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VirtualMachine().PowerOffContext(ctx, vm.ID) }, want: iland.EventVmPowerOff},
+		{name: "vm shutdown", entityID: vm.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VirtualMachine().ShutdownContext(ctx, vm.ID) }, want: iland.EventVmShutdown},
+		{name: "vapp power on", entityID: vapp.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VApp().PowerOnContext(ctx, vapp.ID) }, want: iland.EventVAppPowerOn},
+		{name: "vapp power off", entityID: vapp.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VApp().PowerOffContext(ctx, vapp.ID) }, want: iland.EventVAppPowerOff},
+		{name: "vapp shutdown", entityID: vapp.ID, run: func(ctx context.Context) (iland.Task, error) { return c.VApp().ShutdownContext(ctx, vapp.ID) }, want: iland.EventVAppShutdown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			task, err := tt.run(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.Task().Wait(ctx, task.ID, iland.WaitOptions{PollInterval: 10 * time.Millisecond}); err != nil {
+				t.Fatal(err)
+			}
+			var got *iland.Event
+			for _, event := range srv.Events() {
+				if event.TaskID == task.ID {
+					got = &event
+				}
+			}
+			if got == nil {
+				t.Fatalf("no event for task %s", task.ID)
+			}
+			if got.Type != tt.want || got.EntityID != tt.entityID {
+				t.Errorf("event %s on %s, want %s on %s", got.Type, got.EntityID, tt.want, tt.entityID)
+			}
+			if !slices.Contains(iland.EventTypes[got.EntityType], got.Type) {
+				t.Errorf("%s is not listed in EventTypes[%s]", got.Type, got.EntityType)
+			}
+		})
+	}
+}
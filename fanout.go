@@ -0,0 +1,67 @@
+package iland
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultFanOutConcurrency = 8
+
+// WithFanOutConcurrency sets how many requests run at once when a call
+// queries every location or company, such as CompanyService.GetOrgs. It
+// defaults to 8.
+func WithFanOutConcurrency(n int) Option {
+	return func(c *client) error {
+		c.fanOutConcurrency = n
+		return nil
+	}
+}
+
+// fanOut calls fetch for every key with at most concurrency calls at once
+// and merges the results in key order. Keys that fail are reported in a
+// *PartialError returned together with the results of the others.
+func fanOut[T any](ctx context.Context, op string, keys []string, concurrency int, fetch func(ctx context.Context, key string) ([]T, error)) ([]T, error) {
+	if concurrency <= 0 {
+		concurrency = defaultFanOutConcurrency
+	}
+	results := make([][]T, len(keys))
+	errs := make([]error, len(keys))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+		// select picks at random when a slot frees up as ctx is cancelled,
+		// so check again before starting the fetch.
+		if errs[i] = ctx.Err(); errs[i] != nil {
+			if acquired {
+				<-sem
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = fetch(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+	items := []T{}
+	failures := map[string]error{}
+	for i, key := range keys {
+		if errs[i] != nil {
+			failures[key] = errs[i]
+			continue
+		}
+		items = append(items, results[i]...)
+	}
+	if len(failures) > 0 {
+		return items, &PartialError{Op: op, Total: len(keys), Errors: failures}
+	}
+	return items, nil
+}
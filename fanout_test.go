@@ -0,0 +1,142 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFanOut(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		concurrency int
+		// fail is the key whose fetch fails with errBoom.
+		fail string
+		// cancel is the key whose fetch cancels the context and fails with
+		// its error.
+		cancel    string
+		want      []string
+		wantErrs  map[string]error
+		wantTotal int
+	}{
+		{name: "all succeed", keys: []string{"a", "b", "c"}, concurrency: 2, want: []string{"a1", "a2", "b1", "b2", "c1", "c2"}},
+		{name: "no keys", keys: []string{}, want: []string{}},
+		{name: "default concurrency", keys: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, want: []string{"a1", "a2", "b1", "b2", "c1", "c2", "d1", "d2", "e1", "e2", "f1", "f2", "g1", "g2", "h1", "h2", "i1", "i2", "j1", "j2"}},
+		{
+			name: "one fails", keys: []string{"a", "b", "c"}, concurrency: 3, fail: "b",
+			want: []string{"a1", "a2", "c1", "c2"}, wantErrs: map[string]error{"b": errBoom}, wantTotal: 3,
+		},
+		{
+			name: "cancelled", keys: []string{"a", "b", "c", "d"}, concurrency: 1, cancel: "b",
+			want:      []string{"a1", "a2"},
+			wantErrs:  map[string]error{"b": context.Canceled, "c": context.Canceled, "d": context.Canceled},
+			wantTotal: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var inFlight, maxInFlight atomic.Int32
+			fetch := func(ctx context.Context, key string) ([]string, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					peak := maxInFlight.Load()
+					if n <= peak || maxInFlight.CompareAndSwap(peak, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				switch key {
+				case tt.fail:
+					return nil, errBoom
+				case tt.cancel:
+					cancel()
+					return nil, ctx.Err()
+				}
+				return []string{key + "1", key + "2"}, nil
+			}
+
+			got, err := fanOut(ctx, "Test.FanOut", tt.keys, tt.concurrency, fetch)
+
+			limit := tt.concurrency
+			if limit <= 0 {
+				limit = defaultFanOutConcurrency
+			}
+			if peak := maxInFlight.Load(); peak > int32(limit) {
+				t.Errorf("%d fetches ran at once, want at most %d", peak, limit)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if tt.wantErrs == nil {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			var partial *PartialError
+			if !errors.As(err, &partial) {
+				t.Fatalf("err = %v, want *PartialError", err)
+			}
+			if partial.Op != "Test.FanOut" || partial.Total != tt.wantTotal {
+				t.Errorf("PartialError op %q total %d, want %q %d", partial.Op, partial.Total, "Test.FanOut", tt.wantTotal)
+			}
+			if len(partial.Errors) != len(tt.wantErrs) {
+				t.Errorf("PartialError.Errors = %v, want %v", partial.Errors, tt.wantErrs)
+			}
+			for key, want := range tt.wantErrs {
+				if !errors.Is(partial.Errors[key], want) {
+					t.Errorf("Errors[%s] = %v, want %v", key, partial.Errors[key], want)
+				}
+				if !errors.Is(err, want) {
+					t.Errorf("errors.Is(err, %v) = false", want)
+				}
+			}
+		})
+	}
+}
+
+func TestJoinPartial(t *testing.T) {
+	first := &PartialError{Op: "a", Total: 2, Errors: map[string]error{"org-1": errBoom}}
+	second := &PartialError{Op: "b", Total: 3, Errors: map[string]error{"vm-1": context.Canceled}}
+	tests := []struct {
+		name      string
+		errs      []error
+		wantTotal int
+		wantKeys  []string
+	}{
+		{name: "none failed", errs: []error{nil, nil}},
+		{name: "other errors are ignored", errs: []error{errBoom}},
+		{name: "one stage", errs: []error{nil, first}, wantTotal: 2, wantKeys: []string{"org-1"}},
+		{name: "merged", errs: []error{first, second}, wantTotal: 5, wantKeys: []string{"org-1", "vm-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := joinPartial("Test.Join", tt.errs...)
+			if tt.wantKeys == nil {
+				if err != nil {
+					t.Fatalf("err = %v, want nil", err)
+				}
+				return
+			}
+			var partial *PartialError
+			if !errors.As(err, &partial) {
+				t.Fatalf("err = %v, want *PartialError", err)
+			}
+			keys := []string{}
+			for key := range partial.Errors {
+				keys = append(keys, key)
+			}
+			slices.Sort(keys)
+			if partial.Op != "Test.Join" || partial.Total != tt.wantTotal || !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("got %s total %d keys %v, want total %d keys %v", partial.Op, partial.Total, keys, tt.wantTotal, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package iland
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of an API served by handler, with a static
+// token that is valid for an hour.
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	token := &Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}
+	opts = append([]Option{WithBaseURL(srv.URL), WithTokenSource(StaticTokenSource(token))}, opts...)
+	c, err := newClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -2,193 +2,141 @@ package iland
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
-	"strings"
 	"time"
 )
 
 func (c *client) Get(endpoint string) (io.ReadCloser, error) {
-	return c.requestJSON(endpoint, http.MethodGet, []byte{})
+	return c.GetContext(context.Background(), endpoint)
 }
 
-func (c *client) Post(endpoint string, body []byte) (io.ReadCloser, error) {
-	return c.requestJSON(endpoint, http.MethodPost, body)
+func (c *client) GetContext(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	return c.requestJSON(ctx, endpoint, http.MethodGet, []byte{})
 }
 
-func (c *client) Put(endpoint string, body []byte) (io.ReadCloser, error) {
-	return c.requestJSON(endpoint, http.MethodPut, body)
+func (c *client) Post(endpoint string, body []byte) (io.ReadCloser, error) {
+	return c.PostContext(context.Background(), endpoint, body)
 }
 
-func (c *client) Delete(endpoint string) (io.ReadCloser, error) {
-	return c.requestJSON(endpoint, http.MethodDelete, []byte{})
+func (c *client) PostContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error) {
+	return c.requestJSON(ctx, endpoint, http.MethodPost, body)
 }
 
-func (c *client) getObject(endpoint string, object interface{}) error {
-	resp, err := c.Get(endpoint)
-	if err != nil {
-		return err
-	}
-	return unmarshalBody(resp, object)
-}
-
-type Token struct {
-	AccessToken  string `json:"access_token"`
-	ExpiresIn    int64  `json:"expires_in"`
-	RefreshToken string `json:"refresh_token"`
+func (c *client) Put(endpoint string, body []byte) (io.ReadCloser, error) {
+	return c.PutContext(context.Background(), endpoint, body)
 }
 
-type TokenRequest struct {
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
-	Username     string `json:"username"`
-	Password     string `json:"password"`
-	GrantType    string `json:"grant_type"`
+func (c *client) PutContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error) {
+	return c.requestJSON(ctx, endpoint, http.MethodPut, body)
 }
 
-type RefreshTokenRequest struct {
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
-	RefreshToken string `json:"refresh_token"`
-	GrantType    string `json:"grant_type"`
+func (c *client) Delete(endpoint string) (io.ReadCloser, error) {
+	return c.DeleteContext(context.Background(), endpoint)
 }
 
-type APIError struct {
-	Error         string `json:"error"`
-	Message       string `json:"message"`
-	DetailMessage string `json:"detail_message"`
+func (c *client) DeleteContext(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	return c.requestJSON(ctx, endpoint, http.MethodDelete, []byte{})
 }
 
-func (c *client) getToken() error {
-	tokenRequest := TokenRequest{c.clientID, c.clientSecret, c.username, c.password, "password"}
-	form := url.Values{}
-	form.Add("client_id", tokenRequest.ClientID)
-	form.Add("client_secret", tokenRequest.ClientSecret)
-	form.Add("username", tokenRequest.Username)
-	form.Add("password", tokenRequest.Password)
-	form.Add("grant_type", tokenRequest.GrantType)
-	resp, err := http.Post(accessURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+func (c *client) getObject(ctx context.Context, endpoint string, object interface{}) error {
+	resp, err := c.GetContext(ctx, endpoint)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
-		return errors.New("Could not retrieve a token.")
-	}
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	var t Token
-	err = json.Unmarshal(body, &t)
-	if err != nil {
-		return err
-	}
-	c.Token = t
-	c.setTokenExpiration()
-	return nil
+	return unmarshalBody(resp, object)
 }
 
-func (c *client) refreshToken() error {
-	tokenRequest := RefreshTokenRequest{c.clientID, c.clientSecret, c.Token.RefreshToken, "refresh_token"}
-	form := url.Values{}
-	form.Add("client_id", tokenRequest.ClientID)
-	form.Add("client_secret", tokenRequest.ClientSecret)
-	form.Add("refresh_token", tokenRequest.RefreshToken)
-	form.Add("grant_type", tokenRequest.GrantType)
-	resp, err := http.Post(refreshURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
-		return errors.New("Could not refresh current token.")
-	}
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	var t Token
-	err = json.Unmarshal(body, &t)
-	if err != nil {
-		return err
+func (c *client) request(ctx context.Context, relPath, verb, acceptHeader string, payload []byte) (io.ReadCloser, error) {
+	policy := c.retryPolicyFor(ctx)
+	for attempt := 1; ; attempt++ {
+		body, err := c.do(ctx, attempt, relPath, verb, acceptHeader, payload)
+		if err == nil {
+			return body, nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.allows(verb) || !isRetryable(err) {
+			return nil, err
+		}
+		delay, ok := policy.delay(attempt, err)
+		if !ok {
+			return nil, err
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryAttempt{
+				Method:  verb,
+				Path:    relPath,
+				Attempt: attempt,
+				Delay:   delay,
+				Err:     err,
+			})
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
 	}
-	c.Token = t
-	c.setTokenExpiration()
-	return nil
 }
 
-func (c *client) setTokenExpiration() {
-	c.tokenExpiration = time.Now().Add(time.Duration(c.Token.ExpiresIn-60) * time.Second)
-}
-
-func (c *client) request(relPath, verb, acceptHeader string, payload []byte) (io.ReadCloser, error) {
-	if c.Token.AccessToken == "" {
-		err := c.getToken()
+func (c *client) do(ctx context.Context, attempt int, relPath, verb, acceptHeader string, payload []byte) (io.ReadCloser, error) {
+	accessToken, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.send(ctx, attempt, accessToken, relPath, verb, acceptHeader, payload)
+	if errors.Is(err, ErrUnauthorized) {
+		c.invalidateToken(accessToken)
+		accessToken, err = c.accessToken(ctx)
 		if err != nil {
 			return nil, err
 		}
+		return c.send(ctx, attempt, accessToken, relPath, verb, acceptHeader, payload)
 	}
-	err := c.RefreshTokenIfNecessary()
+	return body, err
+}
+
+func (c *client) send(ctx context.Context, attempt int, accessToken, relPath, verb, acceptHeader string, payload []byte) (io.ReadCloser, error) {
+	bytesJSON := bytes.NewBuffer(payload)
+	req, err := http.NewRequestWithContext(ctx, verb, c.baseURL+relPath, bytesJSON)
 	if err != nil {
 		return nil, err
 	}
-	bytesJSON := bytes.NewBuffer(payload)
-	req, err := http.NewRequest(verb, fmt.Sprintf("https://%s%s", apiHostname, relPath), bytesJSON)
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.Token.AccessToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Add("Accept", acceptHeader)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 	if verb == http.MethodPut || verb == http.MethodPost {
 		req.Header.Add("Content-Type", "application/json")
 	}
-	resp, err := c.http.Do(req)
+	name, entityID := OperationFromContext(ctx)
+	resp, err := c.handler(&Call{
+		Operation: name,
+		EntityID:  entityID,
+		Attempt:   attempt,
+		Request:   req,
+	})
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode > 204 {
 		defer resp.Body.Close()
-		data, err := ioutil.ReadAll(resp.Body)
-		if err == nil {
-			return nil, errors.New(string(data))
-		}
-		return nil, errors.New(http.StatusText(resp.StatusCode))
+		return nil, newAPIError(resp)
 	}
 	return resp.Body, nil
 }
 
-func (c *client) requestJSON(relPath, verb string, payload []byte) (io.ReadCloser, error) {
-	return c.request(relPath, verb, "application/vnd.ilandcloud.api.v1.0+json", payload)
-}
-
-func (c *client) RefreshTokenIfNecessary() error {
-	emptyToken := Token{}
-	if c == nil || c.Token == emptyToken {
-		err := c.getToken()
-		if err != nil {
-			return fmt.Errorf("Error retrieving iland cloud API token. %s", err.Error())
-		}
-	}
-	if c.isTokenExpired() {
-		err := c.refreshToken()
-		if err != nil {
-			err := c.getToken()
-			if err != nil {
-				return fmt.Errorf("Error refreshing iland cloud API token. %s", err.Error())
-			}
-		}
-	}
-	return nil
+func (c *client) requestJSON(ctx context.Context, relPath, verb string, payload []byte) (io.ReadCloser, error) {
+	return c.request(ctx, relPath, verb, c.mediaType("json"), payload)
 }
 
-func (c *client) isTokenExpired() bool {
-	if time.Now().After(c.tokenExpiration) {
-		return true
-	}
-	return false
+func (c *client) mediaType(format string) string {
+	return fmt.Sprintf("application/vnd.ilandcloud.api.v%s+%s", c.apiVersion, format)
 }
 
 func unmarshalBody(body io.ReadCloser, object interface{}) error {
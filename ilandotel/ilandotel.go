@@ -0,0 +1,121 @@
+// Package ilandotel provides OpenTelemetry tracing and metrics middleware
+// for the iland cloud SDK.
+package ilandotel
+
+import (
+	"net/http"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ilanddev/go-sdk/ilandotel"
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The global provider is used
+// by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider. The global provider is used by
+// default.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator used to inject trace context into
+// request headers. The global propagator is used by default.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Middleware returns an iland.Middleware that records a client span for
+// every call, named after the SDK operation, and the metrics
+// iland.client.requests (counter) and iland.client.duration (histogram, in
+// seconds).
+func Middleware(opts ...Option) (iland.Middleware, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+	requests, err := meter.Int64Counter("iland.client.requests",
+		metric.WithDescription("Number of iland API requests."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.Float64Histogram("iland.client.duration",
+		metric.WithDescription("Duration of iland API requests."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	return func(next iland.Handler) iland.Handler {
+		return func(call *iland.Call) (*http.Response, error) {
+			name := call.Operation
+			if name == "" {
+				name = call.Request.Method
+			}
+			attrs := []attribute.KeyValue{
+				attribute.String("iland.operation", call.Operation),
+				attribute.String("http.request.method", call.Request.Method),
+				attribute.String("server.address", call.Request.URL.Host),
+			}
+			spanAttrs := append([]attribute.KeyValue{
+				attribute.String("iland.entity_id", call.EntityID),
+				attribute.Int("iland.attempt", call.Attempt),
+				attribute.String("url.path", call.Request.URL.Path),
+			}, attrs...)
+			ctx, span := tracer.Start(call.Request.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...))
+			defer span.End()
+			call.Request = call.Request.WithContext(ctx)
+			cfg.propagator.Inject(ctx, propagation.HeaderCarrier(call.Request.Header))
+
+			start := time.Now()
+			resp, err := next(call)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				attrs = append(attrs, attribute.String("error.type", "transport"))
+			} else {
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+				attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+				if resp.StatusCode >= 400 {
+					span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+				}
+			}
+			requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+			return resp, err
+		}
+	}, nil
+}
@@ -0,0 +1,109 @@
+package ilandotel_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandotel"
+	"github.com/ilanddev/go-sdk/ilandtest"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddlewareSpans(t *testing.T) {
+	srv := ilandtest.NewServer()
+	defer srv.Close()
+	company := srv.AddCompany(iland.Company{})
+	org := srv.AddOrg(iland.Org{CompanyID: company.ID, LocationID: "dal02.ilandcloud.com"})
+	vapp := srv.AddVApp(iland.VApp{VdcID: srv.AddVdc(iland.Vdc{OrgID: org.ID}).ID})
+	vm := srv.AddVirtualMachine(iland.VirtualMachine{VAppID: vapp.ID})
+
+	tests := []struct {
+		name      string
+		fault     *ilandtest.Fault
+		wantCode  codes.Code
+		wantDesc  string
+		wantAttrs map[string]string
+		// wantError means the error is recorded as a span event.
+		wantError bool
+	}{
+		{
+			name:      "success",
+			wantCode:  codes.Unset,
+			wantAttrs: map[string]string{"http.response.status_code": "200"},
+		},
+		{
+			name:      "api error",
+			fault:     &ilandtest.Fault{Path: "/v1/vms/" + vm.ID, Status: http.StatusInternalServerError},
+			wantCode:  codes.Error,
+			wantDesc:  "Internal Server Error",
+			wantAttrs: map[string]string{"http.response.status_code": "500"},
+		},
+		{
+			name:      "transport error",
+			fault:     &ilandtest.Fault{Path: "/v1/vms/" + vm.ID, Disconnect: true},
+			wantCode:  codes.Error,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fault != nil {
+				remove := srv.InjectFault(*tt.fault)
+				defer remove()
+			}
+			recorder := tracetest.NewSpanRecorder()
+			middleware, err := ilandotel.Middleware(ilandotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := srv.NewClient(iland.WithMiddleware(middleware), iland.WithRetryPolicy(iland.NoRetryPolicy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c.VirtualMachine().GetContext(context.Background(), vm.ID)
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != "VirtualMachine.Get" || span.SpanKind() != trace.SpanKindClient {
+				t.Errorf("span %q of kind %s, want VirtualMachine.Get of kind client", span.Name(), span.SpanKind())
+			}
+			attrs := map[string]string{}
+			for _, kv := range span.Attributes() {
+				attrs[string(kv.Key)] = kv.Value.Emit()
+			}
+			want := map[string]string{
+				"iland.operation":     "VirtualMachine.Get",
+				"iland.entity_id":     vm.ID,
+				"iland.attempt":       "1",
+				"http.request.method": http.MethodGet,
+				"url.path":            "/v1/vms/" + vm.ID,
+			}
+			for key, value := range tt.wantAttrs {
+				want[key] = value
+			}
+			for key, value := range want {
+				if attrs[key] != value {
+					t.Errorf("attribute %s = %q, want %q", key, attrs[key], value)
+				}
+			}
+			if _, ok := attrs["http.response.status_code"]; ok && tt.wantError {
+				t.Errorf("failed call has status code %s", attrs["http.response.status_code"])
+			}
+			if status := span.Status(); status.Code != tt.wantCode || (tt.wantDesc != "" && status.Description != tt.wantDesc) {
+				t.Errorf("status = %v %q, want %v %q", status.Code, status.Description, tt.wantCode, tt.wantDesc)
+			}
+			if recorded := len(span.Events()) > 0; recorded != tt.wantError {
+				t.Errorf("error recorded = %t, want %t", recorded, tt.wantError)
+			}
+		})
+	}
+}
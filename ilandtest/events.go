@@ -0,0 +1,231 @@
+package ilandtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	iland "github.com/ilanddev/go-sdk"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// subscriber is an authenticated event stream connection.
+type subscriber struct {
+	conn      *websocket.Conn
+	companyID string
+	events    chan []byte
+	done      chan struct{}
+}
+
+// PublishEvent sends event to every connected event stream whose company
+// matches event.OwnerID. Events with no owner go to every stream.
+func (s *Server) PublishEvent(event iland.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if event.ID == "" {
+		event.ID = s.newID("event")
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = int(time.Now().UnixMilli())
+	}
+	s.publish(event)
+}
+
+// PublishMissedEvent records event in the history without sending it to any
+// event stream, as if it was emitted while the streams were disconnected.
+func (s *Server) PublishMissedEvent(event iland.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if event.ID == "" {
+		event.ID = s.newID("event")
+	}
+	if event.Timestamp == 0 {
+		event.Timestamp = int(time.Now().UnixMilli())
+	}
+	s.events = append(s.events, event)
+}
+
+// Events returns every event published so far, oldest first.
+func (s *Server) Events() []iland.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]iland.Event{}, s.events...)
+}
+
+// PublishMessage sends a socket message other than an event, such as those
+// passed to EventStreamOptions.OnMessage, to every connected event stream.
+func (s *Server) PublishMessage(msg iland.SocketData) {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.send(message, "")
+}
+
+// DropEventStreams closes every event stream connection, as happens when the
+// API restarts. Clients are expected to reconnect.
+func (s *Server) DropEventStreams() {
+	s.mu.Lock()
+	subscribers := s.subscribers
+	s.subscribers = map[*subscriber]struct{}{}
+	s.mu.Unlock()
+	for sub := range subscribers {
+		sub.conn.Close()
+	}
+}
+
+// EventStreams returns the number of connected, authenticated event streams.
+func (s *Server) EventStreams() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+// publish records event in the history and queues it for matching
+// subscribers, dropping it for any whose buffer is full. It must be called
+// with s.mu held.
+func (s *Server) publish(event iland.Event) {
+	s.events = append(s.events, event)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	message, err := json.Marshal(iland.SocketData{Type: iland.MessageEvent, Data: data})
+	if err != nil {
+		return
+	}
+	s.send(message, event.OwnerID)
+}
+
+// send queues message for the subscribers of companyID, or every subscriber
+// if it is empty. It must be called with s.mu held.
+func (s *Server) send(message []byte, companyID string) {
+	for sub := range s.subscribers {
+		if sub.companyID != "" && companyID != "" && sub.companyID != companyID {
+			continue
+		}
+		select {
+		case sub.events <- message:
+		default:
+		}
+	}
+}
+
+// handleEventStream speaks the event websocket protocol: the server asks for
+// AUTHORIZATION, the client answers with "Bearer <token>", optionally
+// prefixed by "companyId=<id>,", and events follow as EVENT messages.
+func (s *Server) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	err = conn.WriteMessage(websocket.TextMessage, []byte("AUTHORIZATION"))
+	if err != nil {
+		return
+	}
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	auth := string(message)
+	companyID := ""
+	if strings.HasPrefix(auth, "companyId=") {
+		companyID, auth, _ = strings.Cut(strings.TrimPrefix(auth, "companyId="), ",")
+	}
+	if !strings.HasPrefix(auth, "Bearer ") || !s.validToken(strings.TrimPrefix(auth, "Bearer ")) {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unauthorized"))
+		return
+	}
+	sub := &subscriber{
+		conn:      conn,
+		companyID: companyID,
+		events:    make(chan []byte, 100),
+		done:      make(chan struct{}),
+	}
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, sub)
+		s.mu.Unlock()
+	}()
+	go func() {
+		defer close(sub.done)
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case message := <-sub.events:
+			err = conn.WriteMessage(websocket.TextMessage, message)
+			if err != nil {
+				return
+			}
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+// handleEvents answers historical event queries for a company. Events with
+// no owner belong to every company.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	companyID := r.PathValue("id")
+	query := r.URL.Query()
+	entityID := query.Get("entityUuid")
+	entityType := query.Get("entityType")
+	types := query["type"]
+	after, _ := strconv.Atoi(query.Get("timestampAfter"))
+	before, _ := strconv.Atoi(query.Get("timestampBefore"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+	s.mu.Lock()
+	_, ok := s.companies.get(companyID)
+	events := []iland.Event{}
+	for _, event := range s.events {
+		if event.OwnerID != "" && event.OwnerID != companyID {
+			continue
+		}
+		if entityID != "" && event.EntityID != entityID {
+			continue
+		}
+		if entityType != "" && event.EntityType != entityType {
+			continue
+		}
+		if len(types) > 0 && !contains(types, event.Type) {
+			continue
+		}
+		if after != 0 && event.Timestamp < after {
+			continue
+		}
+		if before != 0 && event.Timestamp >= before {
+			continue
+		}
+		events = append(events, event)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("company %s not found", companyID))
+		return
+	}
+	if query.Get("sort") == "-timestamp" {
+		slices.Reverse(events)
+	}
+	writeList(w, pageOf(events, page, pageSize))
+}
@@ -0,0 +1,124 @@
+package ilandtest
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+)
+
+// Fault describes a failure injected into matching requests.
+type Fault struct {
+	// Method restricts the fault to one HTTP method. Empty matches any.
+	Method string
+	// Path is a path.Match pattern, e.g. "/v1/vms/*/actions/*". Empty
+	// matches any path, including the token endpoint.
+	Path string
+	// Times limits how many requests the fault applies to. Zero applies it
+	// until it is removed.
+	Times int
+
+	// Delay is slept before the request is handled.
+	Delay time.Duration
+	// Disconnect closes the connection without writing a response.
+	Disconnect bool
+	// Status, when non-zero, is returned instead of handling the request,
+	// with an error body made of Code and Message.
+	Status  int
+	Code    string
+	Message string
+	// RetryAfter is sent as the Retry-After header with Status.
+	RetryAfter time.Duration
+	// TaskError makes a task started by the request end in error with this
+	// message.
+	TaskError string
+}
+
+type fault struct {
+	Fault
+	applied int
+}
+
+// InjectFault adds f to the server and returns a function that removes it.
+// When several faults match a request, the earliest one added applies.
+func (s *Server) InjectFault(f Fault) (remove func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	injected := &fault{Fault: f}
+	s.faults = append(s.faults, injected)
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.removeFault(injected)
+	}
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// matchFault returns the fault that applies to r, counting it against its
+// Times limit. It must be called with s.mu held.
+func (s *Server) matchFault(r *http.Request) *fault {
+	for _, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" {
+			if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+				continue
+			}
+		}
+		f.applied++
+		if f.Times > 0 && f.applied >= f.Times {
+			s.removeFault(f)
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) removeFault(f *fault) {
+	for i, existing := range s.faults {
+		if existing == f {
+			s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			return
+		}
+	}
+}
+
+// apply carries out the fault and reports whether it wrote the response.
+func (f *fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if f.Disconnect {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			conn, _, err := hijacker.Hijack()
+			if err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+	if f.Status == 0 {
+		return false
+	}
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter/time.Second)))
+	}
+	code := f.Code
+	if code == "" {
+		code = http.StatusText(f.Status)
+	}
+	writeError(w, f.Status, code, f.Message)
+	return true
+}
@@ -0,0 +1,222 @@
+package ilandtest
+
+import (
+	iland "github.com/ilanddev/go-sdk"
+)
+
+// AddCompany stores company, assigning an ID if it has none, and returns it.
+func (s *Server) AddCompany(company iland.Company) iland.Company {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if company.ID == "" {
+		company.ID = s.newID("company")
+	}
+	s.companies.put(company.ID, company)
+	return company
+}
+
+// AddOrg stores org, assigning an ID if it has none, and returns it.
+func (s *Server) AddOrg(org iland.Org) iland.Org {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if org.ID == "" {
+		org.ID = s.newID("org")
+	}
+	s.orgs.put(org.ID, org)
+	return org
+}
+
+// AddVdc stores vdc, assigning an ID if it has none and filling in the
+// company and location from its org.
+func (s *Server) AddVdc(vdc iland.Vdc) iland.Vdc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if vdc.ID == "" {
+		vdc.ID = s.newID("vdc")
+	}
+	if org, ok := s.orgs.get(vdc.OrgID); ok {
+		vdc.CompanyID = fill(vdc.CompanyID, org.CompanyID)
+		vdc.LocationID = fill(vdc.LocationID, org.LocationID)
+	}
+	s.vdcs.put(vdc.ID, vdc)
+	return vdc
+}
+
+// AddVApp stores vapp, assigning an ID if it has none and filling in its
+// ancestors from its vdc.
+func (s *Server) AddVApp(vapp iland.VApp) iland.VApp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if vapp.ID == "" {
+		vapp.ID = s.newID("vapp")
+	}
+	if vdc, ok := s.vdcs.get(vapp.VdcID); ok {
+		vapp.OrgID = fill(vapp.OrgID, vdc.OrgID)
+		vapp.CompanyID = fill(vapp.CompanyID, vdc.CompanyID)
+		vapp.LocationID = fill(vapp.LocationID, vdc.LocationID)
+	}
+	s.vapps.put(vapp.ID, vapp)
+	return vapp
+}
+
+// AddVirtualMachine stores vm, assigning an ID if it has none and filling
+// in its ancestors from its vApp.
+func (s *Server) AddVirtualMachine(vm iland.VirtualMachine) iland.VirtualMachine {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if vm.ID == "" {
+		vm.ID = s.newID("vm")
+	}
+	if vapp, ok := s.vapps.get(vm.VAppID); ok {
+		vm.VdcID = fill(vm.VdcID, vapp.VdcID)
+		vm.OrgID = fill(vm.OrgID, vapp.OrgID)
+		vm.CompanyID = fill(vm.CompanyID, vapp.CompanyID)
+		vm.LocationID = fill(vm.LocationID, vapp.LocationID)
+	}
+	s.vms.put(vm.ID, vm)
+	return vm
+}
+
+// AddEdge stores edge, assigning an ID if it has none and filling in its
+// ancestors from its vdc.
+func (s *Server) AddEdge(edge iland.Edge) iland.Edge {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if edge.ID == "" {
+		edge.ID = s.newID("edge")
+	}
+	if vdc, ok := s.vdcs.get(edge.VdcID); ok {
+		edge.OrgID = fill(edge.OrgID, vdc.OrgID)
+		edge.CompanyID = fill(edge.CompanyID, vdc.CompanyID)
+		edge.LocationID = fill(edge.LocationID, vdc.LocationID)
+	}
+	s.edges.put(edge.ID, edge)
+	return edge
+}
+
+// AddNetwork stores network, assigning an ID if it has none and filling in
+// its ancestors from its vdc.
+func (s *Server) AddNetwork(network iland.OrgVdcNetwork) iland.OrgVdcNetwork {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if network.ID == "" {
+		network.ID = s.newID("network")
+	}
+	if vdc, ok := s.vdcs.get(network.VdcID); ok {
+		network.OrgID = fill(network.OrgID, vdc.OrgID)
+		network.CompanyID = fill(network.CompanyID, vdc.CompanyID)
+		network.LocationID = fill(network.LocationID, vdc.LocationID)
+	}
+	s.networks.put(network.ID, network)
+	return network
+}
+
+// RemoveNetwork deletes the network with id. It publishes no event.
+func (s *Server) RemoveNetwork(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.networks.delete(id)
+}
+
+// AddCatalog stores catalog, assigning an ID if it has none and filling in
+// its location from its org.
+func (s *Server) AddCatalog(catalog iland.Catalog) iland.Catalog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if catalog.ID == "" {
+		catalog.ID = s.newID("catalog")
+	}
+	if org, ok := s.orgs.get(catalog.OrgID); ok {
+		catalog.LocationID = fill(catalog.LocationID, org.LocationID)
+	}
+	s.catalogs.put(catalog.ID, catalog)
+	return catalog
+}
+
+// AddVAppTemplate stores template, assigning an ID if it has none and
+// filling in its org and location from its catalog.
+func (s *Server) AddVAppTemplate(template iland.VAppTemplate) iland.VAppTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if template.ID == "" {
+		template.ID = s.newID("vapp-template")
+	}
+	if catalog, ok := s.catalogs.get(template.CatalogID); ok {
+		template.OrgID = fill(template.OrgID, catalog.OrgID)
+		template.LocationID = fill(template.LocationID, catalog.LocationID)
+	}
+	s.templates.put(template.ID, template)
+	return template
+}
+
+// SetLocations replaces the location catalogue, which defaults to
+// iland.DefaultLocations.
+func (s *Server) SetLocations(locations []iland.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locations = append([]iland.Location{}, locations...)
+}
+
+// SetBilling sets the bill returned for an org, vdc, vApp or VM.
+func (s *Server) SetBilling(entityID string, billing iland.Billing) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	billing.EntityID = entityID
+	s.billing[entityID] = billing
+}
+
+// SetMetadata sets the metadata returned for a vApp or VM.
+func (s *Server) SetMetadata(entityID string, metadata []iland.Metadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metadata[entityID] = append([]iland.Metadata{}, metadata...)
+}
+
+// SetDisks sets the virtual disks returned for a VM.
+func (s *Server) SetDisks(vmID string, disks []iland.Disk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disks[vmID] = append([]iland.Disk{}, disks...)
+}
+
+// SetNics sets the network cards returned for a VM.
+func (s *Server) SetNics(vmID string, nics []iland.Nic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nics[vmID] = append([]iland.Nic{}, nics...)
+}
+
+// SetFirewall sets the firewall returned for an edge.
+func (s *Server) SetFirewall(edgeID string, firewall iland.EdgeFirewall) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.firewalls[edgeID] = firewall
+}
+
+// SetNAT sets the NAT configuration returned for an edge.
+func (s *Server) SetNAT(edgeID string, nat iland.EdgeNAT) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nats[edgeID] = nat
+}
+
+// VirtualMachine returns the current state of a VM.
+func (s *Server) VirtualMachine(id string) (iland.VirtualMachine, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.vms.get(id)
+}
+
+// VApp returns the current state of a vApp.
+func (s *Server) VApp(id string) (iland.VApp, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.vapps.get(id)
+}
+
+func fill(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
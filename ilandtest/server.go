@@ -0,0 +1,601 @@
+// Package ilandtest provides an in-memory fake of the iland cloud API for
+// testing code built on the SDK without network access.
+//
+// The fake serves the OAuth token endpoint, the REST endpoints for
+// locations, companies, orgs, vdcs, vApps, VMs and their disks and NICs,
+// edges and their firewall and NAT, networks, tasks, billing and metadata,
+// and the event websocket. Actions return tasks that progress over time and
+// apply their effect when they complete, and faults can be injected into
+// any request.
+//
+//	srv := ilandtest.NewServer()
+//	defer srv.Close()
+//	srv.AddVirtualMachine(iland.VirtualMachine{ID: "vm-1", Status: "POWERED_OFF"})
+//	client, err := srv.NewClient()
+package ilandtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+)
+
+const (
+	DefaultUsername     = "test-user"
+	DefaultPassword     = "test-password"
+	DefaultClientID     = "test-client"
+	DefaultClientSecret = "test-secret"
+)
+
+// Server is a stateful fake iland cloud API. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the REST API, e.g. http://127.0.0.1:1234.
+	URL string
+
+	srv *httptest.Server
+
+	username     string
+	password     string
+	clientID     string
+	clientSecret string
+	tokenTTL     time.Duration
+	taskDuration time.Duration
+
+	mu           sync.Mutex
+	nextID       int
+	accessTokens map[string]time.Time
+	refreshToken map[string]bool
+	companies    *table[iland.Company]
+	orgs         *table[iland.Org]
+	vdcs         *table[iland.Vdc]
+	vapps        *table[iland.VApp]
+	vms          *table[iland.VirtualMachine]
+	edges        *table[iland.Edge]
+	networks     *table[iland.OrgVdcNetwork]
+	catalogs     *table[iland.Catalog]
+	templates    *table[iland.VAppTemplate]
+	locations    []iland.Location
+	billing      map[string]iland.Billing
+	metadata     map[string][]iland.Metadata
+	disks        map[string][]iland.Disk
+	nics         map[string][]iland.Nic
+	firewalls    map[string]iland.EdgeFirewall
+	nats         map[string]iland.EdgeNAT
+	tasks        *table[*task]
+	faults       []*fault
+	subscribers  map[*subscriber]struct{}
+	events       []iland.Event
+	requests     []Request
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCredentials sets the username, password, client ID and client secret
+// accepted by the token endpoint.
+func WithCredentials(username, password, clientID, clientSecret string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+		s.clientID = clientID
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithTokenTTL sets the lifetime of issued access tokens. It defaults to
+// one hour.
+func WithTokenTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.tokenTTL = ttl
+	}
+}
+
+// WithTaskDuration sets how long tasks take to complete. It defaults to
+// 100ms; zero completes tasks as soon as they are created.
+func WithTaskDuration(d time.Duration) Option {
+	return func(s *Server) {
+		s.taskDuration = d
+	}
+}
+
+// NewServer starts a fake server. Callers should Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		username:     DefaultUsername,
+		password:     DefaultPassword,
+		clientID:     DefaultClientID,
+		clientSecret: DefaultClientSecret,
+		tokenTTL:     time.Hour,
+		taskDuration: 100 * time.Millisecond,
+		accessTokens: map[string]time.Time{},
+		refreshToken: map[string]bool{},
+		companies:    newTable[iland.Company](),
+		orgs:         newTable[iland.Org](),
+		vdcs:         newTable[iland.Vdc](),
+		vapps:        newTable[iland.VApp](),
+		vms:          newTable[iland.VirtualMachine](),
+		edges:        newTable[iland.Edge](),
+		networks:     newTable[iland.OrgVdcNetwork](),
+		catalogs:     newTable[iland.Catalog](),
+		templates:    newTable[iland.VAppTemplate](),
+		locations:    append([]iland.Location{}, iland.DefaultLocations...),
+		billing:      map[string]iland.Billing{},
+		metadata:     map[string][]iland.Metadata{},
+		disks:        map[string][]iland.Disk{},
+		nics:         map[string][]iland.Nic{},
+		firewalls:    map[string]iland.EdgeFirewall{},
+		nats:         map[string]iland.EdgeNAT{},
+		tasks:        newTable[*task](),
+		subscribers:  map[*subscriber]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.srv = httptest.NewServer(s.routes())
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down, disconnecting event stream clients.
+func (s *Server) Close() {
+	s.DropEventStreams()
+	s.srv.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tasks.list(nil) {
+		if t.timer != nil {
+			t.timer.Stop()
+		}
+	}
+}
+
+// AuthURL returns the URL of the token endpoint.
+func (s *Server) AuthURL() string {
+	return s.URL + "/auth/token"
+}
+
+// EventStreamURL returns the URL of the event websocket.
+func (s *Server) EventStreamURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + "/v1/event-websocket"
+}
+
+// ClientOptions returns the options that point a client at the server.
+func (s *Server) ClientOptions() []iland.Option {
+	return []iland.Option{
+		iland.WithBaseURL(s.URL),
+		iland.WithAuthURL(s.AuthURL()),
+		iland.WithEventStreamURL(s.EventStreamURL()),
+		iland.WithHTTPClient(s.srv.Client()),
+	}
+}
+
+// NewClient returns a client authenticated with the server's credentials.
+// Additional options are applied after ClientOptions.
+func (s *Server) NewClient(opts ...iland.Option) (iland.ConsoleService, error) {
+	return iland.NewClient(s.username, s.password, s.clientID, s.clientSecret, append(s.ClientOptions(), opts...)...)
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ExpireTokens invalidates every access token issued so far, so the next
+// request made with one fails with 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessTokens = map[string]time.Time{}
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/token", s.handleToken)
+	mux.HandleFunc("GET /v1/event-websocket", s.handleEventStream)
+
+	mux.HandleFunc("GET /v1/locations", s.handleLocations)
+
+	mux.HandleFunc("GET /v1/users/{user}", s.handleUser)
+	mux.HandleFunc("GET /v1/users/{user}/companies", s.handleUserCompanies)
+	mux.HandleFunc("GET /v1/users/{user}/orgs", s.handleUserOrgs)
+	mux.HandleFunc("GET /v1/users/{user}/inventory", s.handleInventory)
+
+	mux.HandleFunc("GET /v1/companies/{id}", getEntity(s, s.companies))
+	mux.HandleFunc("GET /v1/companies/{id}/events", s.handleEvents)
+	mux.HandleFunc("GET /v1/companies/{id}/location/{location}/orgs", s.handleLocationOrgs)
+
+	mux.HandleFunc("GET /v1/orgs/{id}", getEntity(s, s.orgs))
+	mux.HandleFunc("GET /v1/orgs/{id}/vdcs", listChildren(s, s.orgs, s.vdcs, func(v iland.Vdc) string { return v.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/vapps", listChildren(s, s.orgs, s.vapps, func(v iland.VApp) string { return v.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/vms", listChildren(s, s.orgs, s.vms, func(v iland.VirtualMachine) string { return v.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/edges", listChildren(s, s.orgs, s.edges, func(e iland.Edge) string { return e.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/org-vdc-networks", listChildren(s, s.orgs, s.networks, func(n iland.OrgVdcNetwork) string { return n.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/catalogs", listChildren(s, s.orgs, s.catalogs, func(c iland.Catalog) string { return c.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/vapp-templates", listChildren(s, s.orgs, s.templates, func(t iland.VAppTemplate) string { return t.OrgID }))
+	mux.HandleFunc("GET /v1/orgs/{id}/billing", getBilling(s, s.orgs))
+
+	mux.HandleFunc("GET /v1/vdcs/{id}", getEntity(s, s.vdcs))
+	mux.HandleFunc("GET /v1/vdcs/{id}/vapps", listChildren(s, s.vdcs, s.vapps, func(v iland.VApp) string { return v.VdcID }))
+	mux.HandleFunc("GET /v1/vdcs/{id}/vms", listChildren(s, s.vdcs, s.vms, func(v iland.VirtualMachine) string { return v.VdcID }))
+	mux.HandleFunc("GET /v1/vdcs/{id}/edges", listChildren(s, s.vdcs, s.edges, func(e iland.Edge) string { return e.VdcID }))
+	mux.HandleFunc("GET /v1/vdcs/{id}/org-vdc-networks", listChildren(s, s.vdcs, s.networks, func(n iland.OrgVdcNetwork) string { return n.VdcID }))
+	mux.HandleFunc("GET /v1/vdcs/{id}/billing", getBilling(s, s.vdcs))
+
+	mux.HandleFunc("GET /v1/vapps/{id}", getEntity(s, s.vapps))
+	mux.HandleFunc("GET /v1/vapps/{id}/vms", listChildren(s, s.vapps, s.vms, func(v iland.VirtualMachine) string { return v.VAppID }))
+	mux.HandleFunc("GET /v1/vapps/{id}/billing", getBilling(s, s.vapps))
+	mux.HandleFunc("GET /v1/vapps/{id}/metadata", getList(s, s.vapps, s.metadata))
+	mux.HandleFunc("POST /v1/vapps/{id}/actions/{action}", s.handleVAppAction)
+	mux.HandleFunc("DELETE /v1/vapps/{id}", s.handleVAppDelete)
+
+	mux.HandleFunc("GET /v1/vms/{id}", getEntity(s, s.vms))
+	mux.HandleFunc("GET /v1/vms/{id}/billing", getBilling(s, s.vms))
+	mux.HandleFunc("GET /v1/vms/{id}/metadata", getList(s, s.vms, s.metadata))
+	mux.HandleFunc("GET /v1/vms/{id}/virtual-disks", getList(s, s.vms, s.disks))
+	mux.HandleFunc("GET /v1/vms/{id}/vnics", getList(s, s.vms, s.nics))
+	mux.HandleFunc("POST /v1/vms/{id}/actions/{action}", s.handleVMAction)
+	mux.HandleFunc("DELETE /v1/vms/{id}", s.handleVMDelete)
+
+	mux.HandleFunc("GET /v1/catalogs/{id}", getEntity(s, s.catalogs))
+	mux.HandleFunc("GET /v1/catalogs/{id}/vapp-templates", listChildren(s, s.catalogs, s.templates, func(t iland.VAppTemplate) string { return t.CatalogID }))
+	mux.HandleFunc("GET /v1/vapp-templates/{id}", getEntity(s, s.templates))
+
+	mux.HandleFunc("GET /v1/edges/{id}", getEntity(s, s.edges))
+	mux.HandleFunc("GET /v1/edge-gateways/{id}/firewall", getDetail(s, s.edges, s.firewalls))
+	mux.HandleFunc("GET /v1/edge-gateways/{id}/nat", getDetail(s, s.edges, s.nats))
+	mux.HandleFunc("GET /v1/org-vdc-networks/{id}", getEntity(s, s.networks))
+
+	mux.HandleFunc("GET /v1/tasks/{id}", s.handleTask)
+	mux.HandleFunc("GET /v1/tasks", s.handleTasks)
+	mux.HandleFunc("POST /v1/tasks/{id}/actions/cancel", s.handleTaskCancel)
+	mux.HandleFunc("POST /v1/tasks/{id}/actions/respond", s.handleTaskRespond)
+	return s.middleware(mux)
+}
+
+// middleware records requests, applies injected faults and checks the
+// bearer token on API requests.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery})
+		f := s.matchFault(r)
+		s.mu.Unlock()
+		if f != nil && f.apply(w, r) {
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v1/") && r.URL.Path != "/v1/event-websocket" {
+			auth := r.Header.Get("Authorization")
+			if !strings.HasPrefix(auth, "Bearer ") || !s.validToken(strings.TrimPrefix(auth, "Bearer ")) {
+				writeError(w, http.StatusUnauthorized, "unauthorized", "invalid or expired access token")
+				return
+			}
+		}
+		if f != nil && f.TaskError != "" {
+			r = r.WithContext(withTaskFault(r.Context(), f.TaskError))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) validToken(accessToken string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry, ok := s.accessTokens[accessToken]
+	return ok && time.Now().Before(expiry)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if r.PostForm.Get("client_id") != s.clientID || r.PostForm.Get("client_secret") != s.clientSecret {
+		writeError(w, http.StatusUnauthorized, "invalid_client", "invalid client credentials")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.PostForm.Get("grant_type") {
+	case "password":
+		if r.PostForm.Get("username") != s.username || r.PostForm.Get("password") != s.password {
+			writeError(w, http.StatusUnauthorized, "invalid_grant", "invalid user credentials")
+			return
+		}
+	case "refresh_token":
+		refreshToken := r.PostForm.Get("refresh_token")
+		if !s.refreshToken[refreshToken] {
+			writeError(w, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+			return
+		}
+		delete(s.refreshToken, refreshToken)
+	case "client_credentials":
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "unsupported grant type")
+		return
+	}
+	token := iland.Token{
+		AccessToken:  s.newID("access"),
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokenTTL / time.Second),
+		RefreshToken: s.newID("refresh"),
+	}
+	s.accessTokens[token.AccessToken] = time.Now().Add(s.tokenTTL)
+	s.refreshToken[token.RefreshToken] = true
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("user")
+	if name != s.username {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("user %s not found", name))
+		return
+	}
+	writeJSON(w, http.StatusOK, iland.User{Name: name, FullName: name})
+}
+
+func (s *Server) handleUserCompanies(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	companies := s.companies.list(nil)
+	s.mu.Unlock()
+	writeList(w, companies)
+}
+
+func (s *Server) handleUserOrgs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	orgs := s.orgs.list(nil)
+	s.mu.Unlock()
+	writeList(w, orgs)
+}
+
+func (s *Server) handleLocations(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	locations := append([]iland.Location{}, s.locations...)
+	s.mu.Unlock()
+	writeList(w, locations)
+}
+
+func (s *Server) handleLocationOrgs(w http.ResponseWriter, r *http.Request) {
+	companyID, locationID := r.PathValue("id"), r.PathValue("location")
+	s.mu.Lock()
+	orgs := s.orgs.list(func(o iland.Org) bool {
+		return o.CompanyID == companyID && o.LocationID == locationID
+	})
+	s.mu.Unlock()
+	writeList(w, orgs)
+}
+
+func (s *Server) handleInventory(w http.ResponseWriter, r *http.Request) {
+	companyID := r.URL.Query().Get("company")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	company, ok := s.companies.get(companyID)
+	inventory := []iland.CompanyInventory{}
+	if ok {
+		entities := iland.Inventory{
+			Company: []iland.InventoryItem{{UUID: company.ID, Type: iland.EntityCompany, Name: company.Name}},
+		}
+		locations := map[string]bool{}
+		for _, o := range s.orgs.list(func(o iland.Org) bool { return o.CompanyID == companyID }) {
+			if o.LocationID == "" {
+				entities.IaasOrganizations = append(entities.IaasOrganizations, iland.InventoryItem{UUID: o.ID, Type: iland.EntityIaasOrganization, Name: o.Name, ParentUUID: companyID, ParentType: iland.EntityCompany})
+				continue
+			}
+			if !locations[o.LocationID] {
+				locations[o.LocationID] = true
+				entities.IaasLocations = append(entities.IaasLocations, iland.InventoryItem{UUID: o.LocationID, Type: iland.EntityIaasLocation, Name: o.LocationID, ParentUUID: companyID, ParentType: iland.EntityCompany})
+			}
+			entities.IaasOrganizations = append(entities.IaasOrganizations, iland.InventoryItem{UUID: o.ID, Type: iland.EntityIaasOrganization, Name: o.Name, ParentUUID: o.LocationID, ParentType: iland.EntityIaasLocation})
+		}
+		for _, v := range s.vdcs.list(func(v iland.Vdc) bool { return v.CompanyID == companyID }) {
+			entities.IaasVdcs = append(entities.IaasVdcs, iland.InventoryItem{UUID: v.ID, Type: iland.EntityIaasVdc, Name: v.Name, ParentUUID: v.OrgID, ParentType: iland.EntityIaasOrganization})
+		}
+		for _, e := range s.edges.list(func(e iland.Edge) bool { return e.CompanyID == companyID }) {
+			entities.IaasEdges = append(entities.IaasEdges, iland.InventoryItem{UUID: e.ID, Type: iland.EntityIaasEdge, Name: e.Name, ParentUUID: e.VdcID, ParentType: iland.EntityIaasVdc})
+		}
+		for _, n := range s.networks.list(func(n iland.OrgVdcNetwork) bool { return n.CompanyID == companyID }) {
+			entities.IaasNetworks = append(entities.IaasNetworks, iland.InventoryItem{UUID: n.ID, Type: iland.EntityIaasInternalNetwork, Name: n.Name, ParentUUID: n.VdcID, ParentType: iland.EntityIaasVdc})
+		}
+		for _, v := range s.vapps.list(func(v iland.VApp) bool { return v.CompanyID == companyID }) {
+			entities.IaasVApps = append(entities.IaasVApps, iland.InventoryItem{UUID: v.ID, Type: iland.EntityIaasVApp, Name: v.Name, ParentUUID: v.VdcID, ParentType: iland.EntityIaasVdc})
+		}
+		for _, v := range s.vms.list(func(v iland.VirtualMachine) bool { return v.CompanyID == companyID }) {
+			entities.IaasVms = append(entities.IaasVms, iland.InventoryItem{UUID: v.ID, Type: iland.EntityIaasVm, Name: v.Name, ParentUUID: v.VAppID, ParentType: iland.EntityIaasVApp})
+		}
+		inventory = append(inventory, iland.CompanyInventory{CompanyID: company.ID, CompanyName: company.Name, Entities: entities})
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Username  string                   `json:"username"`
+		Inventory []iland.CompanyInventory `json:"inventory"`
+	}{s.username, inventory})
+}
+
+func getEntity[T any](s *Server, t *table[T]) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		item, ok := t.get(id)
+		s.mu.Unlock()
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	}
+}
+
+func listChildren[P, T any](s *Server, parents *table[P], children *table[T], parentID func(T) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		_, ok := parents.get(id)
+		items := children.list(func(item T) bool { return parentID(item) == id })
+		s.mu.Unlock()
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		writeList(w, items)
+	}
+}
+
+func getBilling[T any](s *Server, t *table[T]) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		_, ok := t.get(id)
+		billing, found := s.billing[id]
+		s.mu.Unlock()
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		if !found {
+			now := time.Now()
+			billing = iland.Billing{EntityID: id, CurrencyCode: "USD", Year: now.Year(), Month: int(now.Month())}
+		}
+		if month := r.URL.Query().Get("month"); month != "" {
+			billing.Month, _ = strconv.Atoi(month)
+			billing.Year, _ = strconv.Atoi(r.URL.Query().Get("year"))
+		}
+		writeJSON(w, http.StatusOK, billing)
+	}
+}
+
+// getList serves the items stored for an entity, such as its metadata.
+func getList[T, D any](s *Server, t *table[T], items map[string][]D) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		_, ok := t.get(id)
+		list := append([]D{}, items[id]...)
+		s.mu.Unlock()
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Data []D `json:"data"`
+		}{list})
+	}
+}
+
+// getDetail serves the object stored for an entity, such as an edge's
+// firewall, or its zero value.
+func getDetail[T, D any](s *Server, t *table[T], details map[string]D) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		_, ok := t.get(id)
+		detail := details[id]
+		s.mu.Unlock()
+		if !ok {
+			writeNotFound(w, id)
+			return
+		}
+		writeJSON(w, http.StatusOK, detail)
+	}
+}
+
+// writeList writes items wrapped in a data envelope. The list endpoints
+// most list endpoints are not paged by the API, so page parameters are
+// ignored; the paged ones cut their items with pageOf first.
+func writeList[T any](w http.ResponseWriter, items []T) {
+	writeJSON(w, http.StatusOK, struct {
+		Data []T `json:"data"`
+	}{items})
+}
+
+// pageOf returns page number page, counted from 0, of items split into
+// pages of size. A size of 0 or less returns every item.
+func pageOf[T any](items []T, page, size int) []T {
+	if size <= 0 {
+		return items
+	}
+	start := min(max(page, 0)*size, len(items))
+	return items[start:min(start+size, len(items))]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, struct {
+		Code    string `json:"error"`
+		Message string `json:"message"`
+	}{code, message})
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("entity %s not found", id))
+}
+
+// table is an insertion-ordered set of entities keyed by ID.
+type table[T any] struct {
+	ids   []string
+	items map[string]T
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{items: map[string]T{}}
+}
+
+func (t *table[T]) put(id string, item T) {
+	if _, ok := t.items[id]; !ok {
+		t.ids = append(t.ids, id)
+	}
+	t.items[id] = item
+}
+
+func (t *table[T]) get(id string) (T, bool) {
+	item, ok := t.items[id]
+	return item, ok
+}
+
+func (t *table[T]) delete(id string) {
+	if _, ok := t.items[id]; !ok {
+		return
+	}
+	delete(t.items, id)
+	for i, existing := range t.ids {
+		if existing == id {
+			t.ids = append(t.ids[:i], t.ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (t *table[T]) list(match func(T) bool) []T {
+	items := []T{}
+	for _, id := range t.ids {
+		item := t.items[id]
+		if match == nil || match(item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,478 @@
+package ilandtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+)
+
+// task is a task in progress. Its progress is derived from the time it has
+// been running; when it completes, apply is called under the server lock.
+type task struct {
+	iland.Task
+	entityType string
+	details    string
+	ancestors  []string
+	started    time.Time
+	duration   time.Duration
+	failure    string
+	cancelled  bool
+	paused     time.Duration
+	apply      func()
+	timer      *time.Timer
+}
+
+// taskEvents maps the operations of tasks to the types of the events
+// published when they complete.
+var taskEvents = map[string]map[string]string{
+	iland.EntityIaasVm: {
+		"poweron":            iland.EventVmPowerOn,
+		"poweroff":           iland.EventVmPowerOff,
+		"shutdown":           iland.EventVmShutdown,
+		"suspend":            iland.EventVmSuspend,
+		"update-name":        iland.EventVmRenamed,
+		"update-description": iland.EventVmDescriptionUpdated,
+		"delete":             iland.EventVmDeleted,
+	},
+	iland.EntityIaasVApp: {
+		"poweron":     iland.EventVAppPowerOn,
+		"poweroff":    iland.EventVAppPowerOff,
+		"shutdown":    iland.EventVAppShutdown,
+		"suspend":     iland.EventVAppSuspend,
+		"update-name": iland.EventVAppRenamed,
+		"delete":      iland.EventVAppDeleted,
+	},
+}
+
+// renameDetails returns the event details of an update-name task.
+func renameDetails(operation, oldName, newName string) string {
+	if operation != "update-name" {
+		return ""
+	}
+	details, _ := json.Marshal(iland.RenameEventDetails{OldName: oldName, NewName: newName})
+	return string(details)
+}
+
+type taskFaultKey struct{}
+
+func withTaskFault(ctx context.Context, message string) context.Context {
+	return context.WithValue(ctx, taskFaultKey{}, message)
+}
+
+// Task returns the current state of a task.
+func (s *Server) Task(id string) (iland.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks.get(id)
+	if !ok {
+		return iland.Task{}, false
+	}
+	return t.snapshot(), true
+}
+
+// FinishTasks immediately completes every running task.
+func (s *Server) FinishTasks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tasks.list(func(t *task) bool { return t.Active }) {
+		s.completeTask(t)
+	}
+}
+
+// startTask records a new running task against an entity. It must be called
+// with s.mu held.
+func (s *Server) startTask(ctx context.Context, entityType, entityID, entityName, operation, details string, owner ownership, apply func()) iland.Task {
+	now := time.Now()
+	t := &task{
+		Task: iland.Task{
+			ID:          s.newID("task"),
+			Operation:   operation,
+			Description: operation,
+			Type:        operation,
+			Status:      iland.Running,
+			Active:      true,
+			UserName:    s.username,
+			EntityID:    entityID,
+			EntityName:  entityName,
+			OrgID:       owner.orgID,
+			CompanyID:   owner.companyID,
+			LocationID:  owner.locationID,
+			StartTime:   int(now.UnixMilli()),
+		},
+		entityType: entityType,
+		details:    details,
+		ancestors:  owner.ancestors,
+		started:    now,
+		duration:   s.taskDuration,
+		apply:      apply,
+	}
+	if message, ok := ctx.Value(taskFaultKey{}).(string); ok {
+		t.failure = message
+	}
+	s.tasks.put(t.ID, t)
+	if t.duration <= 0 {
+		s.completeTask(t)
+		return t.snapshot()
+	}
+	t.timer = time.AfterFunc(t.duration, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.completeTask(t)
+	})
+	return t.snapshot()
+}
+
+// completeTask finishes t, applying its effect unless a failure was
+// injected, and publishes an event for it. It must be called with s.mu held.
+func (s *Server) completeTask(t *task) {
+	if !t.Active {
+		return
+	}
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.Active = false
+	t.Synced = true
+	t.Progress = 100
+	t.EndTime = int(time.Now().UnixMilli())
+	t.Question = ""
+	t.Choices = nil
+	if t.cancelled {
+		t.Status = iland.Cancelled
+		t.Message = "cancelled by user"
+	} else if t.failure != "" {
+		t.Status = iland.Error
+		t.Message = t.failure
+	} else {
+		t.Status = iland.Success
+		if t.apply != nil {
+			t.apply()
+		}
+	}
+	eventType, ok := taskEvents[t.entityType][t.Operation]
+	if !ok {
+		eventType = t.Operation
+	}
+	details := t.details
+	if details == "" {
+		details = t.Description
+	}
+	s.publish(iland.Event{
+		ID:              s.newID("event"),
+		Details:         details,
+		Type:            eventType,
+		EntityID:        t.EntityID,
+		EntityName:      t.EntityName,
+		EntityType:      t.entityType,
+		OwnerType:       iland.EntityCompany,
+		OwnerID:         t.CompanyID,
+		TaskID:          t.ID,
+		InitiatedByUser: t.UserName,
+		Timestamp:       t.EndTime,
+	})
+}
+
+func (t *task) snapshot() iland.Task {
+	task := t.Task
+	if task.Active && t.duration > 0 {
+		elapsed := time.Since(t.started)
+		if task.Status == iland.WaitingOnUser {
+			elapsed = t.paused
+		}
+		task.Progress = int(elapsed * 100 / t.duration)
+		if task.Progress > 99 {
+			task.Progress = 99
+		}
+	}
+	return task
+}
+
+// AskUser pauses a running task until a client answers question with one
+// of choices. It reports whether the task was running.
+func (s *Server) AskUser(taskID, question string, choices ...iland.TaskChoice) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks.get(taskID)
+	if !ok || !t.Active || t.Status != iland.Running {
+		return false
+	}
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.paused = time.Since(t.started)
+	t.Status = iland.WaitingOnUser
+	t.Question = question
+	t.Choices = choices
+	return true
+}
+
+func (s *Server) handleTaskCancel(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks.get(id)
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	if !t.Active {
+		writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("task %s is not running", id))
+		return
+	}
+	t.cancelled = true
+	s.completeTask(t)
+	writeJSON(w, http.StatusOK, t.snapshot())
+}
+
+func (s *Server) handleTaskRespond(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	params := struct {
+		ChoiceID string `json:"choice_id"`
+	}{}
+	json.NewDecoder(r.Body).Decode(&params)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.tasks.get(id)
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	if t.Status != iland.WaitingOnUser {
+		writeError(w, http.StatusConflict, "conflict", fmt.Sprintf("task %s is not waiting on user input", id))
+		return
+	}
+	valid := false
+	for _, choice := range t.Choices {
+		valid = valid || choice.ID == params.ChoiceID
+	}
+	if !valid {
+		writeError(w, http.StatusBadRequest, "bad_request", fmt.Sprintf("invalid choice %q", params.ChoiceID))
+		return
+	}
+	t.Status = iland.Running
+	t.Question = ""
+	t.Choices = nil
+	t.started = time.Now().Add(-t.paused)
+	t.timer = time.AfterFunc(t.duration-t.paused, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.completeTask(t)
+	})
+	writeJSON(w, http.StatusOK, t.snapshot())
+}
+
+func (s *Server) handleTask(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	task, ok := s.Task(id)
+	if !ok {
+		writeNotFound(w, id)
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+// handleTasks answers task queries with a bare JSON array, as the API does.
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	entityID := query.Get("entityUuid")
+	descendants, _ := strconv.ParseBool(query.Get("includeDescendantTasks"))
+	statuses := query["status"]
+	operations := query["operation"]
+	username := query.Get("username")
+	locationID := query.Get("locationId")
+	startDate, _ := strconv.Atoi(query.Get("startDate"))
+	endDate, _ := strconv.Atoi(query.Get("endDate"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	s.mu.Lock()
+	matched := s.tasks.list(func(t *task) bool {
+		if entityID != "" && t.EntityID != entityID && !(descendants && contains(t.ancestors, entityID)) {
+			return false
+		}
+		if len(statuses) > 0 && !contains(statuses, t.Status) {
+			return false
+		}
+		if len(operations) > 0 && !contains(operations, t.Operation) {
+			return false
+		}
+		if username != "" && t.UserName != username {
+			return false
+		}
+		if locationID != "" && t.LocationID != locationID {
+			return false
+		}
+		if startDate != 0 && t.StartTime < startDate {
+			return false
+		}
+		return endDate == 0 || t.StartTime < endDate
+	})
+	matched = pageOf(matched, page, limit)
+	tasks := []iland.Task{}
+	for _, t := range matched {
+		tasks = append(tasks, t.snapshot())
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ownership identifies where an entity sits in the inventory.
+type ownership struct {
+	companyID  string
+	locationID string
+	orgID      string
+	ancestors  []string
+}
+
+func (s *Server) handleVMAction(w http.ResponseWriter, r *http.Request) {
+	id, action := r.PathValue("id"), r.PathValue("action")
+	params := struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}{}
+	json.NewDecoder(r.Body).Decode(&params)
+	s.mu.Lock()
+	vm, ok := s.vms.get(id)
+	if !ok {
+		s.mu.Unlock()
+		writeNotFound(w, id)
+		return
+	}
+	update := func(change func(*iland.VirtualMachine)) func() {
+		return func() {
+			if vm, ok := s.vms.get(id); ok {
+				change(&vm)
+				vm.UpdatedDate = int(time.Now().UnixMilli())
+				s.vms.put(id, vm)
+			}
+		}
+	}
+	var apply func()
+	switch action {
+	case "poweron":
+		apply = update(func(vm *iland.VirtualMachine) { vm.Status = "POWERED_ON"; vm.Deployed = true })
+	case "poweroff", "shutdown":
+		apply = update(func(vm *iland.VirtualMachine) { vm.Status = "POWERED_OFF" })
+	case "suspend":
+		apply = update(func(vm *iland.VirtualMachine) { vm.Status = "SUSPENDED" })
+	case "update-name":
+		apply = update(func(vm *iland.VirtualMachine) { vm.Name = params.Name })
+	case "update-description":
+		apply = update(func(vm *iland.VirtualMachine) { vm.Description = params.Description })
+	}
+	task := s.startTask(r.Context(), iland.EntityIaasVm, vm.ID, vm.Name, action, renameDetails(action, vm.Name, params.Name), vmOwnership(vm), apply)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleVMDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	vm, ok := s.vms.get(id)
+	if !ok {
+		s.mu.Unlock()
+		writeNotFound(w, id)
+		return
+	}
+	task := s.startTask(r.Context(), iland.EntityIaasVm, vm.ID, vm.Name, "delete", "", vmOwnership(vm), func() {
+		s.vms.delete(id)
+	})
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleVAppAction(w http.ResponseWriter, r *http.Request) {
+	id, action := r.PathValue("id"), r.PathValue("action")
+	params := struct {
+		Name string `json:"name"`
+	}{}
+	json.NewDecoder(r.Body).Decode(&params)
+	s.mu.Lock()
+	vapp, ok := s.vapps.get(id)
+	if !ok {
+		s.mu.Unlock()
+		writeNotFound(w, id)
+		return
+	}
+	setStatus := func(status string) func() {
+		return func() {
+			if vapp, ok := s.vapps.get(id); ok {
+				vapp.Status = status
+				s.vapps.put(id, vapp)
+			}
+			for _, vm := range s.vms.list(func(vm iland.VirtualMachine) bool { return vm.VAppID == id }) {
+				vm.Status = status
+				s.vms.put(vm.ID, vm)
+			}
+		}
+	}
+	var apply func()
+	switch action {
+	case "poweron":
+		apply = setStatus("POWERED_ON")
+	case "poweroff", "shutdown":
+		apply = setStatus("POWERED_OFF")
+	case "suspend":
+		apply = setStatus("SUSPENDED")
+	case "update-name":
+		apply = func() {
+			if vapp, ok := s.vapps.get(id); ok {
+				vapp.Name = params.Name
+				s.vapps.put(id, vapp)
+			}
+		}
+	}
+	task := s.startTask(r.Context(), iland.EntityIaasVApp, vapp.ID, vapp.Name, action, renameDetails(action, vapp.Name, params.Name), vappOwnership(vapp), apply)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleVAppDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	vapp, ok := s.vapps.get(id)
+	if !ok {
+		s.mu.Unlock()
+		writeNotFound(w, id)
+		return
+	}
+	task := s.startTask(r.Context(), iland.EntityIaasVApp, vapp.ID, vapp.Name, "delete", "", vappOwnership(vapp), func() {
+		s.vapps.delete(id)
+		for _, vm := range s.vms.list(func(vm iland.VirtualMachine) bool { return vm.VAppID == id }) {
+			s.vms.delete(vm.ID)
+		}
+	})
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, task)
+}
+
+func vmOwnership(vm iland.VirtualMachine) ownership {
+	return ownership{
+		companyID:  vm.CompanyID,
+		locationID: vm.LocationID,
+		orgID:      vm.OrgID,
+		ancestors:  []string{vm.VAppID, vm.VdcID, vm.OrgID, vm.CompanyID},
+	}
+}
+
+func vappOwnership(vapp iland.VApp) ownership {
+	return ownership{
+		companyID:  vapp.CompanyID,
+		locationID: vapp.LocationID,
+		orgID:      vapp.OrgID,
+		ancestors:  []string{vapp.VdcID, vapp.OrgID, vapp.CompanyID},
+	}
+}
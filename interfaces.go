@@ -1,21 +1,33 @@
 package iland
 
 import (
+	"context"
 	"io"
+	"iter"
 	"time"
 )
 
 type ConsoleService interface {
 	Get(endpoint string) (io.ReadCloser, error)
+	GetContext(ctx context.Context, endpoint string) (io.ReadCloser, error)
 	Post(endpoint string, body []byte) (io.ReadCloser, error)
+	PostContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error)
 	Put(endpoint string, body []byte) (io.ReadCloser, error)
+	PutContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error)
 	Delete(endpoint string) (io.ReadCloser, error)
+	DeleteContext(ctx context.Context, endpoint string) (io.ReadCloser, error)
 
 	GetOperatingSystems() ([]OperatingSystem, error)
+	GetOperatingSystemsContext(ctx context.Context) ([]OperatingSystem, error)
 	GetLocations() []Location
+	GetLocationsContext(ctx context.Context) ([]Location, error)
 	GetCompanies() ([]Company, error)
+	GetCompaniesContext(ctx context.Context) ([]Company, error)
 	GetOrgs() ([]Org, error)
+	GetOrgsContext(ctx context.Context) ([]Org, error)
 	StreamEvents(companyID string) (chan Event, error)
+	StreamEventsContext(ctx context.Context, companyID string) (chan Event, error)
+	OpenEventStream(ctx context.Context, companyID string, opts EventStreamOptions) (*EventStream, error)
 
 	Location() LocationService
 	Company() CompanyService
@@ -34,118 +46,250 @@ type ConsoleService interface {
 	Vpg() VpgService
 	O365() O365Service
 	Task() TaskService
+	Event() EventService
 }
 
 type LocationService interface {
+	Get(locationID string) (Location, error)
+	GetContext(ctx context.Context, locationID string) (Location, error)
 	GetPublicCatalogs(locationID string) ([]Catalog, error)
+	GetPublicCatalogsContext(ctx context.Context, locationID string) ([]Catalog, error)
+	ListPublicCatalogs(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[Catalog, error]
 	GetPublicVAppTemplates(locationID string) ([]VAppTemplate, error)
+	GetPublicVAppTemplatesContext(ctx context.Context, locationID string) ([]VAppTemplate, error)
+	ListPublicVAppTemplates(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[VAppTemplate, error]
 	GetPublicMedia(locationID string) ([]Media, error)
+	GetPublicMediaContext(ctx context.Context, locationID string) ([]Media, error)
+	ListPublicMedia(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[Media, error]
 }
 
 type TaskService interface {
 	Get(taskID string) (Task, error)
+	GetContext(ctx context.Context, taskID string) (Task, error)
 	Track(taskID string) (Task, error)
+	TrackContext(ctx context.Context, taskID string) (Task, error)
+	Wait(ctx context.Context, taskID string, opts WaitOptions) (Task, error)
+	WaitAll(ctx context.Context, taskIDs []string, opts WaitOptions) ([]Task, error)
+	WaitAny(ctx context.Context, taskIDs []string, opts WaitOptions) (Task, error)
 	Query(entityID, entityType string, childTasks bool) ([]Task, error)
+	QueryContext(ctx context.Context, entityID, entityType string, childTasks bool) ([]Task, error)
+	List(ctx context.Context, entityID, entityType string, childTasks bool, opts ListOptions) iter.Seq2[Task, error]
+	Search(ctx context.Context, query TaskQuery, opts ListOptions) iter.Seq2[Task, error]
+	Cancel(taskID string) (Task, error)
+	CancelContext(ctx context.Context, taskID string) (Task, error)
+	Respond(taskID, choiceID string) (Task, error)
+	RespondContext(ctx context.Context, taskID, choiceID string) (Task, error)
+}
+
+type EventService interface {
+	Search(ctx context.Context, query EventQuery, opts ListOptions) iter.Seq2[Event, error]
 }
 
 type VCCBackupTenantService interface {
 	Get(vccBackupTenantID string) (VCCBackupTenant, error)
+	GetContext(ctx context.Context, vccBackupTenantID string) (VCCBackupTenant, error)
 }
 
 type VacTenantService interface {
 	Get(id string) (VacTenant, error)
+	GetContext(ctx context.Context, id string) (VacTenant, error)
 }
 
 type O365Service interface {
 	GetOrganization(id string) (O365Organization, error)
+	GetOrganizationContext(ctx context.Context, id string) (O365Organization, error)
 	GetUsers(id string) ([]O365User, error)
+	GetUsersContext(ctx context.Context, id string) ([]O365User, error)
+	ListUsers(ctx context.Context, id string, opts ListOptions) iter.Seq2[O365User, error]
 	GetUserReport(id string) ([]byte, error)
+	GetUserReportContext(ctx context.Context, id string) ([]byte, error)
 }
 
 type CompanyService interface {
 	Get(companyID string) (Company, error)
+	GetContext(ctx context.Context, companyID string) (Company, error)
 	GetUsers(companyID string) ([]User, error)
+	GetUsersContext(ctx context.Context, companyID string) ([]User, error)
+	ListUsers(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[User, error]
 	CreateUser(companyID string, params CreateUserParams) (User, error)
+	CreateUserContext(ctx context.Context, companyID string, params CreateUserParams) (User, error)
 	GetRoles(companyID string) ([]Role, error)
+	GetRolesContext(ctx context.Context, companyID string) ([]Role, error)
+	ListRoles(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[Role, error]
 	GetRole(companyID, roleID string) (Role, error)
+	GetRoleContext(ctx context.Context, companyID, roleID string) (Role, error)
 	GetOrgs(companyID string) ([]Org, error)
+	GetOrgsContext(ctx context.Context, companyID string) ([]Org, error)
 	GetLocationOrgs(companyID, locationID string) ([]Org, error)
+	GetLocationOrgsContext(ctx context.Context, companyID, locationID string) ([]Org, error)
+	ListLocationOrgs(ctx context.Context, companyID, locationID string, opts ListOptions) iter.Seq2[Org, error]
 	GetVCCBackupTenants(companyID string) ([]VCCBackupTenant, error)
+	GetVCCBackupTenantsContext(ctx context.Context, companyID string) ([]VCCBackupTenant, error)
+	ListVCCBackupTenants(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[VCCBackupTenant, error]
 	GetVacTenants(companyID string) ([]VacTenant, error)
+	GetVacTenantsContext(ctx context.Context, companyID string) ([]VacTenant, error)
+	ListVacTenants(ctx context.Context, companyID string, opts ListOptions) iter.Seq2[VacTenant, error]
 	GetLocationVacTenants(companyID, location string) ([]VacTenant, error)
+	GetLocationVacTenantsContext(ctx context.Context, companyID, location string) ([]VacTenant, error)
+	ListLocationVacTenants(ctx context.Context, companyID, location string, opts ListOptions) iter.Seq2[VacTenant, error]
 	GetInventory(companyID string) (CompanyInventory, error)
+	GetInventoryContext(ctx context.Context, companyID string) (CompanyInventory, error)
+	GetInventoryGraph(companyID string) (*InventoryGraph, error)
+	GetInventoryGraphContext(ctx context.Context, companyID string) (*InventoryGraph, error)
+	GetInventorySnapshot(companyID string) (*InventorySnapshot, error)
+	GetInventorySnapshotContext(ctx context.Context, companyID string) (*InventorySnapshot, error)
+	GetLocations(companyID string) ([]Location, error)
+	GetLocationsContext(ctx context.Context, companyID string) ([]Location, error)
+	SearchVirtualMachines(ctx context.Context, companyID string, query VirtualMachineQuery) ([]VirtualMachine, error)
+	SearchVApps(ctx context.Context, companyID string, query VAppQuery) ([]VApp, error)
+	SearchEdges(ctx context.Context, companyID string, query EdgeQuery) ([]Edge, error)
 }
 
 type UserService interface {
 	Get(username string) (User, error)
+	GetContext(ctx context.Context, username string) (User, error)
 	Delete(username string) error
+	DeleteContext(ctx context.Context, username string) error
 	Update(username string, params UpdateUserParams) (User, error)
+	UpdateContext(ctx context.Context, username string, params UpdateUserParams) (User, error)
 	GetCompanies(username string) ([]Company, error)
+	GetCompaniesContext(ctx context.Context, username string) ([]Company, error)
+	ListCompanies(ctx context.Context, username string, opts ListOptions) iter.Seq2[Company, error]
 	GetUserCompanyVacTenants(username, companyID string) ([]VacTenant, error)
+	GetUserCompanyVacTenantsContext(ctx context.Context, username, companyID string) ([]VacTenant, error)
+	ListUserCompanyVacTenants(ctx context.Context, username, companyID string, opts ListOptions) iter.Seq2[VacTenant, error]
 	GetCompanyVacTenants(companyID string) ([]VacTenant, error)
+	GetCompanyVacTenantsContext(ctx context.Context, companyID string) ([]VacTenant, error)
 	GetOrgs(username string) ([]Org, error)
+	GetOrgsContext(ctx context.Context, username string) ([]Org, error)
+	ListOrgs(ctx context.Context, username string, opts ListOptions) iter.Seq2[Org, error]
 	AssignRole(username, companyID, roleID string) error
+	AssignRoleContext(ctx context.Context, username, companyID, roleID string) error
 	GetRole(username, companyID string) (Role, error)
+	GetRoleContext(ctx context.Context, username, companyID string) (Role, error)
 	DeleteRole(username, companyID string) error
+	DeleteRoleContext(ctx context.Context, username, companyID string) error
 }
 
 type OrgService interface {
 	Get(orgID string) (Org, error)
+	GetContext(ctx context.Context, orgID string) (Org, error)
 	GetVdcs(orgID string) ([]Vdc, error)
+	GetVdcsContext(ctx context.Context, orgID string) ([]Vdc, error)
+	ListVdcs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Vdc, error]
 	GetEdges(orgID string) ([]Edge, error)
+	GetEdgesContext(ctx context.Context, orgID string) ([]Edge, error)
+	ListEdges(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Edge, error]
 	GetCatalogs(orgID string) ([]Catalog, error)
+	GetCatalogsContext(ctx context.Context, orgID string) ([]Catalog, error)
+	ListCatalogs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Catalog, error]
 	GetVAppTemplates(orgID string) ([]VAppTemplate, error)
+	GetVAppTemplatesContext(ctx context.Context, orgID string) ([]VAppTemplate, error)
+	ListVAppTemplates(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VAppTemplate, error]
 	GetMedia(orgID string) ([]Media, error)
+	GetMediaContext(ctx context.Context, orgID string) ([]Media, error)
+	ListMedia(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Media, error]
 	GetNetworks(orgID string) ([]OrgVdcNetwork, error)
+	GetNetworksContext(ctx context.Context, orgID string) ([]OrgVdcNetwork, error)
+	ListNetworks(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[OrgVdcNetwork, error]
 	GetVApps(orgID string) ([]VApp, error)
+	GetVAppsContext(ctx context.Context, orgID string) ([]VApp, error)
+	ListVApps(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VApp, error]
 	GetVirtualMachines(orgID string) ([]VirtualMachine, error)
+	GetVirtualMachinesContext(ctx context.Context, orgID string) ([]VirtualMachine, error)
+	ListVirtualMachines(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VirtualMachine, error]
 	GetVpgs(orgID string) ([]Vpg, error)
+	GetVpgsContext(ctx context.Context, orgID string) ([]Vpg, error)
+	ListVpgs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Vpg, error]
 	GetPublicIPs(orgID string) ([]string, error)
+	GetPublicIPsContext(ctx context.Context, orgID string) ([]string, error)
 	GetPublicIPAssignments(orgID string) ([]PublicIPAssignment, error)
+	GetPublicIPAssignmentsContext(ctx context.Context, orgID string) ([]PublicIPAssignment, error)
+	ListPublicIPAssignments(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[PublicIPAssignment, error]
 	GetCurrentBill(orgID string) (Billing, error)
+	GetCurrentBillContext(ctx context.Context, orgID string) (Billing, error)
 	GetBill(orgID string, month, year int) (Billing, error)
+	GetBillContext(ctx context.Context, orgID string, month, year int) (Billing, error)
 	GetVCCFailoverPlans(orgID string) ([]VCCFailoverPlan, error)
+	GetVCCFailoverPlansContext(ctx context.Context, orgID string) ([]VCCFailoverPlan, error)
+	ListVCCFailoverPlans(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VCCFailoverPlan, error]
 }
 
 type CatalogService interface {
 	Get(catalogID string) (Catalog, error)
+	GetContext(ctx context.Context, catalogID string) (Catalog, error)
 	Update(catalogID string, params UpdateCatalogParams) (Task, error)
+	UpdateContext(ctx context.Context, catalogID string, params UpdateCatalogParams) (Task, error)
 	GetVAppTemplates(catalogID string) ([]VAppTemplate, error)
+	GetVAppTemplatesContext(ctx context.Context, catalogID string) ([]VAppTemplate, error)
+	ListVAppTemplates(ctx context.Context, catalogID string, opts ListOptions) iter.Seq2[VAppTemplate, error]
 	GetMedia(catalogID string) ([]Media, error)
+	GetMediaContext(ctx context.Context, catalogID string) ([]Media, error)
+	ListMedia(ctx context.Context, catalogID string, opts ListOptions) iter.Seq2[Media, error]
 	CreateVAppTemplate(catalogID string, params CreateVAppTemplateParams) (Task, error)
+	CreateVAppTemplateContext(ctx context.Context, catalogID string, params CreateVAppTemplateParams) (Task, error)
 	SyncSubscription(catalogID string) (Task, error)
+	SyncSubscriptionContext(ctx context.Context, catalogID string) (Task, error)
 }
 
 type VAppTemplateService interface {
 	Get(vappTemplateID string) (VAppTemplate, error)
+	GetContext(ctx context.Context, vappTemplateID string) (VAppTemplate, error)
 	Update(vappTemplateID string, params UpdateVAppTemplateParams) (Task, error)
+	UpdateContext(ctx context.Context, vappTemplateID string, params UpdateVAppTemplateParams) (Task, error)
 	Delete(vappTemplateID string) (Task, error)
+	DeleteContext(ctx context.Context, vappTemplateID string) (Task, error)
 	GetVirtualMachines(vappTemplateID string) ([]VirtualMachineTemplate, error)
+	GetVirtualMachinesContext(ctx context.Context, vappTemplateID string) ([]VirtualMachineTemplate, error)
+	ListVirtualMachines(ctx context.Context, vappTemplateID string, opts ListOptions) iter.Seq2[VirtualMachineTemplate, error]
 	GetConfig(vappTemplateID string) (VAppTemplateConfig, error)
+	GetConfigContext(ctx context.Context, vappTemplateID string) (VAppTemplateConfig, error)
 	SyncSubscription(vappTemplateID string) (Task, error)
+	SyncSubscriptionContext(ctx context.Context, vappTemplateID string) (Task, error)
 }
 
 type VdcService interface {
 	Get(vdcID string) (Vdc, error)
+	GetContext(ctx context.Context, vdcID string) (Vdc, error)
 	GetStorageProfiles(vdcID string) ([]StorageProfile, error)
+	GetStorageProfilesContext(ctx context.Context, vdcID string) ([]StorageProfile, error)
 	GetSummary(vdcID string) (VdcSummary, error)
+	GetSummaryContext(ctx context.Context, vdcID string) (VdcSummary, error)
 	GetVApps(vdcID string) ([]VApp, error)
+	GetVAppsContext(ctx context.Context, vdcID string) ([]VApp, error)
+	ListVApps(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[VApp, error]
 	GetVirtualMachines(vdcID string) ([]VirtualMachine, error)
+	GetVirtualMachinesContext(ctx context.Context, vdcID string) ([]VirtualMachine, error)
+	ListVirtualMachines(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[VirtualMachine, error]
 	GetEdges(vdcID string) ([]Edge, error)
+	GetEdgesContext(ctx context.Context, vdcID string) ([]Edge, error)
+	ListEdges(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[Edge, error]
 	GetNetworks(vdcID string) ([]OrgVdcNetwork, error)
+	GetNetworksContext(ctx context.Context, vdcID string) ([]OrgVdcNetwork, error)
+	ListNetworks(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[OrgVdcNetwork, error]
 	GetCurrentBill(vdcID string) (Billing, error)
+	GetCurrentBillContext(ctx context.Context, vdcID string) (Billing, error)
 	GetBill(vdcID string, month, year int) (Billing, error)
+	GetBillContext(ctx context.Context, vdcID string, month, year int) (Billing, error)
 	GetCurrentVAppBill(vdcID string) ([]Billing, error)
+	GetCurrentVAppBillContext(ctx context.Context, vdcID string) ([]Billing, error)
 	GetVAppBill(vdcID string, month, year int) ([]Billing, error)
+	GetVAppBillContext(ctx context.Context, vdcID string, month, year int) ([]Billing, error)
 	GetPerformanceCounters(vdcID string) ([]PerformanceCounter, error)
+	GetPerformanceCountersContext(ctx context.Context, vdcID string) ([]PerformanceCounter, error)
 	GetPerformance(vdcID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
+	GetPerformanceContext(ctx context.Context, vdcID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
 	BuildVApp(vdcID string, params BuildVAppParams) (Task, error)
+	BuildVAppContext(ctx context.Context, vdcID string, params BuildVAppParams) (Task, error)
 	DeployVAppTemplate(vdcID string, params DeployVAppTemplateParams) (Task, error)
+	DeployVAppTemplateContext(ctx context.Context, vdcID string, params DeployVAppTemplateParams) (Task, error)
 	GetBackupStats(vdcID string) (VdcBackupStats, error)
+	GetBackupStatsContext(ctx context.Context, vdcID string) (VdcBackupStats, error)
 }
 
 type EdgeService interface {
 	Get(edgeID string) (Edge, error)
+	GetContext(ctx context.Context, edgeID string) (Edge, error)
 	// GetFirewall(edgeID string) (EdgeFirewall, error)
 	// UpdateFirewallRules(edgeID string, rules []EdgeFirewallRule) (Task, error)
 	// GetNAT(edgeID string) (EdgeNAT, error)
@@ -154,137 +298,260 @@ type EdgeService interface {
 	// DisableNAT(edgeID string) (Task, error)
 
 	GetFirewall(edgeID string) (EdgeFirewall, error)
+	GetFirewallContext(ctx context.Context, edgeID string) (EdgeFirewall, error)
 	UpdateFirewall(edgeID string, firewall EdgeFirewall) (EdgeFirewall, error)
+	UpdateFirewallContext(ctx context.Context, edgeID string, firewall EdgeFirewall) (EdgeFirewall, error)
 	GetNAT(edgeID string) (EdgeNAT, error)
+	GetNATContext(ctx context.Context, edgeID string) (EdgeNAT, error)
 	UpdateNAT(edgeID string, nat EdgeNAT) (EdgeNAT, error)
+	UpdateNATContext(ctx context.Context, edgeID string, nat EdgeNAT) (EdgeNAT, error)
 }
 
 type OrgVdcNetworkService interface {
 	Get(networkID string) (OrgVdcNetwork, error)
+	GetContext(ctx context.Context, networkID string) (OrgVdcNetwork, error)
 	Update(networkID string, params UpdateOrgVdcNetworkParams) (Task, error)
+	UpdateContext(ctx context.Context, networkID string, params UpdateOrgVdcNetworkParams) (Task, error)
 }
 
 type VAppService interface {
 	Get(vappID string) (VApp, error)
+	GetContext(ctx context.Context, vappID string) (VApp, error)
 	Delete(vappID string) (Task, error)
+	DeleteContext(ctx context.Context, vappID string) (Task, error)
 	GetVirtualMachines(vappID string) ([]VirtualMachine, error)
+	GetVirtualMachinesContext(ctx context.Context, vappID string) ([]VirtualMachine, error)
+	ListVirtualMachines(ctx context.Context, vappID string, opts ListOptions) iter.Seq2[VirtualMachine, error]
 	GetNetworks(vappID string) ([]VAppNetwork, error)
+	GetNetworksContext(ctx context.Context, vappID string) ([]VAppNetwork, error)
+	ListNetworks(ctx context.Context, vappID string, opts ListOptions) iter.Seq2[VAppNetwork, error]
 	AddOrgNetwork(vappID, orgVdcNetworkID string) (Task, error)
+	AddOrgNetworkContext(ctx context.Context, vappID, orgVdcNetworkID string) (Task, error)
 	UpdateName(vappID, name string) (Task, error)
+	UpdateNameContext(ctx context.Context, vappID, name string) (Task, error)
 	UpdateDescription(vappID, description string) (Task, error)
+	UpdateDescriptionContext(ctx context.Context, vappID, description string) (Task, error)
 	Copy(vappID string, params CopyVAppParams) (Task, error)
+	CopyContext(ctx context.Context, vappID string, params CopyVAppParams) (Task, error)
 	Move(vappID string, params MoveVAppParams) (Task, error)
+	MoveContext(ctx context.Context, vappID string, params MoveVAppParams) (Task, error)
 	BuildVirtualMachines(vappID string, params []BuildVirtualMachineParams) (Task, error)
+	BuildVirtualMachinesContext(ctx context.Context, vappID string, params []BuildVirtualMachineParams) (Task, error)
 	AddTemplateVirtualMachines(vappID string, params []AddTemplateVirtualMachineParams) (Task, error)
+	AddTemplateVirtualMachinesContext(ctx context.Context, vappID string, params []AddTemplateVirtualMachineParams) (Task, error)
 	CreateNetwork(vappID string, params CreateVAppNetworkParams) (Task, error)
+	CreateNetworkContext(ctx context.Context, vappID string, params CreateVAppNetworkParams) (Task, error)
 	PowerOn(vappID string) (Task, error)
+	PowerOnContext(ctx context.Context, vappID string) (Task, error)
 	PowerOff(vappID string) (Task, error)
+	PowerOffContext(ctx context.Context, vappID string) (Task, error)
 	Shutdown(vappID string) (Task, error)
+	ShutdownContext(ctx context.Context, vappID string) (Task, error)
 	Reboot(vappID string) (Task, error)
+	RebootContext(ctx context.Context, vappID string) (Task, error)
 	Reset(vappID string) (Task, error)
+	ResetContext(ctx context.Context, vappID string) (Task, error)
 	Suspend(vappID string) (Task, error)
+	SuspendContext(ctx context.Context, vappID string) (Task, error)
 	GetCurrentBill(vappID string) (Billing, error)
+	GetCurrentBillContext(ctx context.Context, vappID string) (Billing, error)
 	GetBill(vappID string, month, year int) (Billing, error)
+	GetBillContext(ctx context.Context, vappID string, month, year int) (Billing, error)
 	GetAvailableStorageProfiles(vappID string) ([]StorageProfile, error)
+	GetAvailableStorageProfilesContext(ctx context.Context, vappID string) ([]StorageProfile, error)
 	GetMetadata(vappID string) ([]Metadata, error)
+	GetMetadataContext(ctx context.Context, vappID string) ([]Metadata, error)
 	UpdateMetadata(vappID string, metadata []Metadata) (Task, error)
+	UpdateMetadataContext(ctx context.Context, vappID string, metadata []Metadata) (Task, error)
 	DeleteMetadata(vappID, metadataKey string) (Task, error)
+	DeleteMetadataContext(ctx context.Context, vappID, metadataKey string) (Task, error)
 	HasSnapshot(vappID string) (bool, error)
+	HasSnapshotContext(ctx context.Context, vappID string) (bool, error)
 	GetSnapshot(vappID string) (Snapshot, error)
+	GetSnapshotContext(ctx context.Context, vappID string) (Snapshot, error)
 	CreateSnapshot(vappID string) (Task, error)
+	CreateSnapshotContext(ctx context.Context, vappID string) (Task, error)
 	RestoreSnapshot(vappID string) (Task, error)
+	RestoreSnapshotContext(ctx context.Context, vappID string) (Task, error)
 	RemoveSnapshot(vappID string) (Task, error)
+	RemoveSnapshotContext(ctx context.Context, vappID string) (Task, error)
 	GetStartupSettings(vappID string) ([]VAppStartupSetting, error)
+	GetStartupSettingsContext(ctx context.Context, vappID string) ([]VAppStartupSetting, error)
 	UpdateStartupSettings(vappID string, params []VAppStartupSetting) (Task, error)
+	UpdateStartupSettingsContext(ctx context.Context, vappID string, params []VAppStartupSetting) (Task, error)
 	GetPerformanceCounters(vappID string) ([]PerformanceCounter, error)
+	GetPerformanceCountersContext(ctx context.Context, vappID string) ([]PerformanceCounter, error)
 	GetPerformance(vappID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
+	GetPerformanceContext(ctx context.Context, vappID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
 	GetSummary(vappID string) (VAppSummary, error)
+	GetSummaryContext(ctx context.Context, vappID string) (VAppSummary, error)
 }
 
 type VAppNetworkService interface {
 	Get(vappNetworkID string) (VAppNetwork, error)
+	GetContext(ctx context.Context, vappNetworkID string) (VAppNetwork, error)
 	Update(vappNetworkID string, params UpdateVAppNetworkParams) (Task, error)
+	UpdateContext(ctx context.Context, vappNetworkID string, params UpdateVAppNetworkParams) (Task, error)
 	Delete(vappNetworkID string) (Task, error)
+	DeleteContext(ctx context.Context, vappNetworkID string) (Task, error)
 	UpdateDHCP(vappNetworkID string, params DHCP) (Task, error)
+	UpdateDHCPContext(ctx context.Context, vappNetworkID string, params DHCP) (Task, error)
 	GetFirewall(vappNetworkID string) (VAppNetworkFirewall, error)
+	GetFirewallContext(ctx context.Context, vappNetworkID string) (VAppNetworkFirewall, error)
 	UpdateFirewallRules(vappNetworkID string, rules []VAppNetworkFirewallRule) (Task, error)
+	UpdateFirewallRulesContext(ctx context.Context, vappNetworkID string, rules []VAppNetworkFirewallRule) (Task, error)
 	EnableFirewall(vappNetworkID string) (Task, error)
+	EnableFirewallContext(ctx context.Context, vappNetworkID string) (Task, error)
 	DisableFirewall(vappNetworkID string) (Task, error)
+	DisableFirewallContext(ctx context.Context, vappNetworkID string) (Task, error)
 	GetNAT(vappNetworkID string) (VAppNetworkNAT, error)
+	GetNATContext(ctx context.Context, vappNetworkID string) (VAppNetworkNAT, error)
 	UpdateNATIPTranslationRules(vappNetworkID string, rules []IPTranslationRule) (Task, error)
+	UpdateNATIPTranslationRulesContext(ctx context.Context, vappNetworkID string, rules []IPTranslationRule) (Task, error)
 	UpdateNATPortForwardingRules(vappNetworkID string, rules []PortForwardingRule) (Task, error)
+	UpdateNATPortForwardingRulesContext(ctx context.Context, vappNetworkID string, rules []PortForwardingRule) (Task, error)
 	EnableNAT(vappNetworkID string) (Task, error)
+	EnableNATContext(ctx context.Context, vappNetworkID string) (Task, error)
 	DisableNAT(vappNetworkID string) (Task, error)
+	DisableNATContext(ctx context.Context, vappNetworkID string) (Task, error)
 	GetInterfaces(vappNetwork string) ([]VirtualMachineInterface, error)
+	GetInterfacesContext(ctx context.Context, vappNetwork string) ([]VirtualMachineInterface, error)
+	ListInterfaces(ctx context.Context, vappNetwork string, opts ListOptions) iter.Seq2[VirtualMachineInterface, error]
 }
 
 type VirtualMachineService interface {
 	Get(virtualMachineID string) (VirtualMachine, error)
+	GetContext(ctx context.Context, virtualMachineID string) (VirtualMachine, error)
 	Delete(virtualMachineID string) (Task, error)
+	DeleteContext(ctx context.Context, virtualMachineID string) (Task, error)
 	UpdateName(virtualMachineID, name string) (Task, error)
+	UpdateNameContext(ctx context.Context, virtualMachineID, name string) (Task, error)
 	UpdateDescription(virtualMachineID, description string) (Task, error)
+	UpdateDescriptionContext(ctx context.Context, virtualMachineID, description string) (Task, error)
 	PowerOn(virtualMachineID string) (Task, error)
+	PowerOnContext(ctx context.Context, virtualMachineID string) (Task, error)
 	PowerOnForceCustomization(virtualMachineID string) (Task, error)
+	PowerOnForceCustomizationContext(ctx context.Context, virtualMachineID string) (Task, error)
 	PowerOff(virtualMachineID string) (Task, error)
+	PowerOffContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Reboot(virtualMachineID string) (Task, error)
+	RebootContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Reset(virtualMachineID string) (Task, error)
+	ResetContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Shutdown(virtualMachineID string) (Task, error)
+	ShutdownContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Suspend(virtualMachineID string) (Task, error)
+	SuspendContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Copy(virtualMachineID string, params CopyVirtualMachineParams) (Task, error)
+	CopyContext(ctx context.Context, virtualMachineID string, params CopyVirtualMachineParams) (Task, error)
 	Move(virtualMachineID string, params MoveVirtualMachineParams) (Task, error)
+	MoveContext(ctx context.Context, virtualMachineID string, params MoveVirtualMachineParams) (Task, error)
 
 	GetSummary(virtualMachineID string) (Summary, error)
+	GetSummaryContext(ctx context.Context, virtualMachineID string) (Summary, error)
 	GetAvailableStorageProfiles(virtualMachineID string) ([]StorageProfile, error)
+	GetAvailableStorageProfilesContext(ctx context.Context, virtualMachineID string) ([]StorageProfile, error)
 	ChangeStorageProfile(virtualMachineID, storageProfileID string) (Task, error)
+	ChangeStorageProfileContext(ctx context.Context, virtualMachineID, storageProfileID string) (Task, error)
 	EnableNestedHypervisor(virtualMachineID string) (Task, error)
+	EnableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (Task, error)
 	DisableNestedHypervisor(virtualMachineID string) (Task, error)
+	DisableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (Task, error)
 	InsertMedia(virtualMachineID, mediaID string) (Task, error)
+	InsertMediaContext(ctx context.Context, virtualMachineID, mediaID string) (Task, error)
 	EjectMedia(virtualMachineID string) (Task, error)
+	EjectMediaContext(ctx context.Context, virtualMachineID string) (Task, error)
 	GetGuestCustomization(virtualMachineID string) (GuestCustomization, error)
+	GetGuestCustomizationContext(ctx context.Context, virtualMachineID string) (GuestCustomization, error)
 	UpdateGuestCustomization(virtualMachineID string, params GuestCustomization) (Task, error)
+	UpdateGuestCustomizationContext(ctx context.Context, virtualMachineID string, params GuestCustomization) (Task, error)
 	GetHotAdd(virtualMachineID string) (HotAdd, error)
+	GetHotAddContext(ctx context.Context, virtualMachineID string) (HotAdd, error)
 	UpdateHotAdd(virtualMachineID string, params HotAdd) (Task, error)
+	UpdateHotAddContext(ctx context.Context, virtualMachineID string, params HotAdd) (Task, error)
 	GetBootOptions(virtualMachineID string) (BootOptions, error)
+	GetBootOptionsContext(ctx context.Context, virtualMachineID string) (BootOptions, error)
 	UpdateBootOptions(virtualMachineID string, params BootOptions) (Task, error)
+	UpdateBootOptionsContext(ctx context.Context, virtualMachineID string, params BootOptions) (Task, error)
 	UpdateHardwareVersion(virtualMachineID string) (Task, error)
+	UpdateHardwareVersionContext(ctx context.Context, virtualMachineID string) (Task, error)
 	GetVMwareTools(virtualMachineID string) (VMwareTools, error)
+	GetVMwareToolsContext(ctx context.Context, virtualMachineID string) (VMwareTools, error)
 	UpgradeVMwareTools(virtualMachineID string) (Task, error)
+	UpgradeVMwareToolsContext(ctx context.Context, virtualMachineID string) (Task, error)
 	InstallVMwareTools(virtualMachineID string) (Task, error)
+	InstallVMwareToolsContext(ctx context.Context, virtualMachineID string) (Task, error)
 	Reconfigure(virtualMachineID string, params ReconfigureParams) (Task, error)
+	ReconfigureContext(ctx context.Context, virtualMachineID string, params ReconfigureParams) (Task, error)
 	GetDisks(virtualMachineID string) ([]Disk, error)
+	GetDisksContext(ctx context.Context, virtualMachineID string) ([]Disk, error)
 	AddDisk(virtualMachineID string, params DiskParams) (Task, error)
+	AddDiskContext(ctx context.Context, virtualMachineID string, params DiskParams) (Task, error)
 	UpdateDisk(virtualMachineID string, params DiskParams) (Task, error)
+	UpdateDiskContext(ctx context.Context, virtualMachineID string, params DiskParams) (Task, error)
 	UpdateDisks(virtualMachineID string, params []DiskParams) (Task, error)
+	UpdateDisksContext(ctx context.Context, virtualMachineID string, params []DiskParams) (Task, error)
 	DeleteDisk(virtualMachineID string, diskName string) (Task, error)
+	DeleteDiskContext(ctx context.Context, virtualMachineID string, diskName string) (Task, error)
 	GetRecommendedBusType(virtualMachineID string) (string, error)
+	GetRecommendedBusTypeContext(ctx context.Context, virtualMachineID string) (string, error)
 	GetNics(virtualMachineID string) ([]Nic, error)
+	GetNicsContext(ctx context.Context, virtualMachineID string) ([]Nic, error)
 	DeleteNic(virtualMachineID string, nicID int) (Task, error)
+	DeleteNicContext(ctx context.Context, virtualMachineID string, nicID int) (Task, error)
 	UpdateNics(virtualMachineID string, params []Nic) (Task, error)
+	UpdateNicsContext(ctx context.Context, virtualMachineID string, params []Nic) (Task, error)
 	UpdateCPU(virtualMachineID string, params UpdateCPUParams) (Task, error)
+	UpdateCPUContext(ctx context.Context, virtualMachineID string, params UpdateCPUParams) (Task, error)
 	UpdateCPUCount(virtualMachineID string, cpuCount int) (Task, error)
+	UpdateCPUCountContext(ctx context.Context, virtualMachineID string, cpuCount int) (Task, error)
 	UpdateMemory(virtualMachineID string, memorySize int) (Task, error)
+	UpdateMemoryContext(ctx context.Context, virtualMachineID string, memorySize int) (Task, error)
 	GetBackups(virtualMachineID string) ([]VirtualMachineBackup, error)
+	GetBackupsContext(ctx context.Context, virtualMachineID string) ([]VirtualMachineBackup, error)
+	ListBackups(ctx context.Context, virtualMachineID string, opts ListOptions) iter.Seq2[VirtualMachineBackup, error]
 	RestoreBackup(virtualMachineID string, backupTimestamp int) (Task, error)
+	RestoreBackupContext(ctx context.Context, virtualMachineID string, backupTimestamp int) (Task, error)
 	RestoreBackupToVApp(virtualMachineID, vappID string, backupTimestamp int) (Task, error)
+	RestoreBackupToVAppContext(ctx context.Context, virtualMachineID, vappID string, backupTimestamp int) (Task, error)
 	HasSnapshot(virtualMachineID string) (bool, error)
+	HasSnapshotContext(ctx context.Context, virtualMachineID string) (bool, error)
 	GetSnapshot(virtualMachineID string) (Snapshot, error)
+	GetSnapshotContext(ctx context.Context, virtualMachineID string) (Snapshot, error)
 	CreateSnapshot(virtualMachineID string) (Task, error)
+	CreateSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error)
 	RestoreSnapshot(virtualMachineID string) (Task, error)
+	RestoreSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error)
 	RemoveSnapshot(virtualMachineID string) (Task, error)
+	RemoveSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error)
 
 	GetNetworks(virtualMachineID string) ([]VAppNetwork, error)
+	GetNetworksContext(ctx context.Context, virtualMachineID string) ([]VAppNetwork, error)
+	ListNetworks(ctx context.Context, virtualMachineID string, opts ListOptions) iter.Seq2[VAppNetwork, error]
 	GetCurrentBill(virtualMachineID string) (Billing, error)
+	GetCurrentBillContext(ctx context.Context, virtualMachineID string) (Billing, error)
 	GetBill(virtualMachineID string, month, year int) (Billing, error)
+	GetBillContext(ctx context.Context, virtualMachineID string, month, year int) (Billing, error)
 	GetMetadata(virtualMachineID string) ([]Metadata, error)
+	GetMetadataContext(ctx context.Context, virtualMachineID string) ([]Metadata, error)
 	UpdateMetadata(virtualMachineID string, metadata []Metadata) (Task, error)
+	UpdateMetadataContext(ctx context.Context, virtualMachineID string, metadata []Metadata) (Task, error)
 	DeleteMetadata(virtualMachineID string, metadataKey string) (Task, error)
+	DeleteMetadataContext(ctx context.Context, virtualMachineID string, metadataKey string) (Task, error)
 	GetPerformanceCounters(virtualMachineID string) ([]PerformanceCounter, error)
+	GetPerformanceCountersContext(ctx context.Context, virtualMachineID string) ([]PerformanceCounter, error)
 	GetPerformance(virtualMachineID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
+	GetPerformanceContext(ctx context.Context, virtualMachineID string, counter PerformanceCounter, start, end time.Time) (Performance, error)
 	GetConsoleSession(virtualMachineID string) (ConsoleSession, error)
+	GetConsoleSessionContext(ctx context.Context, virtualMachineID string) (ConsoleSession, error)
 	GetScreenThumbnail(virtualMachineID string) ([]byte, error)
+	GetScreenThumbnailContext(ctx context.Context, virtualMachineID string) ([]byte, error)
 }
 
 type VpgService interface {
 	Get(vpgID string) (Vpg, error)
+	GetContext(ctx context.Context, vpgID string) (Vpg, error)
 	GetCheckpoints(vpgID string) ([]VpgCheckpoint, error)
+	GetCheckpointsContext(ctx context.Context, vpgID string) ([]VpgCheckpoint, error)
+	ListCheckpoints(ctx context.Context, vpgID string, opts ListOptions) iter.Seq2[VpgCheckpoint, error]
 }
@@ -0,0 +1,501 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// InventoryCacheOptions configures an InventoryCache.
+type InventoryCacheOptions struct {
+	// ResyncInterval is how often every entity is fetched again, repairing
+	// anything the events missed. It defaults to 10 minutes; a negative
+	// interval disables resyncs.
+	ResyncInterval time.Duration
+	// Concurrency is how many orgs are fetched at once. It defaults to 8.
+	Concurrency int
+	// Stream configures the event stream. Its Filter is ignored.
+	Stream EventStreamOptions
+	// OnChange, if set, is called for each entity added, modified or
+	// removed after the cache was seeded. It runs on the cache's goroutine,
+	// may read the cache and should return quickly.
+	OnChange func(CacheChange)
+	// OnError, if set, is called with the errors of event updates and
+	// resyncs. The cache keeps its previous state for the entities affected.
+	OnError func(error)
+}
+
+// CacheChange is an entity added to, modified in or removed from an
+// InventoryCache. Old is nil for an added entity and New for a removed one.
+type CacheChange struct {
+	Kind ChangeKind
+	// Type is the entity type, such as EntityIaasVm.
+	Type string
+	ID   string
+	Old  interface{}
+	New  interface{}
+}
+
+// InventoryCache keeps a local copy of a company's orgs, vdcs, vApps, VMs,
+// edges and networks so that reads do not reach the API. It is seeded from
+// the list calls, and each event on one of those entities refetches it, or
+// removes it once it is deleted. Events on a vApp also refetch its VMs, and
+// network events on a vdc refetch its networks. Reads are safe for
+// concurrent use, and lists are ordered by ID.
+type InventoryCache struct {
+	console   ConsoleService
+	companyID string
+	opts      InventoryCacheOptions
+	stream    *EventStream
+	cancel    context.CancelFunc
+	done      chan struct{}
+	resyncs   chan resyncRequest
+
+	mu       sync.RWMutex
+	entities map[string]map[string]interface{}
+	synced   time.Time
+}
+
+// cacheTypes are the entity types an InventoryCache holds.
+var cacheTypes = []string{EntityIaasOrganization, EntityIaasVdc, EntityIaasVApp, EntityIaasVm, EntityIaasEdge, EntityIaasInternalNetwork}
+
+// NewInventoryCache returns an empty cache of companyID. Call Start to fill
+// it.
+func NewInventoryCache(console ConsoleService, companyID string, opts InventoryCacheOptions) *InventoryCache {
+	if opts.ResyncInterval == 0 {
+		opts.ResyncInterval = 10 * time.Minute
+	}
+	entities := map[string]map[string]interface{}{}
+	for _, entityType := range cacheTypes {
+		entities[entityType] = map[string]interface{}{}
+	}
+	return &InventoryCache{console: console, companyID: companyID, opts: opts, entities: entities, done: make(chan struct{}), resyncs: make(chan resyncRequest)}
+}
+
+// Start opens the event stream and seeds the cache, then keeps it in sync
+// until Close. ctx only bounds opening the stream and seeding. If seeding
+// partly fails, the cache starts with what was fetched and the
+// *PartialError is returned.
+func (c *InventoryCache) Start(ctx context.Context) error {
+	ctx = withOperation(ctx, "InventoryCache.Start", c.companyID)
+	// The stream is opened first so that changes made while seeding are
+	// not missed.
+	streamOpts := c.opts.Stream
+	streamOpts.Filter = EventFilter{}
+	stream, err := c.console.OpenEventStream(context.WithoutCancel(ctx), c.companyID, streamOpts)
+	if err != nil {
+		return err
+	}
+	c.stream = stream
+	err = c.resync(ctx, false)
+	var partial *PartialError
+	if err != nil && !errors.As(err, &partial) {
+		stream.Close()
+		return err
+	}
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c.cancel = cancel
+	go c.run(runCtx)
+	return err
+}
+
+// Close stops the cache. Its contents stay readable.
+func (c *InventoryCache) Close() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	<-c.done
+}
+
+// LastSync returns when every entity was last fetched successfully.
+func (c *InventoryCache) LastSync() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.synced
+}
+
+// resyncRequest asks the cache's goroutine to resync on behalf of Resync.
+type resyncRequest struct {
+	ctx   context.Context
+	reply chan error
+}
+
+// Resync fetches every entity again now, reporting the differences to
+// OnChange. Entities are only removed if the whole fetch succeeds. Once the
+// cache is started, the fetch runs on the cache's goroutine, so that it is
+// not applied over the updates of events received meanwhile.
+func (c *InventoryCache) Resync(ctx context.Context) error {
+	ctx = withOperation(ctx, "InventoryCache.Resync", c.companyID)
+	if c.cancel == nil {
+		return c.resync(ctx, true)
+	}
+	req := resyncRequest{ctx: ctx, reply: make(chan error, 1)}
+	select {
+	case c.resyncs <- req:
+	case <-c.done:
+		return c.resync(ctx, true)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *InventoryCache) run(ctx context.Context) {
+	defer close(c.done)
+	defer c.stream.Close()
+	var tick <-chan time.Time
+	if c.opts.ResyncInterval > 0 {
+		ticker := time.NewTicker(c.opts.ResyncInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case event, ok := <-c.stream.Events():
+			if !ok {
+				c.report(c.stream.Err())
+				return
+			}
+			c.update(ctx, event)
+		case <-tick:
+			c.report(c.resync(ctx, true))
+		case req := <-c.resyncs:
+			// The resync stops with either the caller's context or Close.
+			reqCtx, cancel := context.WithCancel(req.ctx)
+			stop := context.AfterFunc(ctx, cancel)
+			req.reply <- c.resync(reqCtx, true)
+			stop()
+			cancel()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// update applies one event.
+func (c *InventoryCache) update(ctx context.Context, event Event) {
+	if _, ok := c.entities[event.EntityType]; !ok || event.EntityID == "" {
+		return
+	}
+	switch event.Type {
+	case EventVmDeleted, EventVAppDeleted:
+		c.remove(event.EntityType, event.EntityID)
+		return
+	case EventOrgVdcNetworkCreated, EventOrgVdcNetworkUpdated, EventOrgVdcNetworkDeleted:
+		// Network events are on the vdc, so its networks are listed again.
+		c.updateNetworks(ctx, event.EntityID)
+		return
+	}
+	entity, err := c.fetch(ctx, event.EntityType, event.EntityID)
+	if errors.Is(err, ErrNotFound) {
+		c.remove(event.EntityType, event.EntityID)
+		return
+	}
+	if err != nil {
+		c.report(err)
+		return
+	}
+	c.apply(event.EntityType, map[string]interface{}{event.EntityID: entity}, nil)
+	if event.EntityType == EntityIaasVApp {
+		c.updateVMs(ctx, event.EntityID)
+	}
+}
+
+// remove removes an entity, along with its VMs if it is a vApp.
+func (c *InventoryCache) remove(entityType, id string) {
+	c.apply(entityType, map[string]interface{}{id: nil}, nil)
+	if entityType == EntityIaasVApp {
+		c.apply(EntityIaasVm, map[string]interface{}{}, func(v interface{}) bool { return v.(VirtualMachine).VAppID == id })
+	}
+}
+
+// updateVMs replaces the cached VMs of a vApp.
+func (c *InventoryCache) updateVMs(ctx context.Context, vappID string) {
+	vms, err := c.console.VApp().GetVirtualMachinesContext(ctx, vappID)
+	if err != nil {
+		c.report(err)
+		return
+	}
+	updates := map[string]interface{}{}
+	for _, vm := range vms {
+		updates[vm.ID] = vm
+	}
+	c.apply(EntityIaasVm, updates, func(v interface{}) bool { return v.(VirtualMachine).VAppID == vappID })
+}
+
+// updateNetworks replaces the cached networks of a vdc, removing them all
+// if the vdc no longer exists.
+func (c *InventoryCache) updateNetworks(ctx context.Context, vdcID string) {
+	networks, err := c.console.Vdc().GetNetworksContext(ctx, vdcID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		c.report(err)
+		return
+	}
+	updates := map[string]interface{}{}
+	for _, network := range networks {
+		updates[network.ID] = network
+	}
+	c.apply(EntityIaasInternalNetwork, updates, func(v interface{}) bool { return v.(OrgVdcNetwork).VdcID == vdcID })
+}
+
+func (c *InventoryCache) fetch(ctx context.Context, entityType, id string) (interface{}, error) {
+	switch entityType {
+	case EntityIaasOrganization:
+		return c.console.Org().GetContext(ctx, id)
+	case EntityIaasVdc:
+		return c.console.Vdc().GetContext(ctx, id)
+	case EntityIaasVApp:
+		return c.console.VApp().GetContext(ctx, id)
+	case EntityIaasVm:
+		return c.console.VirtualMachine().GetContext(ctx, id)
+	case EntityIaasEdge:
+		return c.console.Edge().GetContext(ctx, id)
+	}
+	return c.console.OrgVdcNetwork().GetContext(ctx, id)
+}
+
+// cacheEntry is an entity fetched by a resync.
+type cacheEntry struct {
+	entityType string
+	id         string
+	value      interface{}
+}
+
+// resync fetches every entity. With notify unset, as when seeding, no
+// changes are reported.
+func (c *InventoryCache) resync(ctx context.Context, notify bool) error {
+	const op = "InventoryCache.Resync"
+	orgs, orgsErr := c.console.Company().GetOrgsContext(ctx, c.companyID)
+	var partial *PartialError
+	if orgsErr != nil && !errors.As(orgsErr, &partial) {
+		return orgsErr
+	}
+	orgIDs := make([]string, len(orgs))
+	for i, org := range orgs {
+		orgIDs[i] = org.ID
+	}
+	entries, listErr := fanOut(ctx, op, orgIDs, c.opts.Concurrency, c.fetchOrg)
+	fresh := map[string]map[string]interface{}{}
+	for _, entityType := range cacheTypes {
+		fresh[entityType] = map[string]interface{}{}
+	}
+	for _, org := range orgs {
+		fresh[EntityIaasOrganization][org.ID] = org
+	}
+	for _, entry := range entries {
+		fresh[entry.entityType][entry.id] = entry.value
+	}
+	err := joinPartial(op, orgsErr, listErr)
+	// A failed fetch looks like removals, so they are only trusted when
+	// everything was fetched.
+	removeStale := func(interface{}) bool { return true }
+	if err != nil {
+		removeStale = nil
+	}
+	changes := []CacheChange{}
+	c.mu.Lock()
+	for _, entityType := range cacheTypes {
+		changes = append(changes, c.applyLocked(entityType, fresh[entityType], removeStale)...)
+	}
+	if err == nil {
+		c.synced = time.Now()
+	}
+	c.mu.Unlock()
+	if notify {
+		c.notify(changes)
+	}
+	return err
+}
+
+// fetchOrg lists the entities of one org.
+func (c *InventoryCache) fetchOrg(ctx context.Context, orgID string) ([]cacheEntry, error) {
+	org := c.console.Org()
+	entries := []cacheEntry{}
+	vdcs, err := org.GetVdcsContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vdc := range vdcs {
+		entries = append(entries, cacheEntry{EntityIaasVdc, vdc.ID, vdc})
+	}
+	vapps, err := org.GetVAppsContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vapp := range vapps {
+		entries = append(entries, cacheEntry{EntityIaasVApp, vapp.ID, vapp})
+	}
+	vms, err := org.GetVirtualMachinesContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vm := range vms {
+		entries = append(entries, cacheEntry{EntityIaasVm, vm.ID, vm})
+	}
+	edges, err := org.GetEdgesContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range edges {
+		entries = append(entries, cacheEntry{EntityIaasEdge, edge.ID, edge})
+	}
+	networks, err := org.GetNetworksContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range networks {
+		entries = append(entries, cacheEntry{EntityIaasInternalNetwork, network.ID, network})
+	}
+	return entries, nil
+}
+
+// apply stores updates, where a nil value removes the entity, and reports
+// the changes.
+func (c *InventoryCache) apply(entityType string, updates map[string]interface{}, removeStale func(interface{}) bool) {
+	c.mu.Lock()
+	changes := c.applyLocked(entityType, updates, removeStale)
+	c.mu.Unlock()
+	c.notify(changes)
+}
+
+// applyLocked stores updates. If removeStale is set, cached entities for
+// which it returns true that are missing from updates are removed too.
+func (c *InventoryCache) applyLocked(entityType string, updates map[string]interface{}, removeStale func(interface{}) bool) []CacheChange {
+	table := c.entities[entityType]
+	changes := []CacheChange{}
+	if removeStale != nil {
+		for _, id := range mapKeys(table) {
+			if _, ok := updates[id]; !ok && removeStale(table[id]) {
+				changes = append(changes, CacheChange{Kind: ChangeRemoved, Type: entityType, ID: id, Old: table[id]})
+				delete(table, id)
+			}
+		}
+	}
+	for _, id := range mapKeys(updates) {
+		value := updates[id]
+		old, exists := table[id]
+		switch {
+		case value == nil && exists:
+			changes = append(changes, CacheChange{Kind: ChangeRemoved, Type: entityType, ID: id, Old: old})
+			delete(table, id)
+		case value == nil:
+		case !exists:
+			changes = append(changes, CacheChange{Kind: ChangeAdded, Type: entityType, ID: id, New: value})
+			table[id] = value
+		case !reflect.DeepEqual(old, value):
+			changes = append(changes, CacheChange{Kind: ChangeModified, Type: entityType, ID: id, Old: old, New: value})
+			table[id] = value
+		}
+	}
+	return changes
+}
+
+func (c *InventoryCache) notify(changes []CacheChange) {
+	if c.opts.OnChange == nil {
+		return
+	}
+	for _, change := range changes {
+		c.opts.OnChange(change)
+	}
+}
+
+func (c *InventoryCache) report(err error) {
+	if err != nil && c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// Org returns the cached org with id.
+func (c *InventoryCache) Org(id string) (Org, bool) {
+	return cacheGet[Org](c, EntityIaasOrganization, id)
+}
+
+// Orgs returns every cached org.
+func (c *InventoryCache) Orgs() []Org {
+	return cacheList(c, EntityIaasOrganization, "", func(Org) []string { return nil })
+}
+
+// Vdc returns the cached vdc with id.
+func (c *InventoryCache) Vdc(id string) (Vdc, bool) {
+	return cacheGet[Vdc](c, EntityIaasVdc, id)
+}
+
+// Vdcs returns the cached vdcs of the org parentID, or every vdc if it is
+// empty.
+func (c *InventoryCache) Vdcs(parentID string) []Vdc {
+	return cacheList(c, EntityIaasVdc, parentID, func(v Vdc) []string { return []string{v.OrgID} })
+}
+
+// VApp returns the cached vApp with id.
+func (c *InventoryCache) VApp(id string) (VApp, bool) {
+	return cacheGet[VApp](c, EntityIaasVApp, id)
+}
+
+// VApps returns the cached vApps below parentID, an org or vdc ID, or
+// every vApp if it is empty.
+func (c *InventoryCache) VApps(parentID string) []VApp {
+	return cacheList(c, EntityIaasVApp, parentID, func(v VApp) []string { return []string{v.OrgID, v.VdcID} })
+}
+
+// VirtualMachine returns the cached VM with id.
+func (c *InventoryCache) VirtualMachine(id string) (VirtualMachine, bool) {
+	return cacheGet[VirtualMachine](c, EntityIaasVm, id)
+}
+
+// VirtualMachines returns the cached VMs below parentID, an org, vdc or
+// vApp ID, or every VM if it is empty.
+func (c *InventoryCache) VirtualMachines(parentID string) []VirtualMachine {
+	return cacheList(c, EntityIaasVm, parentID, func(v VirtualMachine) []string { return []string{v.OrgID, v.VdcID, v.VAppID} })
+}
+
+// Edge returns the cached edge with id.
+func (c *InventoryCache) Edge(id string) (Edge, bool) {
+	return cacheGet[Edge](c, EntityIaasEdge, id)
+}
+
+// Edges returns the cached edges below parentID, an org or vdc ID, or
+// every edge if it is empty.
+func (c *InventoryCache) Edges(parentID string) []Edge {
+	return cacheList(c, EntityIaasEdge, parentID, func(e Edge) []string { return []string{e.OrgID, e.VdcID} })
+}
+
+// Network returns the cached org vdc network with id.
+func (c *InventoryCache) Network(id string) (OrgVdcNetwork, bool) {
+	return cacheGet[OrgVdcNetwork](c, EntityIaasInternalNetwork, id)
+}
+
+// Networks returns the cached org vdc networks below parentID, an org or
+// vdc ID, or every network if it is empty.
+func (c *InventoryCache) Networks(parentID string) []OrgVdcNetwork {
+	return cacheList(c, EntityIaasInternalNetwork, parentID, func(n OrgVdcNetwork) []string { return []string{n.OrgID, n.VdcID} })
+}
+
+func cacheGet[T any](c *InventoryCache, entityType, id string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.entities[entityType][id].(T)
+	return value, ok
+}
+
+func cacheList[T any](c *InventoryCache, entityType, parentID string, parents func(T) []string) []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	table := c.entities[entityType]
+	items := []T{}
+	for _, id := range mapKeys(table) {
+		item := table[id].(T)
+		if parentID == "" || slices.Contains(parents(item), parentID) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
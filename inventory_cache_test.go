@@ -0,0 +1,165 @@
+package iland_test
+
+import (
+	"context"
+	"testing"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+// cacheFixture is the inventory a cache test starts from.
+type cacheFixture struct {
+	company      iland.Company
+	vdc          iland.Vdc
+	otherVdc     iland.Vdc
+	vapp         iland.VApp
+	otherVApp    iland.VApp
+	network      iland.OrgVdcNetwork
+	otherNetwork iland.OrgVdcNetwork
+}
+
+func newCacheFixture(srv *ilandtest.Server) cacheFixture {
+	var f cacheFixture
+	f.company = srv.AddCompany(iland.Company{Name: "acme"})
+	org := srv.AddOrg(iland.Org{Name: "org", CompanyID: f.company.ID, LocationID: "dal02.ilandcloud.com"})
+	f.vdc = srv.AddVdc(iland.Vdc{Name: "vdc", OrgID: org.ID})
+	f.otherVdc = srv.AddVdc(iland.Vdc{Name: "other vdc", OrgID: org.ID})
+	f.vapp = srv.AddVApp(iland.VApp{Name: "app", VdcID: f.vdc.ID})
+	f.otherVApp = srv.AddVApp(iland.VApp{Name: "other app", VdcID: f.vdc.ID})
+	srv.AddVirtualMachine(iland.VirtualMachine{Name: "web", VAppID: f.vapp.ID})
+	srv.AddVirtualMachine(iland.VirtualMachine{Name: "db", VAppID: f.vapp.ID})
+	srv.AddVirtualMachine(iland.VirtualMachine{Name: "other", VAppID: f.otherVApp.ID})
+	f.network = srv.AddNetwork(iland.OrgVdcNetwork{Name: "net", VdcID: f.vdc.ID})
+	f.otherNetwork = srv.AddNetwork(iland.OrgVdcNetwork{Name: "other net", VdcID: f.otherVdc.ID})
+	return f
+}
+
+func startCache(t *testing.T, srv *ilandtest.Server, f cacheFixture) *iland.InventoryCache {
+	t.Helper()
+	c, err := srv.NewClient(iland.WithRetryPolicy(iland.NoRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := iland.NewInventoryCache(c, f.company.ID, iland.InventoryCacheOptions{
+		OnError: func(err error) { t.Errorf("cache error: %v", err) },
+	})
+	if err := cache.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(cache.Networks("")); got != 2 {
+		t.Fatalf("networks after Start = %d, want 2", got)
+	}
+	return cache
+}
+
+func TestInventoryCacheEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		// act changes the server and publishes the event of the change.
+		act func(srv *ilandtest.Server, f cacheFixture)
+		// done reports whether the cache has applied the change.
+		done func(cache *iland.InventoryCache, f cacheFixture) bool
+		// check verifies the entities the change must leave alone.
+		check func(t *testing.T, cache *iland.InventoryCache, f cacheFixture)
+	}{
+		{
+			name: "vApp deleted removes its VMs",
+			act: func(srv *ilandtest.Server, f cacheFixture) {
+				srv.PublishEvent(iland.Event{Type: iland.EventVAppDeleted, EntityType: iland.EntityIaasVApp, EntityID: f.vapp.ID, OwnerID: f.company.ID})
+			},
+			done: func(cache *iland.InventoryCache, f cacheFixture) bool {
+				_, ok := cache.VApp(f.vapp.ID)
+				return !ok && len(cache.VirtualMachines(f.vapp.ID)) == 0
+			},
+			check: func(t *testing.T, cache *iland.InventoryCache, f cacheFixture) {
+				if got := len(cache.VirtualMachines(f.otherVApp.ID)); got != 1 {
+					t.Errorf("VMs of other vApp = %d, want 1", got)
+				}
+			},
+		},
+		{
+			name: "network created",
+			act: func(srv *ilandtest.Server, f cacheFixture) {
+				srv.AddNetwork(iland.OrgVdcNetwork{Name: "new net", VdcID: f.vdc.ID})
+				srv.PublishEvent(iland.Event{Type: iland.EventOrgVdcNetworkCreated, EntityType: iland.EntityIaasVdc, EntityID: f.vdc.ID, OwnerID: f.company.ID})
+			},
+			done: func(cache *iland.InventoryCache, f cacheFixture) bool {
+				return len(cache.Networks(f.vdc.ID)) == 2
+			},
+		},
+		{
+			name: "network updated",
+			act: func(srv *ilandtest.Server, f cacheFixture) {
+				network := f.network
+				network.Name = "renamed"
+				srv.AddNetwork(network)
+				srv.PublishEvent(iland.Event{Type: iland.EventOrgVdcNetworkUpdated, EntityType: iland.EntityIaasVdc, EntityID: f.vdc.ID, OwnerID: f.company.ID})
+			},
+			done: func(cache *iland.InventoryCache, f cacheFixture) bool {
+				network, _ := cache.Network(f.network.ID)
+				return network.Name == "renamed"
+			},
+		},
+		{
+			name: "network deleted",
+			act: func(srv *ilandtest.Server, f cacheFixture) {
+				srv.RemoveNetwork(f.network.ID)
+				srv.PublishEvent(iland.Event{Type: iland.EventOrgVdcNetworkDeleted, EntityType: iland.EntityIaasVdc, EntityID: f.vdc.ID, OwnerID: f.company.ID})
+			},
+			done: func(cache *iland.InventoryCache, f cacheFixture) bool {
+				_, ok := cache.Network(f.network.ID)
+				return !ok
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ilandtest.NewServer()
+			defer srv.Close()
+			f := newCacheFixture(srv)
+			cache := startCache(t, srv, f)
+			defer cache.Close()
+
+			tt.act(srv, f)
+			waitFor(t, tt.name, func() bool { return tt.done(cache, f) })
+			// Network events carry the vdc's ID, which must not be taken
+			// for a network's or remove the vdc.
+			for _, vdc := range []iland.Vdc{f.vdc, f.otherVdc} {
+				if _, ok := cache.Vdc(vdc.ID); !ok {
+					t.Errorf("vdc %s removed", vdc.Name)
+				}
+			}
+			if _, ok := cache.Network(f.otherNetwork.ID); !ok {
+				t.Errorf("network of other vdc removed")
+			}
+			if tt.check != nil {
+				tt.check(t, cache, f)
+			}
+		})
+	}
+}
+
+func TestInventoryCacheResync(t *testing.T) {
+	srv := ilandtest.NewServer()
+	defer srv.Close()
+	f := newCacheFixture(srv)
+	cache := startCache(t, srv, f)
+
+	srv.AddEdge(iland.Edge{Name: "edge", VdcID: f.vdc.ID})
+	if err := cache.Resync(context.Background()); err != nil {
+		t.Fatalf("Resync: %v", err)
+	}
+	if got := len(cache.Edges(f.vdc.ID)); got != 1 {
+		t.Fatalf("edges after Resync = %d, want 1", got)
+	}
+
+	cache.Close()
+	srv.AddEdge(iland.Edge{Name: "edge 2", VdcID: f.vdc.ID})
+	if err := cache.Resync(context.Background()); err != nil {
+		t.Fatalf("Resync after Close: %v", err)
+	}
+	if got := len(cache.Edges(f.vdc.ID)); got != 2 {
+		t.Fatalf("edges after Resync = %d, want 2", got)
+	}
+}
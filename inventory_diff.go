@@ -0,0 +1,323 @@
+package iland
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ChangeKind is how an entity differs between two snapshots.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// DiffOptions configures DiffSnapshots.
+type DiffOptions struct {
+	// IgnoreFields lists fields left out of the comparison. A name such as
+	// "updated_date" matches that field at any depth; a path such as
+	// "firewall.firewall_rules" matches it and everything below it.
+	IgnoreFields []string
+}
+
+// SnapshotDiff lists the entities that differ between two snapshots.
+type SnapshotDiff struct {
+	CompanyID string         `json:"company_id"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Changes   []EntityChange `json:"changes"`
+}
+
+// EntityChange is an entity that was added, removed or modified.
+type EntityChange struct {
+	Kind ChangeKind `json:"kind"`
+	// Type is the entity type, such as EntityIaasVm.
+	Type string `json:"type"`
+	ID   string `json:"uuid"`
+	Name string `json:"name"`
+	// Fields lists the fields that changed in a modified entity.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// FieldChange is a field that differs between two versions of an entity.
+// Path uses the JSON field names of the snapshot, with list elements
+// identified by their ID, key or name where they have one and by index
+// otherwise, such as "metadata[owner].value" or "disks[1].size". Old is
+// nil for an added field and New for a removed one, and both are always
+// encoded, as null in those cases, so zero values such as false, 0 and ""
+// are told apart from missing ones.
+type FieldChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// Empty reports whether the snapshots were the same.
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// WriteJSON writes the diff as indented JSON.
+func (d *SnapshotDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteText writes the diff for people to read: one line per entity marked
+// "+", "-" or "~", followed by the changed fields of modified entities.
+func (d *SnapshotDiff) WriteText(w io.Writer) error {
+	b := &strings.Builder{}
+	marks := map[ChangeKind]string{ChangeAdded: "+", ChangeRemoved: "-", ChangeModified: "~"}
+	for _, change := range d.Changes {
+		fmt.Fprintf(b, "%s %s %s (%s)\n", marks[change.Kind], change.Type, change.Name, change.ID)
+		for _, field := range change.Fields {
+			switch {
+			case field.Old == nil:
+				fmt.Fprintf(b, "    %s: added %s\n", field.Path, diffValue(field.New))
+			case field.New == nil:
+				fmt.Fprintf(b, "    %s: removed %s\n", field.Path, diffValue(field.Old))
+			default:
+				fmt.Fprintf(b, "    %s: %s -> %s\n", field.Path, diffValue(field.Old), diffValue(field.New))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func diffValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// DiffSnapshots compares two snapshots of a company. Entities are matched
+// by ID, so a renamed entity is modified while a recreated one is removed
+// and added. Changes are ordered by entity type from orgs down to VMs, then
+// by name.
+func DiffSnapshots(from, to *InventorySnapshot, opts DiffOptions) *SnapshotDiff {
+	diff := &SnapshotDiff{CompanyID: to.CompanyID, From: from.TakenAt, To: to.TakenAt, Changes: []EntityChange{}}
+	ignore := func(path string) bool {
+		for _, field := range opts.IgnoreFields {
+			if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+				return true
+			}
+			last := path[strings.LastIndexAny(path, ".]")+1:]
+			if !strings.ContainsAny(field, ".[") && last == field {
+				return true
+			}
+		}
+		return false
+	}
+	old := map[string]snapshotEntity{}
+	for _, e := range from.entities() {
+		old[e.entityType+"/"+e.id] = e
+	}
+	seen := map[string]bool{}
+	for _, e := range to.entities() {
+		key := e.entityType + "/" + e.id
+		seen[key] = true
+		prev, ok := old[key]
+		if !ok {
+			diff.Changes = append(diff.Changes, EntityChange{Kind: ChangeAdded, Type: e.entityType, ID: e.id, Name: e.name})
+			continue
+		}
+		fields := []FieldChange{}
+		diffFields("", prev.value, e.value, ignore, &fields)
+		if len(fields) > 0 {
+			diff.Changes = append(diff.Changes, EntityChange{Kind: ChangeModified, Type: e.entityType, ID: e.id, Name: e.name, Fields: fields})
+		}
+	}
+	for key, e := range old {
+		if !seen[key] {
+			diff.Changes = append(diff.Changes, EntityChange{Kind: ChangeRemoved, Type: e.entityType, ID: e.id, Name: e.name})
+		}
+	}
+	slices.SortStableFunc(diff.Changes, func(a, b EntityChange) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(snapshotTypes, a.Type), slices.Index(snapshotTypes, b.Type)),
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	return diff
+}
+
+var snapshotTypes = []string{EntityIaasOrganization, EntityIaasVdc, EntityIaasInternalNetwork, EntityIaasEdge, EntityIaasVApp, EntityIaasVm}
+
+// snapshotEntity is an entity of a snapshot decoded into generic JSON
+// values for comparison.
+type snapshotEntity struct {
+	entityType string
+	id         string
+	name       string
+	value      interface{}
+}
+
+func (s *InventorySnapshot) entities() []snapshotEntity {
+	entities := []snapshotEntity{}
+	add := func(entityType, id, name string, v interface{}) {
+		data, _ := json.Marshal(v)
+		var value interface{}
+		json.Unmarshal(data, &value)
+		entities = append(entities, snapshotEntity{entityType: entityType, id: id, name: name, value: value})
+	}
+	for _, org := range s.Orgs {
+		add(EntityIaasOrganization, org.ID, org.Name, org)
+	}
+	for _, vdc := range s.Vdcs {
+		add(EntityIaasVdc, vdc.ID, vdc.Name, vdc)
+	}
+	for _, network := range s.Networks {
+		add(EntityIaasInternalNetwork, network.ID, network.Name, network)
+	}
+	for _, edge := range s.Edges {
+		add(EntityIaasEdge, edge.ID, edge.Name, edge)
+	}
+	for _, vapp := range s.VApps {
+		add(EntityIaasVApp, vapp.ID, vapp.Name, vapp)
+	}
+	for _, vm := range s.VMs {
+		add(EntityIaasVm, vm.ID, vm.Name, vm)
+	}
+	return entities
+}
+
+// diffFields appends the differences between two decoded JSON values.
+// Missing, null and empty values are equal.
+func diffFields(path string, old, new interface{}, ignore func(string) bool, changes *[]FieldChange) {
+	if path != "" && ignore(path) {
+		return
+	}
+	if isEmptyJSON(old) && isEmptyJSON(new) {
+		return
+	}
+	if old == nil || new == nil {
+		*changes = append(*changes, FieldChange{Path: path, Old: old, New: new})
+		return
+	}
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := append(mapKeys(oldMap), mapKeys(newMap)...)
+		slices.Sort(keys)
+		for _, key := range slices.Compact(keys) {
+			diffFields(joinPath(path, key), oldMap[key], newMap[key], ignore, changes)
+		}
+		return
+	}
+	oldList, oldIsList := old.([]interface{})
+	newList, newIsList := new.([]interface{})
+	if oldIsList && newIsList {
+		diffLists(path, oldList, newList, ignore, changes)
+		return
+	}
+	if !reflect.DeepEqual(old, new) {
+		*changes = append(*changes, FieldChange{Path: path, Old: old, New: new})
+	}
+}
+
+// diffLists matches elements by identity if they all have one, and by
+// index otherwise.
+func diffLists(path string, old, new []interface{}, ignore func(string) bool, changes *[]FieldChange) {
+	key := listIdentity(old, new)
+	if key == "" {
+		for i := 0; i < max(len(old), len(new)); i++ {
+			var o, n interface{}
+			if i < len(old) {
+				o = old[i]
+			}
+			if i < len(new) {
+				n = new[i]
+			}
+			diffFields(fmt.Sprintf("%s[%d]", path, i), o, n, ignore, changes)
+		}
+		return
+	}
+	byID := map[string]interface{}{}
+	for _, item := range new {
+		byID[fmt.Sprint(item.(map[string]interface{})[key])] = item
+	}
+	seen := map[string]bool{}
+	for _, item := range old {
+		id := fmt.Sprint(item.(map[string]interface{})[key])
+		seen[id] = true
+		diffFields(fmt.Sprintf("%s[%s]", path, id), item, byID[id], ignore, changes)
+	}
+	for _, item := range new {
+		id := fmt.Sprint(item.(map[string]interface{})[key])
+		if !seen[id] {
+			diffFields(fmt.Sprintf("%s[%s]", path, id), nil, item, ignore, changes)
+		}
+	}
+}
+
+// listIdentity returns the first field that holds a unique, non-empty
+// scalar in every element of both lists, or "" if there is none.
+func listIdentity(old, new []interface{}) string {
+	if len(old) == 0 && len(new) == 0 {
+		return ""
+	}
+	candidates := []string{"uuid", "id", "rule_id", "vnic_id", "key", "name"}
+	for _, key := range candidates {
+		ok := true
+		for _, list := range [][]interface{}{old, new} {
+			ids := map[string]bool{}
+			for _, item := range list {
+				m, isMap := item.(map[string]interface{})
+				if !isMap {
+					return ""
+				}
+				v, has := m[key]
+				switch v.(type) {
+				case string, float64:
+				default:
+					has = false
+				}
+				id := fmt.Sprint(v)
+				if !has || id == "" || ids[id] {
+					ok = false
+					break
+				}
+				ids[id] = true
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok {
+			return key
+		}
+	}
+	return ""
+}
+
+func isEmptyJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
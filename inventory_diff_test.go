@@ -0,0 +1,240 @@
+package iland
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshotsKeepsZeroValues(t *testing.T) {
+	vm := func(name string, mediaInserted bool, cpus int) SnapshotVirtualMachine {
+		return SnapshotVirtualMachine{VirtualMachine: VirtualMachine{ID: "vm", Name: name, MediaInserted: mediaInserted, CPUCount: cpus}}
+	}
+	tests := []struct {
+		name     string
+		from, to SnapshotVirtualMachine
+		path     string
+		wantOld  interface{}
+		wantNew  interface{}
+		wantText string
+	}{
+		{name: "true to false", from: vm("a", true, 1), to: vm("a", false, 1), path: "media_inserted", wantOld: true, wantNew: false, wantText: "true -> false"},
+		{name: "number to zero", from: vm("a", false, 2), to: vm("a", false, 0), path: "cpus_number", wantOld: float64(2), wantNew: float64(0), wantText: "2 -> 0"},
+		{name: "string to empty", from: vm("a", false, 1), to: vm("", false, 1), path: "name", wantOld: "a", wantNew: "", wantText: `"a" -> ""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := &InventorySnapshot{Version: SnapshotVersion, VMs: []SnapshotVirtualMachine{tt.from}}
+			to := &InventorySnapshot{Version: SnapshotVersion, VMs: []SnapshotVirtualMachine{tt.to}}
+			diff := DiffSnapshots(from, to, DiffOptions{})
+			buf := &bytes.Buffer{}
+			if err := diff.WriteJSON(buf); err != nil {
+				t.Fatal(err)
+			}
+			decoded := SnapshotDiff{}
+			if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			var field *FieldChange
+			for _, change := range decoded.Changes {
+				for i := range change.Fields {
+					if change.Fields[i].Path == tt.path {
+						field = &change.Fields[i]
+					}
+				}
+			}
+			if field == nil {
+				t.Fatalf("no change to %s in %s", tt.path, buf)
+			}
+			if field.Old != tt.wantOld || field.New != tt.wantNew {
+				t.Errorf("got %#v -> %#v, want %#v -> %#v", field.Old, field.New, tt.wantOld, tt.wantNew)
+			}
+			text := &strings.Builder{}
+			decoded.WriteText(text)
+			if !strings.Contains(text.String(), tt.path+": "+tt.wantText) {
+				t.Errorf("text does not show %q:\n%s", tt.wantText, text)
+			}
+		})
+	}
+}
+
+// diffFixtures returns two snapshots of a company between which an edge
+// rule was renamed, a VM was added, another removed and a third
+// reconfigured.
+func diffFixtures() (from, to *InventorySnapshot) {
+	web := func(diskSize int, ip string, metadata ...Metadata) SnapshotVirtualMachine {
+		return SnapshotVirtualMachine{
+			VirtualMachine: VirtualMachine{ID: "vm-web", Name: "web"},
+			Disks:          []Disk{{Name: "Hard disk 1", SizeMB: diskSize}},
+			Nics:           []Nic{{ID: 0, IPAddress: ip}},
+			Metadata:       metadata,
+		}
+	}
+	edge := func(rule string) SnapshotEdge {
+		return SnapshotEdge{
+			Edge:     Edge{ID: "edge-1", Name: "edge"},
+			Firewall: EdgeFirewall{Rules: []EdgeFirewallRule{{ID: 5, Name: rule}}},
+		}
+	}
+	from = &InventorySnapshot{
+		Version: SnapshotVersion,
+		Orgs:    []Org{{ID: "org-1", Name: "dal"}},
+		Edges:   []SnapshotEdge{edge("allow ssh")},
+		VMs: []SnapshotVirtualMachine{
+			web(1024, "10.0.0.1", Metadata{Key: "owner", Value: "ops"}),
+			{VirtualMachine: VirtualMachine{ID: "vm-old", Name: "old"}},
+		},
+	}
+	from.VMs[0].Disks = append(from.VMs[0].Disks, Disk{Name: "Hard disk 2", SizeMB: 512})
+	to = &InventorySnapshot{
+		Version: SnapshotVersion,
+		Orgs:    []Org{{ID: "org-1", Name: "dal"}},
+		Edges:   []SnapshotEdge{edge("allow https")},
+		VMs: []SnapshotVirtualMachine{
+			{VirtualMachine: VirtualMachine{ID: "vm-new", Name: "new"}},
+			web(2048, "10.0.0.2", Metadata{Key: "owner", Value: "dev"}, Metadata{Key: "env", Value: "prod"}),
+		},
+	}
+	return from, to
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	from, to := diffFixtures()
+	edgeChange := EntityChange{Kind: ChangeModified, Type: EntityIaasEdge, ID: "edge-1", Name: "edge", Fields: []FieldChange{
+		{Path: "firewall.firewall_rules[5].name", Old: "allow ssh", New: "allow https"},
+	}}
+	added := EntityChange{Kind: ChangeAdded, Type: EntityIaasVm, ID: "vm-new", Name: "new"}
+	removed := EntityChange{Kind: ChangeRemoved, Type: EntityIaasVm, ID: "vm-old", Name: "old"}
+	diskChanges := []FieldChange{
+		{Path: "disks[Hard disk 1].size", Old: float64(1024), New: float64(2048)},
+		{Path: "disks[Hard disk 2]", Old: map[string]interface{}{"name": "Hard disk 2", "size": float64(512)}},
+	}
+	metadataChanges := []FieldChange{
+		{Path: "metadata[owner].value", Old: "ops", New: "dev"},
+		{Path: "metadata[env]", New: map[string]interface{}{"key": "env", "value": "prod", "type": "", "access": ""}},
+	}
+	nicChange := FieldChange{Path: "nics[0].ip_address", Old: "10.0.0.1", New: "10.0.0.2"}
+
+	tests := []struct {
+		name     string
+		from, to *InventorySnapshot
+		opts     DiffOptions
+		want     []EntityChange
+	}{
+		{name: "unchanged", from: from, to: from, want: []EntityChange{}},
+		{
+			name: "added, removed and modified", from: from, to: to,
+			want: []EntityChange{
+				edgeChange, added, removed,
+				{Kind: ChangeModified, Type: EntityIaasVm, ID: "vm-web", Name: "web", Fields: slices.Concat(diskChanges, metadataChanges, []FieldChange{nicChange})},
+			},
+		},
+		{
+			name: "ignored fields", from: from, to: to, opts: DiffOptions{IgnoreFields: []string{"ip_address", "firewall.firewall_rules"}},
+			want: []EntityChange{
+				added, removed,
+				{Kind: ChangeModified, Type: EntityIaasVm, ID: "vm-web", Name: "web", Fields: slices.Concat(diskChanges, metadataChanges)},
+			},
+		},
+		{
+			name: "ignored entity", from: from, to: to, opts: DiffOptions{IgnoreFields: []string{"disks", "metadata", "nics"}},
+			want: []EntityChange{edgeChange, added, removed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffSnapshots(tt.from, tt.to, tt.opts)
+			if !reflect.DeepEqual(diff.Changes, tt.want) {
+				t.Errorf("got %+v\nwant %+v", diff.Changes, tt.want)
+			}
+			if diff.Empty() != (len(tt.want) == 0) {
+				t.Errorf("Empty() = %t with %d changes", diff.Empty(), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSnapshotDiffWriteText(t *testing.T) {
+	from, to := diffFixtures()
+	text := &strings.Builder{}
+	if err := DiffSnapshots(from, to, DiffOptions{}).WriteText(text); err != nil {
+		t.Fatal(err)
+	}
+	want := `~ IAAS_EDGE edge (edge-1)
+    firewall.firewall_rules[5].name: "allow ssh" -> "allow https"
++ IAAS_VM new (vm-new)
+- IAAS_VM old (vm-old)
+~ IAAS_VM web (vm-web)
+    disks[Hard disk 1].size: 1024 -> 2048
+    disks[Hard disk 2]: removed {"name":"Hard disk 2","size":512}
+    metadata[owner].value: "ops" -> "dev"
+    metadata[env]: added {"access":"","key":"env","type":"","value":"prod"}
+    nics[0].ip_address: "10.0.0.1" -> "10.0.0.2"
+`
+	if text.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestSnapshotDiffWriteJSON(t *testing.T) {
+	from, to := diffFixtures()
+	from.CompanyID, to.CompanyID = "company-1", "company-1"
+	from.TakenAt = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	to.TakenAt = from.TakenAt.Add(24 * time.Hour)
+	diff := DiffSnapshots(from, to, DiffOptions{})
+	buf := &bytes.Buffer{}
+	if err := diff.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	raw := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"company_id", "from", "to", "changes"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("JSON has no %q field:\n%s", key, buf)
+		}
+	}
+	decoded := &SnapshotDiff{}
+	if err := json.Unmarshal(buf.Bytes(), decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, diff) {
+		t.Errorf("round trip got %+v, want %+v", decoded, diff)
+	}
+}
+
+func TestReadInventorySnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "current version", input: fmt.Sprintf(`{"version": %d, "company_id": "company-1"}`, SnapshotVersion)},
+		{name: "missing version", input: `{"company_id": "company-1"}`, wantErr: "unsupported inventory snapshot version 0"},
+		{name: "newer version", input: fmt.Sprintf(`{"version": %d}`, SnapshotVersion+1), wantErr: fmt.Sprintf("unsupported inventory snapshot version %d", SnapshotVersion+1)},
+		{name: "malformed", input: `{"version":`, wantErr: "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := ReadInventorySnapshot(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if snapshot.CompanyID != "company-1" {
+				t.Errorf("CompanyID = %q", snapshot.CompanyID)
+			}
+		})
+	}
+}
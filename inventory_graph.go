@@ -0,0 +1,230 @@
+package iland
+
+import (
+	"context"
+	"iter"
+)
+
+// InventoryNode is an entity of an InventoryGraph linked to its parent and
+// children.
+type InventoryNode struct {
+	InventoryItem
+	parent   *InventoryNode
+	children []*InventoryNode
+}
+
+// Parent returns the node's parent, or nil for a root.
+func (n *InventoryNode) Parent() *InventoryNode {
+	return n.parent
+}
+
+// Children returns the node's children in inventory order.
+func (n *InventoryNode) Children() []*InventoryNode {
+	return n.children
+}
+
+// ChildrenOfType returns the children of entityType.
+func (n *InventoryNode) ChildrenOfType(entityType string) []*InventoryNode {
+	nodes := []*InventoryNode{}
+	for _, child := range n.children {
+		if child.Type == entityType {
+			nodes = append(nodes, child)
+		}
+	}
+	return nodes
+}
+
+// Ancestors returns the node's ancestors, nearest first.
+func (n *InventoryNode) Ancestors() []*InventoryNode {
+	nodes := []*InventoryNode{}
+	for p := n.parent; p != nil; p = p.parent {
+		nodes = append(nodes, p)
+	}
+	return nodes
+}
+
+// Ancestor returns the nearest ancestor of entityType, such as the
+// EntityIaasVdc or EntityIaasLocation of a VM, or nil if there is none.
+func (n *InventoryNode) Ancestor(entityType string) *InventoryNode {
+	for p := n.parent; p != nil; p = p.parent {
+		if p.Type == entityType {
+			return p
+		}
+	}
+	return nil
+}
+
+// Descendants returns an iterator over the node's descendants, depth first
+// in inventory order, excluding the node itself.
+func (n *InventoryNode) Descendants() iter.Seq[*InventoryNode] {
+	return func(yield func(*InventoryNode) bool) {
+		for _, child := range n.children {
+			if !child.walk(0, func(node *InventoryNode, _ int) bool { return yield(node) }, true) {
+				return
+			}
+		}
+	}
+}
+
+// Walk calls fn for the node and each of its descendants, depth first,
+// with the depth below the node. If fn returns false the node's children
+// are skipped.
+func (n *InventoryNode) Walk(fn func(node *InventoryNode, depth int) bool) {
+	n.walk(0, fn, false)
+}
+
+// walk visits n and its descendants. With stop set, fn returning false ends
+// the walk and walk returns false; otherwise it only skips n's children.
+func (n *InventoryNode) walk(depth int, fn func(*InventoryNode, int) bool, stop bool) bool {
+	if !fn(n, depth) {
+		return !stop
+	}
+	for _, child := range n.children {
+		if !child.walk(depth+1, fn, stop) {
+			return false
+		}
+	}
+	return true
+}
+
+// InventoryGraph links the flat items of a CompanyInventory into a tree
+// from the company down to VMs, vApp networks and O365 jobs.
+type InventoryGraph struct {
+	CompanyID   string
+	CompanyName string
+	nodes       map[nodeKey]*InventoryNode
+	order       []*InventoryNode
+	roots       []*InventoryNode
+}
+
+// nodeKey identifies a node. UUIDs are only unique within an entity type:
+// a location, for example, can share its ID with other entities.
+type nodeKey struct {
+	entityType string
+	uuid       string
+}
+
+// NewInventoryGraph builds the graph of inventory. Items are identified by
+// type and UUID, and linked to the item matching their parent type and
+// UUID. Items whose parent is not part of the inventory become roots.
+func NewInventoryGraph(inventory CompanyInventory) *InventoryGraph {
+	g := &InventoryGraph{
+		CompanyID:   inventory.CompanyID,
+		CompanyName: inventory.CompanyName,
+		nodes:       map[nodeKey]*InventoryNode{},
+	}
+	for _, item := range inventory.Entities.items() {
+		key := nodeKey{item.Type, item.UUID}
+		if _, ok := g.nodes[key]; ok {
+			continue
+		}
+		node := &InventoryNode{InventoryItem: item}
+		g.nodes[key] = node
+		g.order = append(g.order, node)
+	}
+	for _, node := range g.order {
+		parent, ok := g.nodes[nodeKey{node.ParentType, node.ParentUUID}]
+		if !ok || parent == node || parent.descendsFrom(node) {
+			g.roots = append(g.roots, node)
+			continue
+		}
+		node.parent = parent
+		parent.children = append(parent.children, node)
+	}
+	return g
+}
+
+// descendsFrom reports whether n is node or below it, which would make
+// linking node under n a cycle.
+func (n *InventoryNode) descendsFrom(node *InventoryNode) bool {
+	for p := n; p != nil; p = p.parent {
+		if p == node {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of nodes.
+func (g *InventoryGraph) Len() int {
+	return len(g.order)
+}
+
+// Node returns the node of entityType with uuid.
+func (g *InventoryGraph) Node(entityType, uuid string) (*InventoryNode, bool) {
+	node, ok := g.nodes[nodeKey{entityType, uuid}]
+	return node, ok
+}
+
+// Roots returns the nodes without a parent, normally just the company.
+func (g *InventoryGraph) Roots() []*InventoryNode {
+	return g.roots
+}
+
+// OfType returns every node of entityType in inventory order.
+func (g *InventoryGraph) OfType(entityType string) []*InventoryNode {
+	return g.Filter(func(node *InventoryNode) bool { return node.Type == entityType })
+}
+
+// Filter returns every node for which match returns true, in inventory
+// order.
+func (g *InventoryGraph) Filter(match func(*InventoryNode) bool) []*InventoryNode {
+	nodes := []*InventoryNode{}
+	for _, node := range g.order {
+		if match(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// All returns an iterator over every node, depth first from the roots.
+func (g *InventoryGraph) All() iter.Seq[*InventoryNode] {
+	return func(yield func(*InventoryNode) bool) {
+		for _, root := range g.roots {
+			if !root.walk(0, func(node *InventoryNode, _ int) bool { return yield(node) }, true) {
+				return
+			}
+		}
+	}
+}
+
+// Walk calls InventoryNode.Walk on each root.
+func (g *InventoryGraph) Walk(fn func(node *InventoryNode, depth int) bool) {
+	for _, root := range g.roots {
+		root.Walk(fn)
+	}
+}
+
+// items returns every item, parents before children where the API's
+// hierarchy allows.
+func (i Inventory) items() []InventoryItem {
+	groups := [][]InventoryItem{
+		i.Company,
+		i.IaasLocations, i.IaasProducts, i.IaasOrganizations, i.IaasVdcs, i.IaasCatalogs, i.IaasMedia,
+		i.IaasNetworks, i.IaasEdges, i.IaasVApps, i.IaasVAppNetworks, i.IaasVms, i.IaasVpgs, i.VCCFailoverPlans,
+		i.VCCBackupLocations, i.VCCBackupProducts, i.VCCBackupTenants, i.VACBackupJob,
+		i.ObjectStorageLocations, i.ObjectStorageProducts,
+		i.O365Locations, i.O365Product, i.O365Organization, i.O365Jobs,
+	}
+	items := []InventoryItem{}
+	for _, group := range groups {
+		items = append(items, group...)
+	}
+	return items
+}
+
+func (s *companyService) GetInventoryGraph(companyID string) (*InventoryGraph, error) {
+	return s.GetInventoryGraphContext(context.Background(), companyID)
+}
+
+// GetInventoryGraphContext fetches the company inventory and links it into
+// a graph.
+func (s *companyService) GetInventoryGraphContext(ctx context.Context, companyID string) (*InventoryGraph, error) {
+	ctx = withOperation(ctx, "Company.GetInventoryGraph", companyID)
+	inventory, err := s.GetInventoryContext(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return NewInventoryGraph(inventory), nil
+}
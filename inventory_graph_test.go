@@ -0,0 +1,45 @@
+package iland
+
+import "testing"
+
+func TestInventoryGraphKeysNodesByTypeAndID(t *testing.T) {
+	inventory := CompanyInventory{
+		CompanyID: "company",
+		Entities: Inventory{
+			Company:           []InventoryItem{{UUID: "company", Type: EntityCompany}},
+			IaasLocations:     []InventoryItem{{UUID: "dup", Type: EntityIaasLocation, ParentUUID: "company", ParentType: EntityCompany}},
+			IaasOrganizations: []InventoryItem{{UUID: "dup", Type: EntityIaasOrganization, ParentUUID: "dup", ParentType: EntityIaasLocation}},
+			IaasVdcs:          []InventoryItem{{UUID: "vdc", Type: EntityIaasVdc, ParentUUID: "dup", ParentType: EntityIaasOrganization}},
+		},
+	}
+	g := NewInventoryGraph(inventory)
+	if g.Len() != 4 {
+		t.Fatalf("Len = %d, want 4", g.Len())
+	}
+	tests := []struct {
+		entityType string
+		uuid       string
+		wantParent string
+	}{
+		{EntityIaasLocation, "dup", EntityCompany},
+		{EntityIaasOrganization, "dup", EntityIaasLocation},
+		{EntityIaasVdc, "vdc", EntityIaasOrganization},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entityType, func(t *testing.T) {
+			node, ok := g.Node(tt.entityType, tt.uuid)
+			if !ok {
+				t.Fatal("node not found")
+			}
+			if node.Type != tt.entityType {
+				t.Errorf("Type = %q", node.Type)
+			}
+			if node.Parent() == nil || node.Parent().Type != tt.wantParent {
+				t.Errorf("parent = %v, want a %s", node.Parent(), tt.wantParent)
+			}
+		})
+	}
+	if _, ok := g.Node(EntityIaasVdc, "dup"); ok {
+		t.Error("found a vdc that does not exist")
+	}
+}
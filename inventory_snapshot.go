@@ -0,0 +1,220 @@
+package iland
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+// SnapshotVersion is the version of the InventorySnapshot document written
+// by this release. ReadInventorySnapshot rejects later versions.
+const SnapshotVersion = 1
+
+// InventorySnapshot is the configuration of a company's IaaS estate at one
+// point in time. Each list is sorted by ID and each entity's metadata by
+// key, so snapshots of an unchanged estate encode identically apart from
+// TakenAt.
+type InventorySnapshot struct {
+	Version     int                      `json:"version"`
+	TakenAt     time.Time                `json:"taken_at"`
+	CompanyID   string                   `json:"company_id"`
+	CompanyName string                   `json:"company_name"`
+	Orgs        []Org                    `json:"orgs"`
+	Vdcs        []Vdc                    `json:"vdcs"`
+	VApps       []SnapshotVApp           `json:"vapps"`
+	VMs         []SnapshotVirtualMachine `json:"vms"`
+	Edges       []SnapshotEdge           `json:"edges"`
+	Networks    []OrgVdcNetwork          `json:"networks"`
+}
+
+// SnapshotVApp is a vApp and its metadata.
+type SnapshotVApp struct {
+	VApp
+	Metadata []Metadata `json:"metadata"`
+}
+
+// SnapshotVirtualMachine is a VM with its disks, network cards and
+// metadata.
+type SnapshotVirtualMachine struct {
+	VirtualMachine
+	Disks    []Disk     `json:"disks"`
+	Nics     []Nic      `json:"nics"`
+	Metadata []Metadata `json:"metadata"`
+}
+
+// SnapshotEdge is an edge with its firewall and NAT configuration.
+type SnapshotEdge struct {
+	Edge
+	Firewall EdgeFirewall `json:"firewall"`
+	NAT      EdgeNAT      `json:"nat"`
+}
+
+// WriteJSON writes the snapshot as indented JSON.
+func (s *InventorySnapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// ReadInventorySnapshot reads a snapshot written by WriteJSON.
+func ReadInventorySnapshot(r io.Reader) (*InventorySnapshot, error) {
+	snapshot := &InventorySnapshot{}
+	err := json.NewDecoder(r).Decode(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("iland: unsupported inventory snapshot version %d", snapshot.Version)
+	}
+	return snapshot, nil
+}
+
+func (s *companyService) GetInventorySnapshot(companyID string) (*InventorySnapshot, error) {
+	return s.GetInventorySnapshotContext(context.Background(), companyID)
+}
+
+// GetInventorySnapshotContext fetches the configuration of every org of the
+// company concurrently. If some requests fail, the rest of the snapshot is
+// returned with a *PartialError. Such a snapshot is incomplete: the
+// entities of failed orgs are missing from it and those whose disks, NICs,
+// metadata or firewall failed lack them. Diffing it reports those gaps as
+// spurious drift, so check the error before saving or comparing it.
+func (s *companyService) GetInventorySnapshotContext(ctx context.Context, companyID string) (*InventorySnapshot, error) {
+	ctx = withOperation(ctx, "Company.GetInventorySnapshot", companyID)
+	const op = "Company.GetInventorySnapshot"
+	company, err := s.GetContext(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &InventorySnapshot{
+		Version:     SnapshotVersion,
+		TakenAt:     time.Now().UTC(),
+		CompanyID:   company.ID,
+		CompanyName: company.Name,
+	}
+	orgs, orgsErr := s.GetOrgsContext(ctx, companyID)
+	var partial *PartialError
+	if orgsErr != nil && !errors.As(orgsErr, &partial) {
+		return nil, orgsErr
+	}
+	snapshot.Orgs = orgs
+	orgIDs := make([]string, len(orgs))
+	for i, org := range orgs {
+		orgIDs[i] = org.ID
+	}
+	concurrency := s.client.fanOutConcurrency
+	contents, orgErr := fanOut(ctx, op, orgIDs, concurrency, func(ctx context.Context, orgID string) ([]*InventorySnapshot, error) {
+		return s.snapshotOrg(ctx, orgID)
+	})
+	for _, c := range contents {
+		snapshot.Vdcs = append(snapshot.Vdcs, c.Vdcs...)
+		snapshot.VApps = append(snapshot.VApps, c.VApps...)
+		snapshot.VMs = append(snapshot.VMs, c.VMs...)
+		snapshot.Edges = append(snapshot.Edges, c.Edges...)
+		snapshot.Networks = append(snapshot.Networks, c.Networks...)
+	}
+
+	slices.SortFunc(snapshot.Orgs, func(a, b Org) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Vdcs, func(a, b Vdc) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.VApps, func(a, b SnapshotVApp) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.VMs, func(a, b SnapshotVirtualMachine) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Edges, func(a, b SnapshotEdge) int { return cmp.Compare(a.ID, b.ID) })
+	slices.SortFunc(snapshot.Networks, func(a, b OrgVdcNetwork) int { return cmp.Compare(a.ID, b.ID) })
+
+	// Each fetch fills in its own entity, so they can share the slices.
+	vapps := map[string]*SnapshotVApp{}
+	for i := range snapshot.VApps {
+		vapps[snapshot.VApps[i].ID] = &snapshot.VApps[i]
+	}
+	_, vappErr := fanOut(ctx, op, mapKeys(vapps), concurrency, func(ctx context.Context, id string) ([]struct{}, error) {
+		metadata, err := s.client.VApp().GetMetadataContext(ctx, id)
+		vapps[id].Metadata = sortMetadata(metadata)
+		return nil, err
+	})
+	vms := map[string]*SnapshotVirtualMachine{}
+	for i := range snapshot.VMs {
+		vms[snapshot.VMs[i].ID] = &snapshot.VMs[i]
+	}
+	_, vmErr := fanOut(ctx, op, mapKeys(vms), concurrency, func(ctx context.Context, id string) ([]struct{}, error) {
+		vm := vms[id]
+		var err error
+		if vm.Disks, err = s.client.VirtualMachine().GetDisksContext(ctx, id); err != nil {
+			return nil, err
+		}
+		if vm.Nics, err = s.client.VirtualMachine().GetNicsContext(ctx, id); err != nil {
+			return nil, err
+		}
+		metadata, err := s.client.VirtualMachine().GetMetadataContext(ctx, id)
+		vm.Metadata = sortMetadata(metadata)
+		return nil, err
+	})
+	edges := map[string]*SnapshotEdge{}
+	for i := range snapshot.Edges {
+		edges[snapshot.Edges[i].ID] = &snapshot.Edges[i]
+	}
+	_, edgeErr := fanOut(ctx, op, mapKeys(edges), concurrency, func(ctx context.Context, id string) ([]struct{}, error) {
+		edge := edges[id]
+		var err error
+		if edge.Firewall, err = s.client.Edge().GetFirewallContext(ctx, id); err != nil {
+			return nil, err
+		}
+		edge.NAT, err = s.client.Edge().GetNATContext(ctx, id)
+		return nil, err
+	})
+	return snapshot, joinPartial(op, orgsErr, orgErr, vappErr, vmErr, edgeErr)
+}
+
+// snapshotOrg fetches the entities of one org, without their details.
+func (s *companyService) snapshotOrg(ctx context.Context, orgID string) ([]*InventorySnapshot, error) {
+	org := s.client.Org()
+	c := &InventorySnapshot{}
+	var err error
+	if c.Vdcs, err = org.GetVdcsContext(ctx, orgID); err != nil {
+		return nil, err
+	}
+	vapps, err := org.GetVAppsContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vapp := range vapps {
+		c.VApps = append(c.VApps, SnapshotVApp{VApp: vapp})
+	}
+	vms, err := org.GetVirtualMachinesContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, vm := range vms {
+		c.VMs = append(c.VMs, SnapshotVirtualMachine{VirtualMachine: vm})
+	}
+	edges, err := org.GetEdgesContext(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range edges {
+		c.Edges = append(c.Edges, SnapshotEdge{Edge: edge})
+	}
+	if c.Networks, err = org.GetNetworksContext(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return []*InventorySnapshot{c}, nil
+}
+
+func sortMetadata(metadata []Metadata) []Metadata {
+	slices.SortFunc(metadata, func(a, b Metadata) int { return cmp.Compare(a.Key, b.Key) })
+	return metadata
+}
+
+// mapKeys returns the keys of m in sorted order.
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,164 @@
+package iland
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const defaultPageSize = 100
+
+// maxPages bounds paginate in case an endpoint keeps returning full pages.
+const maxPages = 1000
+
+// ListOptions controls the List methods.
+type ListOptions struct {
+	// PageSize is the number of items requested per page from the endpoints
+	// the API pages: O365Service.ListUsers, EventService.Search and
+	// TaskService.Search. It defaults to 100. The other endpoints return
+	// every item in one response.
+	PageSize int
+	// Sort is passed to the API as the sort parameter, e.g. "name" or
+	// "-updated_date".
+	Sort string
+	// Filters are passed to the API as additional query parameters.
+	Filters map[string]string
+}
+
+// Collect drains seq into a slice, stopping at the first error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// listAll returns an iterator over a list endpoint that the API answers in
+// a single response.
+func listAll[T any](ctx context.Context, c *client, endpoint string, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		raw, err := getPage(ctx, c, listURL(endpoint, url.Values{}, opts))
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		items := []T{}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+// paginate returns an iterator over every item of a paged list endpoint,
+// fetching pages lazily until a short or empty page is returned. endpoint
+// returns the URL of a page, starting from page 0. It also stops if a page
+// repeats the previous one, as it does when the endpoint ignores the page
+// parameter, and fails after maxPages pages.
+func paginate[T any](ctx context.Context, c *client, endpoint func(page, pageSize int) string, opts ListOptions) iter.Seq2[T, error] {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		var previous json.RawMessage
+		for page := 0; page < maxPages; page++ {
+			raw, err := getPage(ctx, c, listURL(endpoint(page, pageSize), url.Values{}, opts))
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if page > 0 && bytes.Equal(raw, previous) {
+				return
+			}
+			previous = raw
+			items := []T{}
+			if err := json.Unmarshal(raw, &items); err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) < pageSize {
+				return
+			}
+		}
+		path, _, _ := strings.Cut(endpoint(0, pageSize), "?")
+		yield(zero, fmt.Errorf("iland: %s returned more than %d pages", path, maxPages))
+	}
+}
+
+// pageQuery returns the page URLs of an endpoint that takes the page and
+// pageSize parameters.
+func pageQuery(endpoint string) func(page, pageSize int) string {
+	return func(page, pageSize int) string {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("pageSize", strconv.Itoa(pageSize))
+		return listURL(endpoint, query, ListOptions{})
+	}
+}
+
+// listURL adds params and the sort and filters of opts to endpoint's query.
+func listURL(endpoint string, params url.Values, opts ListOptions) string {
+	path, rawQuery, _ := strings.Cut(endpoint, "?")
+	query, _ := url.ParseQuery(rawQuery)
+	for key, values := range params {
+		query[key] = values
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	for key, value := range opts.Filters {
+		query.Set(key, value)
+	}
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// getPage returns the items of a response that is either wrapped in a data
+// envelope or a bare JSON array, still encoded.
+func getPage(ctx context.Context, c *client, endpoint string) (json.RawMessage, error) {
+	raw := json.RawMessage{}
+	err := c.getObject(ctx, endpoint, &raw)
+	if err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+	if bytes.HasPrefix(raw, []byte("[")) {
+		return raw, nil
+	}
+	schema := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	err = json.Unmarshal(raw, &schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(schema.Data) == 0 || bytes.Equal(schema.Data, []byte("null")) {
+		return json.RawMessage("[]"), nil
+	}
+	return schema.Data, nil
+}
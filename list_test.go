@@ -0,0 +1,131 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := func(n int) []int {
+		list := make([]int, n)
+		for i := range list {
+			list[i] = i
+		}
+		return list
+	}
+	tests := []struct {
+		name      string
+		total     int
+		pageSize  int
+		ignore    bool
+		want      int
+		wantPages int
+		wantErr   string
+	}{
+		{name: "empty", total: 0, pageSize: 10, want: 0, wantPages: 1},
+		{name: "short last page", total: 25, pageSize: 10, want: 25, wantPages: 3},
+		{name: "exact multiple", total: 20, pageSize: 10, want: 20, wantPages: 3},
+		{name: "endpoint ignores paging", total: 10, pageSize: 10, ignore: true, want: 10, wantPages: 2},
+		{name: "endless full pages", total: -1, pageSize: 1, wantPages: maxPages, wantErr: "more than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages atomic.Int32
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages.Add(1)
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+				var data []int
+				switch {
+				case tt.total < 0:
+					data = []int{page}
+				case tt.ignore:
+					data = items(tt.total)
+				default:
+					data = items(tt.total)[min(page*pageSize, tt.total):min((page+1)*pageSize, tt.total)]
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "page": page})
+			}), WithRetryPolicy(NoRetryPolicy))
+			got, err := Collect(paginate[int](context.Background(), c, pageQuery("/v1/items"), ListOptions{PageSize: tt.pageSize}))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if len(got) != tt.want {
+				t.Errorf("got %d items, want %d", len(got), tt.want)
+			}
+			if int(pages.Load()) != tt.wantPages {
+				t.Errorf("fetched %d pages, want %d", pages.Load(), tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestListAllSendsNoPageParameters(t *testing.T) {
+	var query string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`[1,2,3]`))
+	}))
+	got, err := Collect(listAll[int](context.Background(), c, "/v1/items?expand=all", ListOptions{PageSize: 1, Sort: "name"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Errorf("got %d items, want 3", len(got))
+	}
+	if query != "expand=all&sort=name" {
+		t.Errorf("query = %q", query)
+	}
+}
+
+func TestEventSearchPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    int
+		pageSize  int
+		wantPages int
+	}{
+		{name: "one page", events: 3, pageSize: 10, wantPages: 1},
+		{name: "several pages", events: 25, pageSize: 10, wantPages: 3},
+		{name: "default page size", events: 150, wantPages: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+				data := []Event{}
+				for i := page * pageSize; i < min((page+1)*pageSize, tt.events); i++ {
+					data = append(data, Event{ID: strconv.Itoa(i), Timestamp: i})
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+			}), WithRetryPolicy(NoRetryPolicy))
+			query := EventQuery{}.Company("company")
+			got, err := Collect(c.Event().Search(context.Background(), query, ListOptions{PageSize: tt.pageSize}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.events {
+				t.Errorf("got %d events, want %d", len(got), tt.events)
+			}
+			for i, event := range got {
+				if event.Timestamp != i {
+					t.Fatalf("event %d has timestamp %d", i, event.Timestamp)
+				}
+			}
+			if int(requests.Load()) != tt.wantPages {
+				t.Errorf("made %d requests, want %d", requests.Load(), tt.wantPages)
+			}
+		})
+	}
+}
@@ -1,44 +1,233 @@
 package iland
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
 
+// Location is an iland data centre and the services offered there.
 type Location struct {
-	ID string `json:"location_id"`
+	ID               string `json:"location_id"`
+	Name             string `json:"name"`
+	Region           string `json:"region"`
+	Country          string `json:"country"`
+	Timezone         string `json:"timezone"`
+	HasIAAS          bool   `json:"has_iaas"`
+	HasVCC           bool   `json:"has_vcc"`
+	HasObjectStorage bool   `json:"has_object_storage"`
+	HasO365          bool   `json:"has_o365"`
+}
+
+// DefaultLocations is the catalogue used when the locations cannot be
+// fetched from the API. It may lag behind the sites iland has opened since
+// this release.
+var DefaultLocations = []Location{
+	{ID: "res01.ilandcloud.com", Name: "Reston", Region: "North America", Country: "US", Timezone: "America/New_York", HasIAAS: true},
+	{ID: "lax01.ilandcloud.com", Name: "Los Angeles", Region: "North America", Country: "US", Timezone: "America/Los_Angeles", HasIAAS: true},
+	{ID: "man01.ilandcloud.com", Name: "Manchester", Region: "Europe", Country: "GB", Timezone: "Europe/London", HasIAAS: true},
+	{ID: "man03.ilandcloud.com", Name: "Manchester", Region: "Europe", Country: "GB", Timezone: "Europe/London", HasIAAS: true},
+	{ID: "lon02.ilandcloud.com", Name: "London", Region: "Europe", Country: "GB", Timezone: "Europe/London", HasIAAS: true},
+	{ID: "lon03.ilandcloud.com", Name: "London", Region: "Europe", Country: "GB", Timezone: "Europe/London", HasIAAS: true},
+	{ID: "dal02.ilandcloud.com", Name: "Dallas", Region: "North America", Country: "US", Timezone: "America/Chicago", HasIAAS: true},
+	{ID: "dal06.ilandcloud.com", Name: "Dallas", Region: "North America", Country: "US", Timezone: "America/Chicago", HasIAAS: true},
+	{ID: "dal22.ilandcloud.com", Name: "Dallas", Region: "North America", Country: "US", Timezone: "America/Chicago", HasIAAS: true},
+	{ID: "dal23.ilandcloud.com", Name: "Dallas", Region: "North America", Country: "US", Timezone: "America/Chicago", HasIAAS: true},
+	{ID: "dal25.ilandcloud.com", Name: "Dallas", Region: "North America", Country: "US", Timezone: "America/Chicago", HasIAAS: true},
+	{ID: "sin01.ilandcloud.com", Name: "Singapore", Region: "Asia Pacific", Country: "SG", Timezone: "Asia/Singapore", HasIAAS: true},
+	{ID: "ams01.ilandcloud.com", Name: "Amsterdam", Region: "Europe", Country: "NL", Timezone: "Europe/Amsterdam", HasIAAS: true},
+	{ID: "ams02.ilandcloud.com", Name: "Amsterdam", Region: "Europe", Country: "NL", Timezone: "Europe/Amsterdam", HasIAAS: true},
+	{ID: "ams03.ilandcloud.com", Name: "Amsterdam", Region: "Europe", Country: "NL", Timezone: "Europe/Amsterdam", HasIAAS: true},
+	{ID: "ams04.ilandcloud.com", Name: "Amsterdam", Region: "Europe", Country: "NL", Timezone: "Europe/Amsterdam", HasIAAS: true},
+	{ID: "syd02.ilandcloud.com", Name: "Sydney", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Sydney", HasIAAS: true},
+	{ID: "syd03.ilandcloud.com", Name: "Sydney", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Sydney", HasIAAS: true},
+	{ID: "syd04.ilandcloud.com", Name: "Sydney", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Sydney", HasIAAS: true},
+	{ID: "mel02.ilandcloud.com", Name: "Melbourne", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Melbourne", HasIAAS: true},
+	{ID: "mel03.ilandcloud.com", Name: "Melbourne", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Melbourne", HasIAAS: true},
+	{ID: "mel04.ilandcloud.com", Name: "Melbourne", Region: "Asia Pacific", Country: "AU", Timezone: "Australia/Melbourne", HasIAAS: true},
+	{ID: "str02.ilandcloud.com", Name: "Stockholm", Region: "Europe", Country: "SE", Timezone: "Europe/Stockholm", HasIAAS: true},
+	{ID: "str03.ilandcloud.com", Name: "Stockholm", Region: "Europe", Country: "SE", Timezone: "Europe/Stockholm", HasIAAS: true},
+	{ID: "str05.ilandcloud.com", Name: "Stockholm", Region: "Europe", Country: "SE", Timezone: "Europe/Stockholm", HasIAAS: true},
+}
+
+// LocationIDs lists the IDs of DefaultLocations.
+//
+// Deprecated: use ConsoleService.GetLocationsContext, which also finds
+// locations opened since this release.
+var LocationIDs = locationIDs(DefaultLocations)
+
+func locationIDs(locations []Location) []string {
+	ids := make([]string, len(locations))
+	for i, location := range locations {
+		ids[i] = location.ID
+	}
+	return ids
+}
+
+const (
+	// locationsTTL is how long the locations fetched from the API are
+	// reused.
+	locationsTTL = time.Hour
+	// locationsFallbackTTL is how long DefaultLocations is used after the
+	// locations could not be fetched, before trying again.
+	locationsFallbackTTL = time.Minute
+)
+
+// GetLocationsContext fetches the location catalogue, reusing it for an
+// hour. Concurrent callers share a single request. If it cannot be
+// fetched, DefaultLocations is returned along with the error, so callers
+// that can make do with the static catalogue may ignore it; both are
+// reused for a minute before the API is tried again.
+func (c *client) GetLocationsContext(ctx context.Context) ([]Location, error) {
+	ctx = withOperation(ctx, "Console.GetLocations", "")
+	for {
+		c.locationsMu.Lock()
+		if c.locations != nil && time.Now().Before(c.locationsExpiry) {
+			locations, err := append([]Location{}, c.locations...), c.locationsErr
+			c.locationsMu.Unlock()
+			return locations, err
+		}
+		fetch := c.locationsFetch
+		if fetch == nil {
+			fetch = make(chan struct{})
+			c.locationsFetch = fetch
+			c.locationsMu.Unlock()
+			locations, err := c.fetchLocations(ctx)
+			c.locationsMu.Lock()
+			c.locationsFetch = nil
+			switch {
+			case err == nil:
+				c.locations, c.locationsErr = locations, nil
+				c.locationsExpiry = time.Now().Add(locationsTTL)
+			case ctx.Err() == nil:
+				c.locations, c.locationsErr = DefaultLocations, err
+				c.locationsExpiry = time.Now().Add(locationsFallbackTTL)
+			}
+			c.locationsMu.Unlock()
+			close(fetch)
+			if err != nil {
+				return append([]Location{}, DefaultLocations...), err
+			}
+			return append([]Location{}, locations...), nil
+		}
+		c.locationsMu.Unlock()
+		select {
+		case <-ctx.Done():
+			return append([]Location{}, DefaultLocations...), ctx.Err()
+		case <-fetch:
+		}
+		// The result is cached now, unless the fetch was abandoned by its
+		// caller's context, in which case it is retried with ours.
+	}
+}
+
+func (c *client) fetchLocations(ctx context.Context) ([]Location, error) {
+	schema := struct {
+		Locations []Location `json:"data"`
+	}{}
+	err := c.getObject(ctx, "/v1/locations", &schema)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Locations == nil {
+		schema.Locations = []Location{}
+	}
+	return schema.Locations, nil
+}
+
+// iaasLocationIDs returns the IDs of the locations offering IaaS.
+func (c *client) iaasLocationIDs(ctx context.Context) []string {
+	locations, _ := c.GetLocationsContext(ctx)
+	ids := []string{}
+	for _, location := range locations {
+		if location.HasIAAS {
+			ids = append(ids, location.ID)
+		}
+	}
+	return ids
 }
 
 type locationService struct {
 	client *client
 }
 
+func (s *locationService) Get(locationID string) (Location, error) {
+	return s.GetContext(context.Background(), locationID)
+}
+
+// GetContext returns the location with locationID from the catalogue
+// returned by ConsoleService.GetLocationsContext.
+func (s *locationService) GetContext(ctx context.Context, locationID string) (Location, error) {
+	ctx = withOperation(ctx, "Location.Get", locationID)
+	locations, _ := s.client.GetLocationsContext(ctx)
+	for _, location := range locations {
+		if location.ID == locationID {
+			return location, nil
+		}
+	}
+	return Location{}, fmt.Errorf("iland: location %q: %w", locationID, ErrNotFound)
+}
+
 func (s *locationService) GetPublicCatalogs(locationID string) ([]Catalog, error) {
+	return s.GetPublicCatalogsContext(context.Background(), locationID)
+}
+
+func (s *locationService) GetPublicCatalogsContext(ctx context.Context, locationID string) ([]Catalog, error) {
+	ctx = withOperation(ctx, "Location.GetPublicCatalogs", locationID)
 	schema := struct {
 		Catalogs []Catalog `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/locations/%s/public-catalogs", locationID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/locations/%s/public-catalogs", locationID), &schema)
 	if err != nil {
 		return []Catalog{}, err
 	}
 	return schema.Catalogs, nil
 }
 
+func (s *locationService) ListPublicCatalogs(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[Catalog, error] {
+	ctx = withOperation(ctx, "Location.ListPublicCatalogs", locationID)
+	return listAll[Catalog](ctx, s.client, fmt.Sprintf("/v1/locations/%s/public-catalogs", locationID), opts)
+}
+
 func (s *locationService) GetPublicVAppTemplates(locationID string) ([]VAppTemplate, error) {
+	return s.GetPublicVAppTemplatesContext(context.Background(), locationID)
+}
+
+func (s *locationService) GetPublicVAppTemplatesContext(ctx context.Context, locationID string) ([]VAppTemplate, error) {
+	ctx = withOperation(ctx, "Location.GetPublicVAppTemplates", locationID)
 	schema := struct {
 		VAppTemplates []VAppTemplate `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/locations/%s/public-vapp-templates", locationID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/locations/%s/public-vapp-templates", locationID), &schema)
 	if err != nil {
 		return []VAppTemplate{}, err
 	}
 	return schema.VAppTemplates, nil
 }
 
+func (s *locationService) ListPublicVAppTemplates(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[VAppTemplate, error] {
+	ctx = withOperation(ctx, "Location.ListPublicVAppTemplates", locationID)
+	return listAll[VAppTemplate](ctx, s.client, fmt.Sprintf("/v1/locations/%s/public-vapp-templates", locationID), opts)
+}
+
 func (s *locationService) GetPublicMedia(locationID string) ([]Media, error) {
+	return s.GetPublicMediaContext(context.Background(), locationID)
+}
+
+func (s *locationService) GetPublicMediaContext(ctx context.Context, locationID string) ([]Media, error) {
+	ctx = withOperation(ctx, "Location.GetPublicMedia", locationID)
 	schema := struct {
 		Media []Media `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/locations/%s/public-media", locationID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/locations/%s/public-media", locationID), &schema)
 	if err != nil {
 		return []Media{}, err
 	}
 	return schema.Media, nil
 }
+
+func (s *locationService) ListPublicMedia(ctx context.Context, locationID string, opts ListOptions) iter.Seq2[Media, error] {
+	ctx = withOperation(ctx, "Location.ListPublicMedia", locationID)
+	return listAll[Media](ctx, s.client, fmt.Sprintf("/v1/locations/%s/public-media", locationID), opts)
+}
@@ -0,0 +1,108 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetLocations(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		callers   int
+		wantIDs   int
+		wantErr   bool
+		wantFetch int32
+	}{
+		{name: "fetched once", status: http.StatusOK, callers: 1, wantIDs: 1, wantFetch: 1},
+		{name: "concurrent callers share a fetch", status: http.StatusOK, callers: 10, wantIDs: 1, wantFetch: 1},
+		{name: "fallback is cached after an error", status: http.StatusNotFound, callers: 10, wantIDs: len(DefaultLocations), wantErr: true, wantFetch: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetches atomic.Int32
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fetches.Add(1)
+				time.Sleep(20 * time.Millisecond)
+				if tt.status != http.StatusOK {
+					w.WriteHeader(tt.status)
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"data": []Location{{ID: "new01.ilandcloud.com", HasIAAS: true}}})
+			}), WithRetryPolicy(NoRetryPolicy))
+			var wg sync.WaitGroup
+			for i := 0; i < tt.callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					locations, err := c.GetLocationsContext(context.Background())
+					if (err != nil) != tt.wantErr || len(locations) != tt.wantIDs {
+						t.Errorf("got %d locations, err %v", len(locations), err)
+					}
+				}()
+			}
+			wg.Wait()
+			// A later call is answered from the cache.
+			locations, err := c.GetLocationsContext(context.Background())
+			if (err != nil) != tt.wantErr || len(locations) != tt.wantIDs {
+				t.Errorf("cached: got %d locations, err %v", len(locations), err)
+			}
+			if got := fetches.Load(); got != tt.wantFetch {
+				t.Errorf("fetched %d times, want %d", got, tt.wantFetch)
+			}
+		})
+	}
+}
+
+func TestCompanyLocationFlagsComeFromInventory(t *testing.T) {
+	catalogue := []Location{{ID: "res01", Name: "Reston", Region: "North America", HasIAAS: true, HasVCC: true, HasObjectStorage: true, HasO365: true}}
+	inventory := CompanyInventory{CompanyID: "company", Entities: Inventory{
+		IaasLocations:      []InventoryItem{{UUID: "res01"}},
+		VCCBackupLocations: []InventoryItem{{UUID: "new01"}},
+	}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/locations", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": catalogue})
+	})
+	mux.HandleFunc("GET /v1/users/{user}/inventory", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"inventory": []CompanyInventory{inventory}})
+	})
+	c := newTestClient(t, mux, WithUsername("user"))
+	got, err := c.Company().GetLocationsContext(context.Background(), "company")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Location{
+		{ID: "res01", Name: "Reston", Region: "North America", HasIAAS: true},
+		{ID: "new01", HasVCC: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("location %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDefaultLocationsAreComplete(t *testing.T) {
+	seen := map[string]bool{}
+	for _, location := range DefaultLocations {
+		if seen[location.ID] {
+			t.Errorf("%s is listed twice", location.ID)
+		}
+		seen[location.ID] = true
+		if location.Name == "" || location.Region == "" || len(location.Country) != 2 {
+			t.Errorf("%s has name %q, region %q and country %q", location.ID, location.Name, location.Region, location.Country)
+		}
+		if _, err := time.LoadLocation(location.Timezone); err != nil || location.Timezone == "" {
+			t.Errorf("%s has time zone %q: %v", location.ID, location.Timezone, err)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package iland
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Call describes a single HTTP exchange made on behalf of an SDK operation.
+type Call struct {
+	// Operation is the logical SDK operation, e.g. "VirtualMachine.PowerOn".
+	// It is empty for raw Get, Post, Put and Delete calls.
+	Operation string
+	// EntityID is the ID of the entity the operation acts on, if any.
+	EntityID string
+	// Attempt is 1 for the first attempt and increases with each retry.
+	Attempt int
+	Request *http.Request
+}
+
+// Handler performs a call and returns its response.
+type Handler func(call *Call) (*http.Response, error)
+
+// Middleware wraps a Handler to observe or modify calls.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware around every HTTP exchange made by the
+// client. The first middleware is the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *client) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+type operationKey struct{}
+
+type operation struct {
+	name     string
+	entityID string
+}
+
+// withOperation records the logical operation for calls made with ctx. The
+// outermost operation wins, so helpers called by an operation are reported
+// as part of it.
+func withOperation(ctx context.Context, name, entityID string) context.Context {
+	if _, ok := ctx.Value(operationKey{}).(operation); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, operationKey{}, operation{name, entityID})
+}
+
+// OperationFromContext returns the SDK operation and entity ID recorded in
+// ctx, such as the context of Call.Request.
+func OperationFromContext(ctx context.Context) (name, entityID string) {
+	op, _ := ctx.Value(operationKey{}).(operation)
+	return op.name, op.entityID
+}
+
+func (c *client) buildHandler() {
+	handler := Handler(func(call *Call) (*http.Response, error) {
+		return c.http.Do(call.Request)
+	})
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	c.handler = handler
+}
+
+// LoggingOptions configures LoggingMiddleware.
+type LoggingOptions struct {
+	// Level is used for successful calls. Failed calls are logged at
+	// slog.LevelError.
+	Level slog.Level
+	// Headers logs request and response headers. Authorization and cookie
+	// headers are redacted.
+	Headers bool
+	// Bodies logs request and response bodies up to MaxBodySize bytes. JSON
+	// fields that look like credentials are redacted.
+	Bodies      bool
+	MaxBodySize int
+}
+
+const redacted = "[REDACTED]"
+
+// LoggingMiddleware logs every call to logger.
+func LoggingMiddleware(logger *slog.Logger, opts LoggingOptions) Middleware {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = 4096
+	}
+	return func(next Handler) Handler {
+		return func(call *Call) (*http.Response, error) {
+			ctx := call.Request.Context()
+			attrs := []slog.Attr{
+				slog.String("operation", call.Operation),
+				slog.String("entity_id", call.EntityID),
+				slog.Int("attempt", call.Attempt),
+				slog.String("method", call.Request.Method),
+				slog.String("path", call.Request.URL.Path),
+			}
+			if opts.Headers {
+				attrs = append(attrs, slog.Any("request_headers", redactHeaders(call.Request.Header)))
+			}
+			if opts.Bodies && call.Request.GetBody != nil {
+				if body, err := call.Request.GetBody(); err == nil {
+					data, _ := ioutil.ReadAll(body)
+					body.Close()
+					attrs = append(attrs, slog.String("request_body", redactBody(data, opts.MaxBodySize)))
+				}
+			}
+			start := time.Now()
+			resp, err := next(call)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "iland api call failed", attrs...)
+				return resp, err
+			}
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if opts.Headers {
+				attrs = append(attrs, slog.Any("response_headers", redactHeaders(resp.Header)))
+			}
+			if opts.Bodies {
+				data, readErr := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+				if readErr == nil {
+					attrs = append(attrs, slog.String("response_body", redactBody(data, opts.MaxBodySize)))
+				}
+			}
+			level := opts.Level
+			if resp.StatusCode >= 400 {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "iland api call", attrs...)
+			return resp, nil
+		}
+	}
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	out := map[string]string{}
+	for name, values := range header {
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization":
+			out[name] = redacted
+		default:
+			out[name] = strings.Join(values, ", ")
+		}
+	}
+	return out
+}
+
+func redactBody(data []byte, max int) string {
+	var value interface{}
+	if json.Unmarshal(data, &value) == nil {
+		if redactedData, err := json.Marshal(redactValue(value)); err == nil {
+			data = redactedData
+		}
+	}
+	if len(data) > max {
+		return string(data[:max]) + "...(truncated)"
+	}
+	return string(data)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, field := range v {
+			v[i] = redactValue(field)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"password", "secret", "token", "ticket"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
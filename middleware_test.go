@@ -0,0 +1,81 @@
+package iland
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggingMiddlewareRedacts(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestBody  string
+		responseBody string
+		// secrets must not appear in the log, and kept must.
+		secrets []string
+		kept    []string
+	}{
+		{
+			name:        "authorization header",
+			requestBody: `{}`, responseBody: `{}`,
+			secrets: []string{"access-s3cret"}, kept: []string{`"Authorization":"[REDACTED]"`},
+		},
+		{
+			name:        "password",
+			requestBody: `{"username":"ops","password":"pw-s3cret"}`, responseBody: `{}`,
+			secrets: []string{"pw-s3cret"}, kept: []string{`"username":"ops"`},
+		},
+		{
+			name:        "nested client secret",
+			requestBody: `{"apps":[{"client_id":"app","client_secret":"cs-s3cret"}]}`, responseBody: `{}`,
+			secrets: []string{"cs-s3cret"}, kept: []string{`"client_id":"app"`},
+		},
+		{
+			name:        "tokens in response",
+			requestBody: `{}`, responseBody: `{"access_token":"at-s3cret","refresh_token":"rt-s3cret","expires_in":300}`,
+			secrets: []string{"at-s3cret", "rt-s3cret"}, kept: []string{`"expires_in":300`},
+		},
+		{
+			name:        "response cookie",
+			requestBody: `{}`, responseBody: `{}`,
+			secrets: []string{"cookie-s3cret"}, kept: []string{`"Set-Cookie":"[REDACTED]"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := &bytes.Buffer{}
+			logger := slog.New(slog.NewJSONHandler(logs, nil))
+			token := &Token{AccessToken: "access-s3cret", Expiry: time.Now().Add(time.Hour)}
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.SetCookie(w, &http.Cookie{Name: "session", Value: "cookie-s3cret"})
+				w.Write([]byte(tt.responseBody))
+			}), WithTokenSource(StaticTokenSource(token)), WithMiddleware(LoggingMiddleware(logger, LoggingOptions{Headers: true, Bodies: true})))
+
+			body, err := c.request(context.Background(), "/v1/items", http.MethodPost, c.mediaType("json"), []byte(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			body.Close()
+
+			// The log escapes the quotes of bodies, which are strings in it.
+			log := strings.ReplaceAll(logs.String(), `\"`, `"`)
+			if !strings.Contains(log, redacted) {
+				t.Errorf("nothing was redacted:\n%s", log)
+			}
+			for _, secret := range tt.secrets {
+				if strings.Contains(log, secret) {
+					t.Errorf("log contains %q:\n%s", secret, log)
+				}
+			}
+			for _, kept := range tt.kept {
+				if !strings.Contains(log, kept) {
+					t.Errorf("log does not contain %s:\n%s", kept, log)
+				}
+			}
+		})
+	}
+}
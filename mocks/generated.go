@@ -0,0 +1,2778 @@
+// Code generated by mockgen from interfaces.go. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+)
+
+// ConsoleService is a mock of iland.ConsoleService.
+type ConsoleService struct {
+	Mock
+
+	LocationService        *LocationService
+	CompanyService         *CompanyService
+	UserService            *UserService
+	OrgService             *OrgService
+	CatalogService         *CatalogService
+	VAppTemplateService    *VAppTemplateService
+	VdcService             *VdcService
+	EdgeService            *EdgeService
+	OrgVdcNetworkService   *OrgVdcNetworkService
+	VAppService            *VAppService
+	VAppNetworkService     *VAppNetworkService
+	VirtualMachineService  *VirtualMachineService
+	VCCBackupTenantService *VCCBackupTenantService
+	VacTenantService       *VacTenantService
+	VpgService             *VpgService
+	O365Service            *O365Service
+	TaskService            *TaskService
+	EventService           *EventService
+}
+
+// NewConsoleService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewConsoleService(t TestingT) *ConsoleService {
+	m := &ConsoleService{}
+	m.LocationService = NewLocationService(t)
+	m.CompanyService = NewCompanyService(t)
+	m.UserService = NewUserService(t)
+	m.OrgService = NewOrgService(t)
+	m.CatalogService = NewCatalogService(t)
+	m.VAppTemplateService = NewVAppTemplateService(t)
+	m.VdcService = NewVdcService(t)
+	m.EdgeService = NewEdgeService(t)
+	m.OrgVdcNetworkService = NewOrgVdcNetworkService(t)
+	m.VAppService = NewVAppService(t)
+	m.VAppNetworkService = NewVAppNetworkService(t)
+	m.VirtualMachineService = NewVirtualMachineService(t)
+	m.VCCBackupTenantService = NewVCCBackupTenantService(t)
+	m.VacTenantService = NewVacTenantService(t)
+	m.VpgService = NewVpgService(t)
+	m.O365Service = NewO365Service(t)
+	m.TaskService = NewTaskService(t)
+	m.EventService = NewEventService(t)
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *ConsoleService) Get(endpoint string) (io.ReadCloser, error) {
+	ret := m.methodCalled("Get", endpoint)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetContext(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	ret := m.methodCalled("GetContext", ctx, endpoint)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) Post(endpoint string, body []byte) (io.ReadCloser, error) {
+	ret := m.methodCalled("Post", endpoint, body)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) PostContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error) {
+	ret := m.methodCalled("PostContext", ctx, endpoint, body)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) Put(endpoint string, body []byte) (io.ReadCloser, error) {
+	ret := m.methodCalled("Put", endpoint, body)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) PutContext(ctx context.Context, endpoint string, body []byte) (io.ReadCloser, error) {
+	ret := m.methodCalled("PutContext", ctx, endpoint, body)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) Delete(endpoint string) (io.ReadCloser, error) {
+	ret := m.methodCalled("Delete", endpoint)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) DeleteContext(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	ret := m.methodCalled("DeleteContext", ctx, endpoint)
+	return value[io.ReadCloser](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetOperatingSystems() ([]iland.OperatingSystem, error) {
+	ret := m.methodCalled("GetOperatingSystems")
+	return value[[]iland.OperatingSystem](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetOperatingSystemsContext(ctx context.Context) ([]iland.OperatingSystem, error) {
+	ret := m.methodCalled("GetOperatingSystemsContext", ctx)
+	return value[[]iland.OperatingSystem](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetLocations() []iland.Location {
+	ret := m.methodCalled("GetLocations")
+	return value[[]iland.Location](ret, 0)
+}
+
+func (m *ConsoleService) GetLocationsContext(ctx context.Context) ([]iland.Location, error) {
+	ret := m.methodCalled("GetLocationsContext", ctx)
+	return value[[]iland.Location](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetCompanies() ([]iland.Company, error) {
+	ret := m.methodCalled("GetCompanies")
+	return value[[]iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetCompaniesContext(ctx context.Context) ([]iland.Company, error) {
+	ret := m.methodCalled("GetCompaniesContext", ctx)
+	return value[[]iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetOrgs() ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgs")
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) GetOrgsContext(ctx context.Context) ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgsContext", ctx)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) StreamEvents(companyID string) (chan iland.Event, error) {
+	ret := m.methodCalled("StreamEvents", companyID)
+	return value[chan iland.Event](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) StreamEventsContext(ctx context.Context, companyID string) (chan iland.Event, error) {
+	ret := m.methodCalled("StreamEventsContext", ctx, companyID)
+	return value[chan iland.Event](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) OpenEventStream(ctx context.Context, companyID string, opts iland.EventStreamOptions) (*iland.EventStream, error) {
+	ret := m.methodCalled("OpenEventStream", ctx, companyID, opts)
+	return value[*iland.EventStream](ret, 0), value[error](ret, 1)
+}
+
+func (m *ConsoleService) Location() iland.LocationService {
+	if ret, ok := m.called("Location"); ok {
+		return value[iland.LocationService](ret, 0)
+	}
+	return m.LocationService
+}
+
+func (m *ConsoleService) Company() iland.CompanyService {
+	if ret, ok := m.called("Company"); ok {
+		return value[iland.CompanyService](ret, 0)
+	}
+	return m.CompanyService
+}
+
+func (m *ConsoleService) User() iland.UserService {
+	if ret, ok := m.called("User"); ok {
+		return value[iland.UserService](ret, 0)
+	}
+	return m.UserService
+}
+
+func (m *ConsoleService) Org() iland.OrgService {
+	if ret, ok := m.called("Org"); ok {
+		return value[iland.OrgService](ret, 0)
+	}
+	return m.OrgService
+}
+
+func (m *ConsoleService) Catalog() iland.CatalogService {
+	if ret, ok := m.called("Catalog"); ok {
+		return value[iland.CatalogService](ret, 0)
+	}
+	return m.CatalogService
+}
+
+func (m *ConsoleService) VAppTemplate() iland.VAppTemplateService {
+	if ret, ok := m.called("VAppTemplate"); ok {
+		return value[iland.VAppTemplateService](ret, 0)
+	}
+	return m.VAppTemplateService
+}
+
+func (m *ConsoleService) Vdc() iland.VdcService {
+	if ret, ok := m.called("Vdc"); ok {
+		return value[iland.VdcService](ret, 0)
+	}
+	return m.VdcService
+}
+
+func (m *ConsoleService) Edge() iland.EdgeService {
+	if ret, ok := m.called("Edge"); ok {
+		return value[iland.EdgeService](ret, 0)
+	}
+	return m.EdgeService
+}
+
+func (m *ConsoleService) OrgVdcNetwork() iland.OrgVdcNetworkService {
+	if ret, ok := m.called("OrgVdcNetwork"); ok {
+		return value[iland.OrgVdcNetworkService](ret, 0)
+	}
+	return m.OrgVdcNetworkService
+}
+
+func (m *ConsoleService) VApp() iland.VAppService {
+	if ret, ok := m.called("VApp"); ok {
+		return value[iland.VAppService](ret, 0)
+	}
+	return m.VAppService
+}
+
+func (m *ConsoleService) VAppNetwork() iland.VAppNetworkService {
+	if ret, ok := m.called("VAppNetwork"); ok {
+		return value[iland.VAppNetworkService](ret, 0)
+	}
+	return m.VAppNetworkService
+}
+
+func (m *ConsoleService) VirtualMachine() iland.VirtualMachineService {
+	if ret, ok := m.called("VirtualMachine"); ok {
+		return value[iland.VirtualMachineService](ret, 0)
+	}
+	return m.VirtualMachineService
+}
+
+func (m *ConsoleService) VCCBackupTenant() iland.VCCBackupTenantService {
+	if ret, ok := m.called("VCCBackupTenant"); ok {
+		return value[iland.VCCBackupTenantService](ret, 0)
+	}
+	return m.VCCBackupTenantService
+}
+
+func (m *ConsoleService) VacTenant() iland.VacTenantService {
+	if ret, ok := m.called("VacTenant"); ok {
+		return value[iland.VacTenantService](ret, 0)
+	}
+	return m.VacTenantService
+}
+
+func (m *ConsoleService) Vpg() iland.VpgService {
+	if ret, ok := m.called("Vpg"); ok {
+		return value[iland.VpgService](ret, 0)
+	}
+	return m.VpgService
+}
+
+func (m *ConsoleService) O365() iland.O365Service {
+	if ret, ok := m.called("O365"); ok {
+		return value[iland.O365Service](ret, 0)
+	}
+	return m.O365Service
+}
+
+func (m *ConsoleService) Task() iland.TaskService {
+	if ret, ok := m.called("Task"); ok {
+		return value[iland.TaskService](ret, 0)
+	}
+	return m.TaskService
+}
+
+func (m *ConsoleService) Event() iland.EventService {
+	if ret, ok := m.called("Event"); ok {
+		return value[iland.EventService](ret, 0)
+	}
+	return m.EventService
+}
+
+// LocationService is a mock of iland.LocationService.
+type LocationService struct {
+	Mock
+}
+
+// NewLocationService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewLocationService(t TestingT) *LocationService {
+	m := &LocationService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *LocationService) Get(locationID string) (iland.Location, error) {
+	ret := m.methodCalled("Get", locationID)
+	return value[iland.Location](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) GetContext(ctx context.Context, locationID string) (iland.Location, error) {
+	ret := m.methodCalled("GetContext", ctx, locationID)
+	return value[iland.Location](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) GetPublicCatalogs(locationID string) ([]iland.Catalog, error) {
+	ret := m.methodCalled("GetPublicCatalogs", locationID)
+	return value[[]iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) GetPublicCatalogsContext(ctx context.Context, locationID string) ([]iland.Catalog, error) {
+	ret := m.methodCalled("GetPublicCatalogsContext", ctx, locationID)
+	return value[[]iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) ListPublicCatalogs(ctx context.Context, locationID string, opts iland.ListOptions) iter.Seq2[iland.Catalog, error] {
+	ret := m.methodCalled("ListPublicCatalogs", ctx, locationID, opts)
+	return seqValue[iland.Catalog](ret, 0)
+}
+
+func (m *LocationService) GetPublicVAppTemplates(locationID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetPublicVAppTemplates", locationID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) GetPublicVAppTemplatesContext(ctx context.Context, locationID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetPublicVAppTemplatesContext", ctx, locationID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) ListPublicVAppTemplates(ctx context.Context, locationID string, opts iland.ListOptions) iter.Seq2[iland.VAppTemplate, error] {
+	ret := m.methodCalled("ListPublicVAppTemplates", ctx, locationID, opts)
+	return seqValue[iland.VAppTemplate](ret, 0)
+}
+
+func (m *LocationService) GetPublicMedia(locationID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetPublicMedia", locationID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) GetPublicMediaContext(ctx context.Context, locationID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetPublicMediaContext", ctx, locationID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *LocationService) ListPublicMedia(ctx context.Context, locationID string, opts iland.ListOptions) iter.Seq2[iland.Media, error] {
+	ret := m.methodCalled("ListPublicMedia", ctx, locationID, opts)
+	return seqValue[iland.Media](ret, 0)
+}
+
+// TaskService is a mock of iland.TaskService.
+type TaskService struct {
+	Mock
+}
+
+// NewTaskService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewTaskService(t TestingT) *TaskService {
+	m := &TaskService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *TaskService) Get(taskID string) (iland.Task, error) {
+	ret := m.methodCalled("Get", taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) GetContext(ctx context.Context, taskID string) (iland.Task, error) {
+	ret := m.methodCalled("GetContext", ctx, taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) Track(taskID string) (iland.Task, error) {
+	ret := m.methodCalled("Track", taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) TrackContext(ctx context.Context, taskID string) (iland.Task, error) {
+	ret := m.methodCalled("TrackContext", ctx, taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) Wait(ctx context.Context, taskID string, opts iland.WaitOptions) (iland.Task, error) {
+	ret := m.methodCalled("Wait", ctx, taskID, opts)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) WaitAll(ctx context.Context, taskIDs []string, opts iland.WaitOptions) ([]iland.Task, error) {
+	ret := m.methodCalled("WaitAll", ctx, taskIDs, opts)
+	return value[[]iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) WaitAny(ctx context.Context, taskIDs []string, opts iland.WaitOptions) (iland.Task, error) {
+	ret := m.methodCalled("WaitAny", ctx, taskIDs, opts)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) Query(entityID string, entityType string, childTasks bool) ([]iland.Task, error) {
+	ret := m.methodCalled("Query", entityID, entityType, childTasks)
+	return value[[]iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) QueryContext(ctx context.Context, entityID string, entityType string, childTasks bool) ([]iland.Task, error) {
+	ret := m.methodCalled("QueryContext", ctx, entityID, entityType, childTasks)
+	return value[[]iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) List(ctx context.Context, entityID string, entityType string, childTasks bool, opts iland.ListOptions) iter.Seq2[iland.Task, error] {
+	ret := m.methodCalled("List", ctx, entityID, entityType, childTasks, opts)
+	return seqValue[iland.Task](ret, 0)
+}
+
+func (m *TaskService) Search(ctx context.Context, query iland.TaskQuery, opts iland.ListOptions) iter.Seq2[iland.Task, error] {
+	ret := m.methodCalled("Search", ctx, query, opts)
+	return seqValue[iland.Task](ret, 0)
+}
+
+func (m *TaskService) Cancel(taskID string) (iland.Task, error) {
+	ret := m.methodCalled("Cancel", taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) CancelContext(ctx context.Context, taskID string) (iland.Task, error) {
+	ret := m.methodCalled("CancelContext", ctx, taskID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) Respond(taskID string, choiceID string) (iland.Task, error) {
+	ret := m.methodCalled("Respond", taskID, choiceID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *TaskService) RespondContext(ctx context.Context, taskID string, choiceID string) (iland.Task, error) {
+	ret := m.methodCalled("RespondContext", ctx, taskID, choiceID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+// EventService is a mock of iland.EventService.
+type EventService struct {
+	Mock
+}
+
+// NewEventService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewEventService(t TestingT) *EventService {
+	m := &EventService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *EventService) Search(ctx context.Context, query iland.EventQuery, opts iland.ListOptions) iter.Seq2[iland.Event, error] {
+	ret := m.methodCalled("Search", ctx, query, opts)
+	return seqValue[iland.Event](ret, 0)
+}
+
+// VCCBackupTenantService is a mock of iland.VCCBackupTenantService.
+type VCCBackupTenantService struct {
+	Mock
+}
+
+// NewVCCBackupTenantService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVCCBackupTenantService(t TestingT) *VCCBackupTenantService {
+	m := &VCCBackupTenantService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VCCBackupTenantService) Get(vccBackupTenantID string) (iland.VCCBackupTenant, error) {
+	ret := m.methodCalled("Get", vccBackupTenantID)
+	return value[iland.VCCBackupTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *VCCBackupTenantService) GetContext(ctx context.Context, vccBackupTenantID string) (iland.VCCBackupTenant, error) {
+	ret := m.methodCalled("GetContext", ctx, vccBackupTenantID)
+	return value[iland.VCCBackupTenant](ret, 0), value[error](ret, 1)
+}
+
+// VacTenantService is a mock of iland.VacTenantService.
+type VacTenantService struct {
+	Mock
+}
+
+// NewVacTenantService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVacTenantService(t TestingT) *VacTenantService {
+	m := &VacTenantService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VacTenantService) Get(id string) (iland.VacTenant, error) {
+	ret := m.methodCalled("Get", id)
+	return value[iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *VacTenantService) GetContext(ctx context.Context, id string) (iland.VacTenant, error) {
+	ret := m.methodCalled("GetContext", ctx, id)
+	return value[iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+// O365Service is a mock of iland.O365Service.
+type O365Service struct {
+	Mock
+}
+
+// NewO365Service returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewO365Service(t TestingT) *O365Service {
+	m := &O365Service{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *O365Service) GetOrganization(id string) (iland.O365Organization, error) {
+	ret := m.methodCalled("GetOrganization", id)
+	return value[iland.O365Organization](ret, 0), value[error](ret, 1)
+}
+
+func (m *O365Service) GetOrganizationContext(ctx context.Context, id string) (iland.O365Organization, error) {
+	ret := m.methodCalled("GetOrganizationContext", ctx, id)
+	return value[iland.O365Organization](ret, 0), value[error](ret, 1)
+}
+
+func (m *O365Service) GetUsers(id string) ([]iland.O365User, error) {
+	ret := m.methodCalled("GetUsers", id)
+	return value[[]iland.O365User](ret, 0), value[error](ret, 1)
+}
+
+func (m *O365Service) GetUsersContext(ctx context.Context, id string) ([]iland.O365User, error) {
+	ret := m.methodCalled("GetUsersContext", ctx, id)
+	return value[[]iland.O365User](ret, 0), value[error](ret, 1)
+}
+
+func (m *O365Service) ListUsers(ctx context.Context, id string, opts iland.ListOptions) iter.Seq2[iland.O365User, error] {
+	ret := m.methodCalled("ListUsers", ctx, id, opts)
+	return seqValue[iland.O365User](ret, 0)
+}
+
+func (m *O365Service) GetUserReport(id string) ([]byte, error) {
+	ret := m.methodCalled("GetUserReport", id)
+	return value[[]byte](ret, 0), value[error](ret, 1)
+}
+
+func (m *O365Service) GetUserReportContext(ctx context.Context, id string) ([]byte, error) {
+	ret := m.methodCalled("GetUserReportContext", ctx, id)
+	return value[[]byte](ret, 0), value[error](ret, 1)
+}
+
+// CompanyService is a mock of iland.CompanyService.
+type CompanyService struct {
+	Mock
+}
+
+// NewCompanyService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewCompanyService(t TestingT) *CompanyService {
+	m := &CompanyService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *CompanyService) Get(companyID string) (iland.Company, error) {
+	ret := m.methodCalled("Get", companyID)
+	return value[iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetContext(ctx context.Context, companyID string) (iland.Company, error) {
+	ret := m.methodCalled("GetContext", ctx, companyID)
+	return value[iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetUsers(companyID string) ([]iland.User, error) {
+	ret := m.methodCalled("GetUsers", companyID)
+	return value[[]iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetUsersContext(ctx context.Context, companyID string) ([]iland.User, error) {
+	ret := m.methodCalled("GetUsersContext", ctx, companyID)
+	return value[[]iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListUsers(ctx context.Context, companyID string, opts iland.ListOptions) iter.Seq2[iland.User, error] {
+	ret := m.methodCalled("ListUsers", ctx, companyID, opts)
+	return seqValue[iland.User](ret, 0)
+}
+
+func (m *CompanyService) CreateUser(companyID string, params iland.CreateUserParams) (iland.User, error) {
+	ret := m.methodCalled("CreateUser", companyID, params)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) CreateUserContext(ctx context.Context, companyID string, params iland.CreateUserParams) (iland.User, error) {
+	ret := m.methodCalled("CreateUserContext", ctx, companyID, params)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetRoles(companyID string) ([]iland.Role, error) {
+	ret := m.methodCalled("GetRoles", companyID)
+	return value[[]iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetRolesContext(ctx context.Context, companyID string) ([]iland.Role, error) {
+	ret := m.methodCalled("GetRolesContext", ctx, companyID)
+	return value[[]iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListRoles(ctx context.Context, companyID string, opts iland.ListOptions) iter.Seq2[iland.Role, error] {
+	ret := m.methodCalled("ListRoles", ctx, companyID, opts)
+	return seqValue[iland.Role](ret, 0)
+}
+
+func (m *CompanyService) GetRole(companyID string, roleID string) (iland.Role, error) {
+	ret := m.methodCalled("GetRole", companyID, roleID)
+	return value[iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetRoleContext(ctx context.Context, companyID string, roleID string) (iland.Role, error) {
+	ret := m.methodCalled("GetRoleContext", ctx, companyID, roleID)
+	return value[iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetOrgs(companyID string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgs", companyID)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetOrgsContext(ctx context.Context, companyID string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgsContext", ctx, companyID)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetLocationOrgs(companyID string, locationID string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetLocationOrgs", companyID, locationID)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetLocationOrgsContext(ctx context.Context, companyID string, locationID string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetLocationOrgsContext", ctx, companyID, locationID)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListLocationOrgs(ctx context.Context, companyID string, locationID string, opts iland.ListOptions) iter.Seq2[iland.Org, error] {
+	ret := m.methodCalled("ListLocationOrgs", ctx, companyID, locationID, opts)
+	return seqValue[iland.Org](ret, 0)
+}
+
+func (m *CompanyService) GetVCCBackupTenants(companyID string) ([]iland.VCCBackupTenant, error) {
+	ret := m.methodCalled("GetVCCBackupTenants", companyID)
+	return value[[]iland.VCCBackupTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetVCCBackupTenantsContext(ctx context.Context, companyID string) ([]iland.VCCBackupTenant, error) {
+	ret := m.methodCalled("GetVCCBackupTenantsContext", ctx, companyID)
+	return value[[]iland.VCCBackupTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListVCCBackupTenants(ctx context.Context, companyID string, opts iland.ListOptions) iter.Seq2[iland.VCCBackupTenant, error] {
+	ret := m.methodCalled("ListVCCBackupTenants", ctx, companyID, opts)
+	return seqValue[iland.VCCBackupTenant](ret, 0)
+}
+
+func (m *CompanyService) GetVacTenants(companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetVacTenants", companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetVacTenantsContext(ctx context.Context, companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetVacTenantsContext", ctx, companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListVacTenants(ctx context.Context, companyID string, opts iland.ListOptions) iter.Seq2[iland.VacTenant, error] {
+	ret := m.methodCalled("ListVacTenants", ctx, companyID, opts)
+	return seqValue[iland.VacTenant](ret, 0)
+}
+
+func (m *CompanyService) GetLocationVacTenants(companyID string, location string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetLocationVacTenants", companyID, location)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetLocationVacTenantsContext(ctx context.Context, companyID string, location string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetLocationVacTenantsContext", ctx, companyID, location)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) ListLocationVacTenants(ctx context.Context, companyID string, location string, opts iland.ListOptions) iter.Seq2[iland.VacTenant, error] {
+	ret := m.methodCalled("ListLocationVacTenants", ctx, companyID, location, opts)
+	return seqValue[iland.VacTenant](ret, 0)
+}
+
+func (m *CompanyService) GetInventory(companyID string) (iland.CompanyInventory, error) {
+	ret := m.methodCalled("GetInventory", companyID)
+	return value[iland.CompanyInventory](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetInventoryContext(ctx context.Context, companyID string) (iland.CompanyInventory, error) {
+	ret := m.methodCalled("GetInventoryContext", ctx, companyID)
+	return value[iland.CompanyInventory](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetInventoryGraph(companyID string) (*iland.InventoryGraph, error) {
+	ret := m.methodCalled("GetInventoryGraph", companyID)
+	return value[*iland.InventoryGraph](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetInventoryGraphContext(ctx context.Context, companyID string) (*iland.InventoryGraph, error) {
+	ret := m.methodCalled("GetInventoryGraphContext", ctx, companyID)
+	return value[*iland.InventoryGraph](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetInventorySnapshot(companyID string) (*iland.InventorySnapshot, error) {
+	ret := m.methodCalled("GetInventorySnapshot", companyID)
+	return value[*iland.InventorySnapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetInventorySnapshotContext(ctx context.Context, companyID string) (*iland.InventorySnapshot, error) {
+	ret := m.methodCalled("GetInventorySnapshotContext", ctx, companyID)
+	return value[*iland.InventorySnapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetLocations(companyID string) ([]iland.Location, error) {
+	ret := m.methodCalled("GetLocations", companyID)
+	return value[[]iland.Location](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) GetLocationsContext(ctx context.Context, companyID string) ([]iland.Location, error) {
+	ret := m.methodCalled("GetLocationsContext", ctx, companyID)
+	return value[[]iland.Location](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) SearchVirtualMachines(ctx context.Context, companyID string, query iland.VirtualMachineQuery) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("SearchVirtualMachines", ctx, companyID, query)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) SearchVApps(ctx context.Context, companyID string, query iland.VAppQuery) ([]iland.VApp, error) {
+	ret := m.methodCalled("SearchVApps", ctx, companyID, query)
+	return value[[]iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *CompanyService) SearchEdges(ctx context.Context, companyID string, query iland.EdgeQuery) ([]iland.Edge, error) {
+	ret := m.methodCalled("SearchEdges", ctx, companyID, query)
+	return value[[]iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+// UserService is a mock of iland.UserService.
+type UserService struct {
+	Mock
+}
+
+// NewUserService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewUserService(t TestingT) *UserService {
+	m := &UserService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *UserService) Get(username string) (iland.User, error) {
+	ret := m.methodCalled("Get", username)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetContext(ctx context.Context, username string) (iland.User, error) {
+	ret := m.methodCalled("GetContext", ctx, username)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) Delete(username string) error {
+	ret := m.methodCalled("Delete", username)
+	return value[error](ret, 0)
+}
+
+func (m *UserService) DeleteContext(ctx context.Context, username string) error {
+	ret := m.methodCalled("DeleteContext", ctx, username)
+	return value[error](ret, 0)
+}
+
+func (m *UserService) Update(username string, params iland.UpdateUserParams) (iland.User, error) {
+	ret := m.methodCalled("Update", username, params)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) UpdateContext(ctx context.Context, username string, params iland.UpdateUserParams) (iland.User, error) {
+	ret := m.methodCalled("UpdateContext", ctx, username, params)
+	return value[iland.User](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetCompanies(username string) ([]iland.Company, error) {
+	ret := m.methodCalled("GetCompanies", username)
+	return value[[]iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetCompaniesContext(ctx context.Context, username string) ([]iland.Company, error) {
+	ret := m.methodCalled("GetCompaniesContext", ctx, username)
+	return value[[]iland.Company](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) ListCompanies(ctx context.Context, username string, opts iland.ListOptions) iter.Seq2[iland.Company, error] {
+	ret := m.methodCalled("ListCompanies", ctx, username, opts)
+	return seqValue[iland.Company](ret, 0)
+}
+
+func (m *UserService) GetUserCompanyVacTenants(username string, companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetUserCompanyVacTenants", username, companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetUserCompanyVacTenantsContext(ctx context.Context, username string, companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetUserCompanyVacTenantsContext", ctx, username, companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) ListUserCompanyVacTenants(ctx context.Context, username string, companyID string, opts iland.ListOptions) iter.Seq2[iland.VacTenant, error] {
+	ret := m.methodCalled("ListUserCompanyVacTenants", ctx, username, companyID, opts)
+	return seqValue[iland.VacTenant](ret, 0)
+}
+
+func (m *UserService) GetCompanyVacTenants(companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetCompanyVacTenants", companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetCompanyVacTenantsContext(ctx context.Context, companyID string) ([]iland.VacTenant, error) {
+	ret := m.methodCalled("GetCompanyVacTenantsContext", ctx, companyID)
+	return value[[]iland.VacTenant](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetOrgs(username string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgs", username)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetOrgsContext(ctx context.Context, username string) ([]iland.Org, error) {
+	ret := m.methodCalled("GetOrgsContext", ctx, username)
+	return value[[]iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) ListOrgs(ctx context.Context, username string, opts iland.ListOptions) iter.Seq2[iland.Org, error] {
+	ret := m.methodCalled("ListOrgs", ctx, username, opts)
+	return seqValue[iland.Org](ret, 0)
+}
+
+func (m *UserService) AssignRole(username string, companyID string, roleID string) error {
+	ret := m.methodCalled("AssignRole", username, companyID, roleID)
+	return value[error](ret, 0)
+}
+
+func (m *UserService) AssignRoleContext(ctx context.Context, username string, companyID string, roleID string) error {
+	ret := m.methodCalled("AssignRoleContext", ctx, username, companyID, roleID)
+	return value[error](ret, 0)
+}
+
+func (m *UserService) GetRole(username string, companyID string) (iland.Role, error) {
+	ret := m.methodCalled("GetRole", username, companyID)
+	return value[iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) GetRoleContext(ctx context.Context, username string, companyID string) (iland.Role, error) {
+	ret := m.methodCalled("GetRoleContext", ctx, username, companyID)
+	return value[iland.Role](ret, 0), value[error](ret, 1)
+}
+
+func (m *UserService) DeleteRole(username string, companyID string) error {
+	ret := m.methodCalled("DeleteRole", username, companyID)
+	return value[error](ret, 0)
+}
+
+func (m *UserService) DeleteRoleContext(ctx context.Context, username string, companyID string) error {
+	ret := m.methodCalled("DeleteRoleContext", ctx, username, companyID)
+	return value[error](ret, 0)
+}
+
+// OrgService is a mock of iland.OrgService.
+type OrgService struct {
+	Mock
+}
+
+// NewOrgService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewOrgService(t TestingT) *OrgService {
+	m := &OrgService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *OrgService) Get(orgID string) (iland.Org, error) {
+	ret := m.methodCalled("Get", orgID)
+	return value[iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetContext(ctx context.Context, orgID string) (iland.Org, error) {
+	ret := m.methodCalled("GetContext", ctx, orgID)
+	return value[iland.Org](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVdcs(orgID string) ([]iland.Vdc, error) {
+	ret := m.methodCalled("GetVdcs", orgID)
+	return value[[]iland.Vdc](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVdcsContext(ctx context.Context, orgID string) ([]iland.Vdc, error) {
+	ret := m.methodCalled("GetVdcsContext", ctx, orgID)
+	return value[[]iland.Vdc](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVdcs(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.Vdc, error] {
+	ret := m.methodCalled("ListVdcs", ctx, orgID, opts)
+	return seqValue[iland.Vdc](ret, 0)
+}
+
+func (m *OrgService) GetEdges(orgID string) ([]iland.Edge, error) {
+	ret := m.methodCalled("GetEdges", orgID)
+	return value[[]iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetEdgesContext(ctx context.Context, orgID string) ([]iland.Edge, error) {
+	ret := m.methodCalled("GetEdgesContext", ctx, orgID)
+	return value[[]iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListEdges(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.Edge, error] {
+	ret := m.methodCalled("ListEdges", ctx, orgID, opts)
+	return seqValue[iland.Edge](ret, 0)
+}
+
+func (m *OrgService) GetCatalogs(orgID string) ([]iland.Catalog, error) {
+	ret := m.methodCalled("GetCatalogs", orgID)
+	return value[[]iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetCatalogsContext(ctx context.Context, orgID string) ([]iland.Catalog, error) {
+	ret := m.methodCalled("GetCatalogsContext", ctx, orgID)
+	return value[[]iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListCatalogs(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.Catalog, error] {
+	ret := m.methodCalled("ListCatalogs", ctx, orgID, opts)
+	return seqValue[iland.Catalog](ret, 0)
+}
+
+func (m *OrgService) GetVAppTemplates(orgID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetVAppTemplates", orgID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVAppTemplatesContext(ctx context.Context, orgID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetVAppTemplatesContext", ctx, orgID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVAppTemplates(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.VAppTemplate, error] {
+	ret := m.methodCalled("ListVAppTemplates", ctx, orgID, opts)
+	return seqValue[iland.VAppTemplate](ret, 0)
+}
+
+func (m *OrgService) GetMedia(orgID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetMedia", orgID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetMediaContext(ctx context.Context, orgID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetMediaContext", ctx, orgID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListMedia(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.Media, error] {
+	ret := m.methodCalled("ListMedia", ctx, orgID, opts)
+	return seqValue[iland.Media](ret, 0)
+}
+
+func (m *OrgService) GetNetworks(orgID string) ([]iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("GetNetworks", orgID)
+	return value[[]iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetNetworksContext(ctx context.Context, orgID string) ([]iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("GetNetworksContext", ctx, orgID)
+	return value[[]iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListNetworks(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.OrgVdcNetwork, error] {
+	ret := m.methodCalled("ListNetworks", ctx, orgID, opts)
+	return seqValue[iland.OrgVdcNetwork](ret, 0)
+}
+
+func (m *OrgService) GetVApps(orgID string) ([]iland.VApp, error) {
+	ret := m.methodCalled("GetVApps", orgID)
+	return value[[]iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVAppsContext(ctx context.Context, orgID string) ([]iland.VApp, error) {
+	ret := m.methodCalled("GetVAppsContext", ctx, orgID)
+	return value[[]iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVApps(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.VApp, error] {
+	ret := m.methodCalled("ListVApps", ctx, orgID, opts)
+	return seqValue[iland.VApp](ret, 0)
+}
+
+func (m *OrgService) GetVirtualMachines(orgID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachines", orgID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVirtualMachinesContext(ctx context.Context, orgID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachinesContext", ctx, orgID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVirtualMachines(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachine, error] {
+	ret := m.methodCalled("ListVirtualMachines", ctx, orgID, opts)
+	return seqValue[iland.VirtualMachine](ret, 0)
+}
+
+func (m *OrgService) GetVpgs(orgID string) ([]iland.Vpg, error) {
+	ret := m.methodCalled("GetVpgs", orgID)
+	return value[[]iland.Vpg](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVpgsContext(ctx context.Context, orgID string) ([]iland.Vpg, error) {
+	ret := m.methodCalled("GetVpgsContext", ctx, orgID)
+	return value[[]iland.Vpg](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVpgs(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.Vpg, error] {
+	ret := m.methodCalled("ListVpgs", ctx, orgID, opts)
+	return seqValue[iland.Vpg](ret, 0)
+}
+
+func (m *OrgService) GetPublicIPs(orgID string) ([]string, error) {
+	ret := m.methodCalled("GetPublicIPs", orgID)
+	return value[[]string](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetPublicIPsContext(ctx context.Context, orgID string) ([]string, error) {
+	ret := m.methodCalled("GetPublicIPsContext", ctx, orgID)
+	return value[[]string](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetPublicIPAssignments(orgID string) ([]iland.PublicIPAssignment, error) {
+	ret := m.methodCalled("GetPublicIPAssignments", orgID)
+	return value[[]iland.PublicIPAssignment](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetPublicIPAssignmentsContext(ctx context.Context, orgID string) ([]iland.PublicIPAssignment, error) {
+	ret := m.methodCalled("GetPublicIPAssignmentsContext", ctx, orgID)
+	return value[[]iland.PublicIPAssignment](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListPublicIPAssignments(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.PublicIPAssignment, error] {
+	ret := m.methodCalled("ListPublicIPAssignments", ctx, orgID, opts)
+	return seqValue[iland.PublicIPAssignment](ret, 0)
+}
+
+func (m *OrgService) GetCurrentBill(orgID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBill", orgID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetCurrentBillContext(ctx context.Context, orgID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBillContext", ctx, orgID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetBill(orgID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBill", orgID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetBillContext(ctx context.Context, orgID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBillContext", ctx, orgID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVCCFailoverPlans(orgID string) ([]iland.VCCFailoverPlan, error) {
+	ret := m.methodCalled("GetVCCFailoverPlans", orgID)
+	return value[[]iland.VCCFailoverPlan](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) GetVCCFailoverPlansContext(ctx context.Context, orgID string) ([]iland.VCCFailoverPlan, error) {
+	ret := m.methodCalled("GetVCCFailoverPlansContext", ctx, orgID)
+	return value[[]iland.VCCFailoverPlan](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgService) ListVCCFailoverPlans(ctx context.Context, orgID string, opts iland.ListOptions) iter.Seq2[iland.VCCFailoverPlan, error] {
+	ret := m.methodCalled("ListVCCFailoverPlans", ctx, orgID, opts)
+	return seqValue[iland.VCCFailoverPlan](ret, 0)
+}
+
+// CatalogService is a mock of iland.CatalogService.
+type CatalogService struct {
+	Mock
+}
+
+// NewCatalogService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewCatalogService(t TestingT) *CatalogService {
+	m := &CatalogService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *CatalogService) Get(catalogID string) (iland.Catalog, error) {
+	ret := m.methodCalled("Get", catalogID)
+	return value[iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) GetContext(ctx context.Context, catalogID string) (iland.Catalog, error) {
+	ret := m.methodCalled("GetContext", ctx, catalogID)
+	return value[iland.Catalog](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) Update(catalogID string, params iland.UpdateCatalogParams) (iland.Task, error) {
+	ret := m.methodCalled("Update", catalogID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) UpdateContext(ctx context.Context, catalogID string, params iland.UpdateCatalogParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateContext", ctx, catalogID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) GetVAppTemplates(catalogID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetVAppTemplates", catalogID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) GetVAppTemplatesContext(ctx context.Context, catalogID string) ([]iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetVAppTemplatesContext", ctx, catalogID)
+	return value[[]iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) ListVAppTemplates(ctx context.Context, catalogID string, opts iland.ListOptions) iter.Seq2[iland.VAppTemplate, error] {
+	ret := m.methodCalled("ListVAppTemplates", ctx, catalogID, opts)
+	return seqValue[iland.VAppTemplate](ret, 0)
+}
+
+func (m *CatalogService) GetMedia(catalogID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetMedia", catalogID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) GetMediaContext(ctx context.Context, catalogID string) ([]iland.Media, error) {
+	ret := m.methodCalled("GetMediaContext", ctx, catalogID)
+	return value[[]iland.Media](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) ListMedia(ctx context.Context, catalogID string, opts iland.ListOptions) iter.Seq2[iland.Media, error] {
+	ret := m.methodCalled("ListMedia", ctx, catalogID, opts)
+	return seqValue[iland.Media](ret, 0)
+}
+
+func (m *CatalogService) CreateVAppTemplate(catalogID string, params iland.CreateVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("CreateVAppTemplate", catalogID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) CreateVAppTemplateContext(ctx context.Context, catalogID string, params iland.CreateVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("CreateVAppTemplateContext", ctx, catalogID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) SyncSubscription(catalogID string) (iland.Task, error) {
+	ret := m.methodCalled("SyncSubscription", catalogID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *CatalogService) SyncSubscriptionContext(ctx context.Context, catalogID string) (iland.Task, error) {
+	ret := m.methodCalled("SyncSubscriptionContext", ctx, catalogID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+// VAppTemplateService is a mock of iland.VAppTemplateService.
+type VAppTemplateService struct {
+	Mock
+}
+
+// NewVAppTemplateService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVAppTemplateService(t TestingT) *VAppTemplateService {
+	m := &VAppTemplateService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VAppTemplateService) Get(vappTemplateID string) (iland.VAppTemplate, error) {
+	ret := m.methodCalled("Get", vappTemplateID)
+	return value[iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) GetContext(ctx context.Context, vappTemplateID string) (iland.VAppTemplate, error) {
+	ret := m.methodCalled("GetContext", ctx, vappTemplateID)
+	return value[iland.VAppTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) Update(vappTemplateID string, params iland.UpdateVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("Update", vappTemplateID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) UpdateContext(ctx context.Context, vappTemplateID string, params iland.UpdateVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateContext", ctx, vappTemplateID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) Delete(vappTemplateID string) (iland.Task, error) {
+	ret := m.methodCalled("Delete", vappTemplateID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) DeleteContext(ctx context.Context, vappTemplateID string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteContext", ctx, vappTemplateID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) GetVirtualMachines(vappTemplateID string) ([]iland.VirtualMachineTemplate, error) {
+	ret := m.methodCalled("GetVirtualMachines", vappTemplateID)
+	return value[[]iland.VirtualMachineTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) GetVirtualMachinesContext(ctx context.Context, vappTemplateID string) ([]iland.VirtualMachineTemplate, error) {
+	ret := m.methodCalled("GetVirtualMachinesContext", ctx, vappTemplateID)
+	return value[[]iland.VirtualMachineTemplate](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) ListVirtualMachines(ctx context.Context, vappTemplateID string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachineTemplate, error] {
+	ret := m.methodCalled("ListVirtualMachines", ctx, vappTemplateID, opts)
+	return seqValue[iland.VirtualMachineTemplate](ret, 0)
+}
+
+func (m *VAppTemplateService) GetConfig(vappTemplateID string) (iland.VAppTemplateConfig, error) {
+	ret := m.methodCalled("GetConfig", vappTemplateID)
+	return value[iland.VAppTemplateConfig](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) GetConfigContext(ctx context.Context, vappTemplateID string) (iland.VAppTemplateConfig, error) {
+	ret := m.methodCalled("GetConfigContext", ctx, vappTemplateID)
+	return value[iland.VAppTemplateConfig](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) SyncSubscription(vappTemplateID string) (iland.Task, error) {
+	ret := m.methodCalled("SyncSubscription", vappTemplateID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppTemplateService) SyncSubscriptionContext(ctx context.Context, vappTemplateID string) (iland.Task, error) {
+	ret := m.methodCalled("SyncSubscriptionContext", ctx, vappTemplateID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+// VdcService is a mock of iland.VdcService.
+type VdcService struct {
+	Mock
+}
+
+// NewVdcService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVdcService(t TestingT) *VdcService {
+	m := &VdcService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VdcService) Get(vdcID string) (iland.Vdc, error) {
+	ret := m.methodCalled("Get", vdcID)
+	return value[iland.Vdc](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetContext(ctx context.Context, vdcID string) (iland.Vdc, error) {
+	ret := m.methodCalled("GetContext", ctx, vdcID)
+	return value[iland.Vdc](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetStorageProfiles(vdcID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetStorageProfiles", vdcID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetStorageProfilesContext(ctx context.Context, vdcID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetStorageProfilesContext", ctx, vdcID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetSummary(vdcID string) (iland.VdcSummary, error) {
+	ret := m.methodCalled("GetSummary", vdcID)
+	return value[iland.VdcSummary](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetSummaryContext(ctx context.Context, vdcID string) (iland.VdcSummary, error) {
+	ret := m.methodCalled("GetSummaryContext", ctx, vdcID)
+	return value[iland.VdcSummary](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetVApps(vdcID string) ([]iland.VApp, error) {
+	ret := m.methodCalled("GetVApps", vdcID)
+	return value[[]iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetVAppsContext(ctx context.Context, vdcID string) ([]iland.VApp, error) {
+	ret := m.methodCalled("GetVAppsContext", ctx, vdcID)
+	return value[[]iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) ListVApps(ctx context.Context, vdcID string, opts iland.ListOptions) iter.Seq2[iland.VApp, error] {
+	ret := m.methodCalled("ListVApps", ctx, vdcID, opts)
+	return seqValue[iland.VApp](ret, 0)
+}
+
+func (m *VdcService) GetVirtualMachines(vdcID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachines", vdcID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetVirtualMachinesContext(ctx context.Context, vdcID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachinesContext", ctx, vdcID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) ListVirtualMachines(ctx context.Context, vdcID string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachine, error] {
+	ret := m.methodCalled("ListVirtualMachines", ctx, vdcID, opts)
+	return seqValue[iland.VirtualMachine](ret, 0)
+}
+
+func (m *VdcService) GetEdges(vdcID string) ([]iland.Edge, error) {
+	ret := m.methodCalled("GetEdges", vdcID)
+	return value[[]iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetEdgesContext(ctx context.Context, vdcID string) ([]iland.Edge, error) {
+	ret := m.methodCalled("GetEdgesContext", ctx, vdcID)
+	return value[[]iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) ListEdges(ctx context.Context, vdcID string, opts iland.ListOptions) iter.Seq2[iland.Edge, error] {
+	ret := m.methodCalled("ListEdges", ctx, vdcID, opts)
+	return seqValue[iland.Edge](ret, 0)
+}
+
+func (m *VdcService) GetNetworks(vdcID string) ([]iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("GetNetworks", vdcID)
+	return value[[]iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetNetworksContext(ctx context.Context, vdcID string) ([]iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("GetNetworksContext", ctx, vdcID)
+	return value[[]iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) ListNetworks(ctx context.Context, vdcID string, opts iland.ListOptions) iter.Seq2[iland.OrgVdcNetwork, error] {
+	ret := m.methodCalled("ListNetworks", ctx, vdcID, opts)
+	return seqValue[iland.OrgVdcNetwork](ret, 0)
+}
+
+func (m *VdcService) GetCurrentBill(vdcID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBill", vdcID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetCurrentBillContext(ctx context.Context, vdcID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBillContext", ctx, vdcID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetBill(vdcID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBill", vdcID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetBillContext(ctx context.Context, vdcID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBillContext", ctx, vdcID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetCurrentVAppBill(vdcID string) ([]iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentVAppBill", vdcID)
+	return value[[]iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetCurrentVAppBillContext(ctx context.Context, vdcID string) ([]iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentVAppBillContext", ctx, vdcID)
+	return value[[]iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetVAppBill(vdcID string, month int, year int) ([]iland.Billing, error) {
+	ret := m.methodCalled("GetVAppBill", vdcID, month, year)
+	return value[[]iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetVAppBillContext(ctx context.Context, vdcID string, month int, year int) ([]iland.Billing, error) {
+	ret := m.methodCalled("GetVAppBillContext", ctx, vdcID, month, year)
+	return value[[]iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetPerformanceCounters(vdcID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCounters", vdcID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetPerformanceCountersContext(ctx context.Context, vdcID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCountersContext", ctx, vdcID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetPerformance(vdcID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformance", vdcID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetPerformanceContext(ctx context.Context, vdcID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformanceContext", ctx, vdcID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) BuildVApp(vdcID string, params iland.BuildVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("BuildVApp", vdcID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) BuildVAppContext(ctx context.Context, vdcID string, params iland.BuildVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("BuildVAppContext", ctx, vdcID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) DeployVAppTemplate(vdcID string, params iland.DeployVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("DeployVAppTemplate", vdcID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) DeployVAppTemplateContext(ctx context.Context, vdcID string, params iland.DeployVAppTemplateParams) (iland.Task, error) {
+	ret := m.methodCalled("DeployVAppTemplateContext", ctx, vdcID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetBackupStats(vdcID string) (iland.VdcBackupStats, error) {
+	ret := m.methodCalled("GetBackupStats", vdcID)
+	return value[iland.VdcBackupStats](ret, 0), value[error](ret, 1)
+}
+
+func (m *VdcService) GetBackupStatsContext(ctx context.Context, vdcID string) (iland.VdcBackupStats, error) {
+	ret := m.methodCalled("GetBackupStatsContext", ctx, vdcID)
+	return value[iland.VdcBackupStats](ret, 0), value[error](ret, 1)
+}
+
+// EdgeService is a mock of iland.EdgeService.
+type EdgeService struct {
+	Mock
+}
+
+// NewEdgeService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewEdgeService(t TestingT) *EdgeService {
+	m := &EdgeService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *EdgeService) Get(edgeID string) (iland.Edge, error) {
+	ret := m.methodCalled("Get", edgeID)
+	return value[iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) GetContext(ctx context.Context, edgeID string) (iland.Edge, error) {
+	ret := m.methodCalled("GetContext", ctx, edgeID)
+	return value[iland.Edge](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) GetFirewall(edgeID string) (iland.EdgeFirewall, error) {
+	ret := m.methodCalled("GetFirewall", edgeID)
+	return value[iland.EdgeFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) GetFirewallContext(ctx context.Context, edgeID string) (iland.EdgeFirewall, error) {
+	ret := m.methodCalled("GetFirewallContext", ctx, edgeID)
+	return value[iland.EdgeFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) UpdateFirewall(edgeID string, firewall iland.EdgeFirewall) (iland.EdgeFirewall, error) {
+	ret := m.methodCalled("UpdateFirewall", edgeID, firewall)
+	return value[iland.EdgeFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) UpdateFirewallContext(ctx context.Context, edgeID string, firewall iland.EdgeFirewall) (iland.EdgeFirewall, error) {
+	ret := m.methodCalled("UpdateFirewallContext", ctx, edgeID, firewall)
+	return value[iland.EdgeFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) GetNAT(edgeID string) (iland.EdgeNAT, error) {
+	ret := m.methodCalled("GetNAT", edgeID)
+	return value[iland.EdgeNAT](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) GetNATContext(ctx context.Context, edgeID string) (iland.EdgeNAT, error) {
+	ret := m.methodCalled("GetNATContext", ctx, edgeID)
+	return value[iland.EdgeNAT](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) UpdateNAT(edgeID string, nat iland.EdgeNAT) (iland.EdgeNAT, error) {
+	ret := m.methodCalled("UpdateNAT", edgeID, nat)
+	return value[iland.EdgeNAT](ret, 0), value[error](ret, 1)
+}
+
+func (m *EdgeService) UpdateNATContext(ctx context.Context, edgeID string, nat iland.EdgeNAT) (iland.EdgeNAT, error) {
+	ret := m.methodCalled("UpdateNATContext", ctx, edgeID, nat)
+	return value[iland.EdgeNAT](ret, 0), value[error](ret, 1)
+}
+
+// OrgVdcNetworkService is a mock of iland.OrgVdcNetworkService.
+type OrgVdcNetworkService struct {
+	Mock
+}
+
+// NewOrgVdcNetworkService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewOrgVdcNetworkService(t TestingT) *OrgVdcNetworkService {
+	m := &OrgVdcNetworkService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *OrgVdcNetworkService) Get(networkID string) (iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("Get", networkID)
+	return value[iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgVdcNetworkService) GetContext(ctx context.Context, networkID string) (iland.OrgVdcNetwork, error) {
+	ret := m.methodCalled("GetContext", ctx, networkID)
+	return value[iland.OrgVdcNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgVdcNetworkService) Update(networkID string, params iland.UpdateOrgVdcNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("Update", networkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *OrgVdcNetworkService) UpdateContext(ctx context.Context, networkID string, params iland.UpdateOrgVdcNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateContext", ctx, networkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+// VAppService is a mock of iland.VAppService.
+type VAppService struct {
+	Mock
+}
+
+// NewVAppService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVAppService(t TestingT) *VAppService {
+	m := &VAppService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VAppService) Get(vappID string) (iland.VApp, error) {
+	ret := m.methodCalled("Get", vappID)
+	return value[iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetContext(ctx context.Context, vappID string) (iland.VApp, error) {
+	ret := m.methodCalled("GetContext", ctx, vappID)
+	return value[iland.VApp](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Delete(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("Delete", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) DeleteContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetVirtualMachines(vappID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachines", vappID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetVirtualMachinesContext(ctx context.Context, vappID string) ([]iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetVirtualMachinesContext", ctx, vappID)
+	return value[[]iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) ListVirtualMachines(ctx context.Context, vappID string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachine, error] {
+	ret := m.methodCalled("ListVirtualMachines", ctx, vappID, opts)
+	return seqValue[iland.VirtualMachine](ret, 0)
+}
+
+func (m *VAppService) GetNetworks(vappID string) ([]iland.VAppNetwork, error) {
+	ret := m.methodCalled("GetNetworks", vappID)
+	return value[[]iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetNetworksContext(ctx context.Context, vappID string) ([]iland.VAppNetwork, error) {
+	ret := m.methodCalled("GetNetworksContext", ctx, vappID)
+	return value[[]iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) ListNetworks(ctx context.Context, vappID string, opts iland.ListOptions) iter.Seq2[iland.VAppNetwork, error] {
+	ret := m.methodCalled("ListNetworks", ctx, vappID, opts)
+	return seqValue[iland.VAppNetwork](ret, 0)
+}
+
+func (m *VAppService) AddOrgNetwork(vappID string, orgVdcNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("AddOrgNetwork", vappID, orgVdcNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) AddOrgNetworkContext(ctx context.Context, vappID string, orgVdcNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("AddOrgNetworkContext", ctx, vappID, orgVdcNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateName(vappID string, name string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateName", vappID, name)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateNameContext(ctx context.Context, vappID string, name string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNameContext", ctx, vappID, name)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateDescription(vappID string, description string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDescription", vappID, description)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateDescriptionContext(ctx context.Context, vappID string, description string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDescriptionContext", ctx, vappID, description)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Copy(vappID string, params iland.CopyVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("Copy", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) CopyContext(ctx context.Context, vappID string, params iland.CopyVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("CopyContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Move(vappID string, params iland.MoveVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("Move", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) MoveContext(ctx context.Context, vappID string, params iland.MoveVAppParams) (iland.Task, error) {
+	ret := m.methodCalled("MoveContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) BuildVirtualMachines(vappID string, params []iland.BuildVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("BuildVirtualMachines", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) BuildVirtualMachinesContext(ctx context.Context, vappID string, params []iland.BuildVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("BuildVirtualMachinesContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) AddTemplateVirtualMachines(vappID string, params []iland.AddTemplateVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("AddTemplateVirtualMachines", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) AddTemplateVirtualMachinesContext(ctx context.Context, vappID string, params []iland.AddTemplateVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("AddTemplateVirtualMachinesContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) CreateNetwork(vappID string, params iland.CreateVAppNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("CreateNetwork", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) CreateNetworkContext(ctx context.Context, vappID string, params iland.CreateVAppNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("CreateNetworkContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) PowerOn(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOn", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) PowerOnContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOnContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) PowerOff(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOff", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) PowerOffContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOffContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Shutdown(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("Shutdown", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) ShutdownContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("ShutdownContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Reboot(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("Reboot", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) RebootContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("RebootContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Reset(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("Reset", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) ResetContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("ResetContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) Suspend(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("Suspend", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) SuspendContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("SuspendContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetCurrentBill(vappID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBill", vappID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetCurrentBillContext(ctx context.Context, vappID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBillContext", ctx, vappID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetBill(vappID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBill", vappID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetBillContext(ctx context.Context, vappID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBillContext", ctx, vappID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetAvailableStorageProfiles(vappID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetAvailableStorageProfiles", vappID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetAvailableStorageProfilesContext(ctx context.Context, vappID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetAvailableStorageProfilesContext", ctx, vappID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetMetadata(vappID string) ([]iland.Metadata, error) {
+	ret := m.methodCalled("GetMetadata", vappID)
+	return value[[]iland.Metadata](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetMetadataContext(ctx context.Context, vappID string) ([]iland.Metadata, error) {
+	ret := m.methodCalled("GetMetadataContext", ctx, vappID)
+	return value[[]iland.Metadata](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateMetadata(vappID string, metadata []iland.Metadata) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMetadata", vappID, metadata)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateMetadataContext(ctx context.Context, vappID string, metadata []iland.Metadata) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMetadataContext", ctx, vappID, metadata)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) DeleteMetadata(vappID string, metadataKey string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteMetadata", vappID, metadataKey)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) DeleteMetadataContext(ctx context.Context, vappID string, metadataKey string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteMetadataContext", ctx, vappID, metadataKey)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) HasSnapshot(vappID string) (bool, error) {
+	ret := m.methodCalled("HasSnapshot", vappID)
+	return value[bool](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) HasSnapshotContext(ctx context.Context, vappID string) (bool, error) {
+	ret := m.methodCalled("HasSnapshotContext", ctx, vappID)
+	return value[bool](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetSnapshot(vappID string) (iland.Snapshot, error) {
+	ret := m.methodCalled("GetSnapshot", vappID)
+	return value[iland.Snapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetSnapshotContext(ctx context.Context, vappID string) (iland.Snapshot, error) {
+	ret := m.methodCalled("GetSnapshotContext", ctx, vappID)
+	return value[iland.Snapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) CreateSnapshot(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("CreateSnapshot", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) CreateSnapshotContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("CreateSnapshotContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) RestoreSnapshot(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("RestoreSnapshot", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) RestoreSnapshotContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("RestoreSnapshotContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) RemoveSnapshot(vappID string) (iland.Task, error) {
+	ret := m.methodCalled("RemoveSnapshot", vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) RemoveSnapshotContext(ctx context.Context, vappID string) (iland.Task, error) {
+	ret := m.methodCalled("RemoveSnapshotContext", ctx, vappID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetStartupSettings(vappID string) ([]iland.VAppStartupSetting, error) {
+	ret := m.methodCalled("GetStartupSettings", vappID)
+	return value[[]iland.VAppStartupSetting](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetStartupSettingsContext(ctx context.Context, vappID string) ([]iland.VAppStartupSetting, error) {
+	ret := m.methodCalled("GetStartupSettingsContext", ctx, vappID)
+	return value[[]iland.VAppStartupSetting](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateStartupSettings(vappID string, params []iland.VAppStartupSetting) (iland.Task, error) {
+	ret := m.methodCalled("UpdateStartupSettings", vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) UpdateStartupSettingsContext(ctx context.Context, vappID string, params []iland.VAppStartupSetting) (iland.Task, error) {
+	ret := m.methodCalled("UpdateStartupSettingsContext", ctx, vappID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetPerformanceCounters(vappID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCounters", vappID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetPerformanceCountersContext(ctx context.Context, vappID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCountersContext", ctx, vappID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetPerformance(vappID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformance", vappID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetPerformanceContext(ctx context.Context, vappID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformanceContext", ctx, vappID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetSummary(vappID string) (iland.VAppSummary, error) {
+	ret := m.methodCalled("GetSummary", vappID)
+	return value[iland.VAppSummary](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppService) GetSummaryContext(ctx context.Context, vappID string) (iland.VAppSummary, error) {
+	ret := m.methodCalled("GetSummaryContext", ctx, vappID)
+	return value[iland.VAppSummary](ret, 0), value[error](ret, 1)
+}
+
+// VAppNetworkService is a mock of iland.VAppNetworkService.
+type VAppNetworkService struct {
+	Mock
+}
+
+// NewVAppNetworkService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVAppNetworkService(t TestingT) *VAppNetworkService {
+	m := &VAppNetworkService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VAppNetworkService) Get(vappNetworkID string) (iland.VAppNetwork, error) {
+	ret := m.methodCalled("Get", vappNetworkID)
+	return value[iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetContext(ctx context.Context, vappNetworkID string) (iland.VAppNetwork, error) {
+	ret := m.methodCalled("GetContext", ctx, vappNetworkID)
+	return value[iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) Update(vappNetworkID string, params iland.UpdateVAppNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("Update", vappNetworkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateContext(ctx context.Context, vappNetworkID string, params iland.UpdateVAppNetworkParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateContext", ctx, vappNetworkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) Delete(vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("Delete", vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) DeleteContext(ctx context.Context, vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteContext", ctx, vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateDHCP(vappNetworkID string, params iland.DHCP) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDHCP", vappNetworkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateDHCPContext(ctx context.Context, vappNetworkID string, params iland.DHCP) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDHCPContext", ctx, vappNetworkID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetFirewall(vappNetworkID string) (iland.VAppNetworkFirewall, error) {
+	ret := m.methodCalled("GetFirewall", vappNetworkID)
+	return value[iland.VAppNetworkFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetFirewallContext(ctx context.Context, vappNetworkID string) (iland.VAppNetworkFirewall, error) {
+	ret := m.methodCalled("GetFirewallContext", ctx, vappNetworkID)
+	return value[iland.VAppNetworkFirewall](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateFirewallRules(vappNetworkID string, rules []iland.VAppNetworkFirewallRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateFirewallRules", vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateFirewallRulesContext(ctx context.Context, vappNetworkID string, rules []iland.VAppNetworkFirewallRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateFirewallRulesContext", ctx, vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) EnableFirewall(vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableFirewall", vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) EnableFirewallContext(ctx context.Context, vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableFirewallContext", ctx, vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) DisableFirewall(vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableFirewall", vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) DisableFirewallContext(ctx context.Context, vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableFirewallContext", ctx, vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetNAT(vappNetworkID string) (iland.VAppNetworkNAT, error) {
+	ret := m.methodCalled("GetNAT", vappNetworkID)
+	return value[iland.VAppNetworkNAT](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetNATContext(ctx context.Context, vappNetworkID string) (iland.VAppNetworkNAT, error) {
+	ret := m.methodCalled("GetNATContext", ctx, vappNetworkID)
+	return value[iland.VAppNetworkNAT](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateNATIPTranslationRules(vappNetworkID string, rules []iland.IPTranslationRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNATIPTranslationRules", vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateNATIPTranslationRulesContext(ctx context.Context, vappNetworkID string, rules []iland.IPTranslationRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNATIPTranslationRulesContext", ctx, vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateNATPortForwardingRules(vappNetworkID string, rules []iland.PortForwardingRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNATPortForwardingRules", vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) UpdateNATPortForwardingRulesContext(ctx context.Context, vappNetworkID string, rules []iland.PortForwardingRule) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNATPortForwardingRulesContext", ctx, vappNetworkID, rules)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) EnableNAT(vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableNAT", vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) EnableNATContext(ctx context.Context, vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableNATContext", ctx, vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) DisableNAT(vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableNAT", vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) DisableNATContext(ctx context.Context, vappNetworkID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableNATContext", ctx, vappNetworkID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetInterfaces(vappNetwork string) ([]iland.VirtualMachineInterface, error) {
+	ret := m.methodCalled("GetInterfaces", vappNetwork)
+	return value[[]iland.VirtualMachineInterface](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) GetInterfacesContext(ctx context.Context, vappNetwork string) ([]iland.VirtualMachineInterface, error) {
+	ret := m.methodCalled("GetInterfacesContext", ctx, vappNetwork)
+	return value[[]iland.VirtualMachineInterface](ret, 0), value[error](ret, 1)
+}
+
+func (m *VAppNetworkService) ListInterfaces(ctx context.Context, vappNetwork string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachineInterface, error] {
+	ret := m.methodCalled("ListInterfaces", ctx, vappNetwork, opts)
+	return seqValue[iland.VirtualMachineInterface](ret, 0)
+}
+
+// VirtualMachineService is a mock of iland.VirtualMachineService.
+type VirtualMachineService struct {
+	Mock
+}
+
+// NewVirtualMachineService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVirtualMachineService(t TestingT) *VirtualMachineService {
+	m := &VirtualMachineService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VirtualMachineService) Get(virtualMachineID string) (iland.VirtualMachine, error) {
+	ret := m.methodCalled("Get", virtualMachineID)
+	return value[iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetContext(ctx context.Context, virtualMachineID string) (iland.VirtualMachine, error) {
+	ret := m.methodCalled("GetContext", ctx, virtualMachineID)
+	return value[iland.VirtualMachine](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Delete(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("Delete", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateName(virtualMachineID string, name string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateName", virtualMachineID, name)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateNameContext(ctx context.Context, virtualMachineID string, name string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNameContext", ctx, virtualMachineID, name)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDescription(virtualMachineID string, description string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDescription", virtualMachineID, description)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDescriptionContext(ctx context.Context, virtualMachineID string, description string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDescriptionContext", ctx, virtualMachineID, description)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOn(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOn", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOnContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOnContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOnForceCustomization(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOnForceCustomization", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOnForceCustomizationContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOnForceCustomizationContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOff(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOff", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) PowerOffContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("PowerOffContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Reboot(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("Reboot", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RebootContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("RebootContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Reset(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("Reset", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ResetContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("ResetContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Shutdown(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("Shutdown", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ShutdownContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("ShutdownContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Suspend(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("Suspend", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) SuspendContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("SuspendContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Copy(virtualMachineID string, params iland.CopyVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("Copy", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) CopyContext(ctx context.Context, virtualMachineID string, params iland.CopyVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("CopyContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Move(virtualMachineID string, params iland.MoveVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("Move", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) MoveContext(ctx context.Context, virtualMachineID string, params iland.MoveVirtualMachineParams) (iland.Task, error) {
+	ret := m.methodCalled("MoveContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetSummary(virtualMachineID string) (iland.Summary, error) {
+	ret := m.methodCalled("GetSummary", virtualMachineID)
+	return value[iland.Summary](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetSummaryContext(ctx context.Context, virtualMachineID string) (iland.Summary, error) {
+	ret := m.methodCalled("GetSummaryContext", ctx, virtualMachineID)
+	return value[iland.Summary](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetAvailableStorageProfiles(virtualMachineID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetAvailableStorageProfiles", virtualMachineID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetAvailableStorageProfilesContext(ctx context.Context, virtualMachineID string) ([]iland.StorageProfile, error) {
+	ret := m.methodCalled("GetAvailableStorageProfilesContext", ctx, virtualMachineID)
+	return value[[]iland.StorageProfile](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ChangeStorageProfile(virtualMachineID string, storageProfileID string) (iland.Task, error) {
+	ret := m.methodCalled("ChangeStorageProfile", virtualMachineID, storageProfileID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ChangeStorageProfileContext(ctx context.Context, virtualMachineID string, storageProfileID string) (iland.Task, error) {
+	ret := m.methodCalled("ChangeStorageProfileContext", ctx, virtualMachineID, storageProfileID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) EnableNestedHypervisor(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableNestedHypervisor", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) EnableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("EnableNestedHypervisorContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DisableNestedHypervisor(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableNestedHypervisor", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DisableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("DisableNestedHypervisorContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) InsertMedia(virtualMachineID string, mediaID string) (iland.Task, error) {
+	ret := m.methodCalled("InsertMedia", virtualMachineID, mediaID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) InsertMediaContext(ctx context.Context, virtualMachineID string, mediaID string) (iland.Task, error) {
+	ret := m.methodCalled("InsertMediaContext", ctx, virtualMachineID, mediaID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) EjectMedia(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("EjectMedia", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) EjectMediaContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("EjectMediaContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetGuestCustomization(virtualMachineID string) (iland.GuestCustomization, error) {
+	ret := m.methodCalled("GetGuestCustomization", virtualMachineID)
+	return value[iland.GuestCustomization](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetGuestCustomizationContext(ctx context.Context, virtualMachineID string) (iland.GuestCustomization, error) {
+	ret := m.methodCalled("GetGuestCustomizationContext", ctx, virtualMachineID)
+	return value[iland.GuestCustomization](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateGuestCustomization(virtualMachineID string, params iland.GuestCustomization) (iland.Task, error) {
+	ret := m.methodCalled("UpdateGuestCustomization", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateGuestCustomizationContext(ctx context.Context, virtualMachineID string, params iland.GuestCustomization) (iland.Task, error) {
+	ret := m.methodCalled("UpdateGuestCustomizationContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetHotAdd(virtualMachineID string) (iland.HotAdd, error) {
+	ret := m.methodCalled("GetHotAdd", virtualMachineID)
+	return value[iland.HotAdd](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetHotAddContext(ctx context.Context, virtualMachineID string) (iland.HotAdd, error) {
+	ret := m.methodCalled("GetHotAddContext", ctx, virtualMachineID)
+	return value[iland.HotAdd](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateHotAdd(virtualMachineID string, params iland.HotAdd) (iland.Task, error) {
+	ret := m.methodCalled("UpdateHotAdd", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateHotAddContext(ctx context.Context, virtualMachineID string, params iland.HotAdd) (iland.Task, error) {
+	ret := m.methodCalled("UpdateHotAddContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBootOptions(virtualMachineID string) (iland.BootOptions, error) {
+	ret := m.methodCalled("GetBootOptions", virtualMachineID)
+	return value[iland.BootOptions](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBootOptionsContext(ctx context.Context, virtualMachineID string) (iland.BootOptions, error) {
+	ret := m.methodCalled("GetBootOptionsContext", ctx, virtualMachineID)
+	return value[iland.BootOptions](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateBootOptions(virtualMachineID string, params iland.BootOptions) (iland.Task, error) {
+	ret := m.methodCalled("UpdateBootOptions", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateBootOptionsContext(ctx context.Context, virtualMachineID string, params iland.BootOptions) (iland.Task, error) {
+	ret := m.methodCalled("UpdateBootOptionsContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateHardwareVersion(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateHardwareVersion", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateHardwareVersionContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("UpdateHardwareVersionContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetVMwareTools(virtualMachineID string) (iland.VMwareTools, error) {
+	ret := m.methodCalled("GetVMwareTools", virtualMachineID)
+	return value[iland.VMwareTools](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetVMwareToolsContext(ctx context.Context, virtualMachineID string) (iland.VMwareTools, error) {
+	ret := m.methodCalled("GetVMwareToolsContext", ctx, virtualMachineID)
+	return value[iland.VMwareTools](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpgradeVMwareTools(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("UpgradeVMwareTools", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpgradeVMwareToolsContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("UpgradeVMwareToolsContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) InstallVMwareTools(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("InstallVMwareTools", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) InstallVMwareToolsContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("InstallVMwareToolsContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) Reconfigure(virtualMachineID string, params iland.ReconfigureParams) (iland.Task, error) {
+	ret := m.methodCalled("Reconfigure", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ReconfigureContext(ctx context.Context, virtualMachineID string, params iland.ReconfigureParams) (iland.Task, error) {
+	ret := m.methodCalled("ReconfigureContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetDisks(virtualMachineID string) ([]iland.Disk, error) {
+	ret := m.methodCalled("GetDisks", virtualMachineID)
+	return value[[]iland.Disk](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetDisksContext(ctx context.Context, virtualMachineID string) ([]iland.Disk, error) {
+	ret := m.methodCalled("GetDisksContext", ctx, virtualMachineID)
+	return value[[]iland.Disk](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) AddDisk(virtualMachineID string, params iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("AddDisk", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) AddDiskContext(ctx context.Context, virtualMachineID string, params iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("AddDiskContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDisk(virtualMachineID string, params iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDisk", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDiskContext(ctx context.Context, virtualMachineID string, params iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDiskContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDisks(virtualMachineID string, params []iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDisks", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateDisksContext(ctx context.Context, virtualMachineID string, params []iland.DiskParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateDisksContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteDisk(virtualMachineID string, diskName string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteDisk", virtualMachineID, diskName)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteDiskContext(ctx context.Context, virtualMachineID string, diskName string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteDiskContext", ctx, virtualMachineID, diskName)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetRecommendedBusType(virtualMachineID string) (string, error) {
+	ret := m.methodCalled("GetRecommendedBusType", virtualMachineID)
+	return value[string](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetRecommendedBusTypeContext(ctx context.Context, virtualMachineID string) (string, error) {
+	ret := m.methodCalled("GetRecommendedBusTypeContext", ctx, virtualMachineID)
+	return value[string](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetNics(virtualMachineID string) ([]iland.Nic, error) {
+	ret := m.methodCalled("GetNics", virtualMachineID)
+	return value[[]iland.Nic](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetNicsContext(ctx context.Context, virtualMachineID string) ([]iland.Nic, error) {
+	ret := m.methodCalled("GetNicsContext", ctx, virtualMachineID)
+	return value[[]iland.Nic](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteNic(virtualMachineID string, nicID int) (iland.Task, error) {
+	ret := m.methodCalled("DeleteNic", virtualMachineID, nicID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteNicContext(ctx context.Context, virtualMachineID string, nicID int) (iland.Task, error) {
+	ret := m.methodCalled("DeleteNicContext", ctx, virtualMachineID, nicID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateNics(virtualMachineID string, params []iland.Nic) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNics", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateNicsContext(ctx context.Context, virtualMachineID string, params []iland.Nic) (iland.Task, error) {
+	ret := m.methodCalled("UpdateNicsContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateCPU(virtualMachineID string, params iland.UpdateCPUParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateCPU", virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateCPUContext(ctx context.Context, virtualMachineID string, params iland.UpdateCPUParams) (iland.Task, error) {
+	ret := m.methodCalled("UpdateCPUContext", ctx, virtualMachineID, params)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateCPUCount(virtualMachineID string, cpuCount int) (iland.Task, error) {
+	ret := m.methodCalled("UpdateCPUCount", virtualMachineID, cpuCount)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateCPUCountContext(ctx context.Context, virtualMachineID string, cpuCount int) (iland.Task, error) {
+	ret := m.methodCalled("UpdateCPUCountContext", ctx, virtualMachineID, cpuCount)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateMemory(virtualMachineID string, memorySize int) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMemory", virtualMachineID, memorySize)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateMemoryContext(ctx context.Context, virtualMachineID string, memorySize int) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMemoryContext", ctx, virtualMachineID, memorySize)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBackups(virtualMachineID string) ([]iland.VirtualMachineBackup, error) {
+	ret := m.methodCalled("GetBackups", virtualMachineID)
+	return value[[]iland.VirtualMachineBackup](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBackupsContext(ctx context.Context, virtualMachineID string) ([]iland.VirtualMachineBackup, error) {
+	ret := m.methodCalled("GetBackupsContext", ctx, virtualMachineID)
+	return value[[]iland.VirtualMachineBackup](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ListBackups(ctx context.Context, virtualMachineID string, opts iland.ListOptions) iter.Seq2[iland.VirtualMachineBackup, error] {
+	ret := m.methodCalled("ListBackups", ctx, virtualMachineID, opts)
+	return seqValue[iland.VirtualMachineBackup](ret, 0)
+}
+
+func (m *VirtualMachineService) RestoreBackup(virtualMachineID string, backupTimestamp int) (iland.Task, error) {
+	ret := m.methodCalled("RestoreBackup", virtualMachineID, backupTimestamp)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RestoreBackupContext(ctx context.Context, virtualMachineID string, backupTimestamp int) (iland.Task, error) {
+	ret := m.methodCalled("RestoreBackupContext", ctx, virtualMachineID, backupTimestamp)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RestoreBackupToVApp(virtualMachineID string, vappID string, backupTimestamp int) (iland.Task, error) {
+	ret := m.methodCalled("RestoreBackupToVApp", virtualMachineID, vappID, backupTimestamp)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RestoreBackupToVAppContext(ctx context.Context, virtualMachineID string, vappID string, backupTimestamp int) (iland.Task, error) {
+	ret := m.methodCalled("RestoreBackupToVAppContext", ctx, virtualMachineID, vappID, backupTimestamp)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) HasSnapshot(virtualMachineID string) (bool, error) {
+	ret := m.methodCalled("HasSnapshot", virtualMachineID)
+	return value[bool](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) HasSnapshotContext(ctx context.Context, virtualMachineID string) (bool, error) {
+	ret := m.methodCalled("HasSnapshotContext", ctx, virtualMachineID)
+	return value[bool](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetSnapshot(virtualMachineID string) (iland.Snapshot, error) {
+	ret := m.methodCalled("GetSnapshot", virtualMachineID)
+	return value[iland.Snapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetSnapshotContext(ctx context.Context, virtualMachineID string) (iland.Snapshot, error) {
+	ret := m.methodCalled("GetSnapshotContext", ctx, virtualMachineID)
+	return value[iland.Snapshot](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) CreateSnapshot(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("CreateSnapshot", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) CreateSnapshotContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("CreateSnapshotContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RestoreSnapshot(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("RestoreSnapshot", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RestoreSnapshotContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("RestoreSnapshotContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RemoveSnapshot(virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("RemoveSnapshot", virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) RemoveSnapshotContext(ctx context.Context, virtualMachineID string) (iland.Task, error) {
+	ret := m.methodCalled("RemoveSnapshotContext", ctx, virtualMachineID)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetNetworks(virtualMachineID string) ([]iland.VAppNetwork, error) {
+	ret := m.methodCalled("GetNetworks", virtualMachineID)
+	return value[[]iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetNetworksContext(ctx context.Context, virtualMachineID string) ([]iland.VAppNetwork, error) {
+	ret := m.methodCalled("GetNetworksContext", ctx, virtualMachineID)
+	return value[[]iland.VAppNetwork](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) ListNetworks(ctx context.Context, virtualMachineID string, opts iland.ListOptions) iter.Seq2[iland.VAppNetwork, error] {
+	ret := m.methodCalled("ListNetworks", ctx, virtualMachineID, opts)
+	return seqValue[iland.VAppNetwork](ret, 0)
+}
+
+func (m *VirtualMachineService) GetCurrentBill(virtualMachineID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBill", virtualMachineID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetCurrentBillContext(ctx context.Context, virtualMachineID string) (iland.Billing, error) {
+	ret := m.methodCalled("GetCurrentBillContext", ctx, virtualMachineID)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBill(virtualMachineID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBill", virtualMachineID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetBillContext(ctx context.Context, virtualMachineID string, month int, year int) (iland.Billing, error) {
+	ret := m.methodCalled("GetBillContext", ctx, virtualMachineID, month, year)
+	return value[iland.Billing](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetMetadata(virtualMachineID string) ([]iland.Metadata, error) {
+	ret := m.methodCalled("GetMetadata", virtualMachineID)
+	return value[[]iland.Metadata](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetMetadataContext(ctx context.Context, virtualMachineID string) ([]iland.Metadata, error) {
+	ret := m.methodCalled("GetMetadataContext", ctx, virtualMachineID)
+	return value[[]iland.Metadata](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateMetadata(virtualMachineID string, metadata []iland.Metadata) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMetadata", virtualMachineID, metadata)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) UpdateMetadataContext(ctx context.Context, virtualMachineID string, metadata []iland.Metadata) (iland.Task, error) {
+	ret := m.methodCalled("UpdateMetadataContext", ctx, virtualMachineID, metadata)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteMetadata(virtualMachineID string, metadataKey string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteMetadata", virtualMachineID, metadataKey)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) DeleteMetadataContext(ctx context.Context, virtualMachineID string, metadataKey string) (iland.Task, error) {
+	ret := m.methodCalled("DeleteMetadataContext", ctx, virtualMachineID, metadataKey)
+	return value[iland.Task](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetPerformanceCounters(virtualMachineID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCounters", virtualMachineID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetPerformanceCountersContext(ctx context.Context, virtualMachineID string) ([]iland.PerformanceCounter, error) {
+	ret := m.methodCalled("GetPerformanceCountersContext", ctx, virtualMachineID)
+	return value[[]iland.PerformanceCounter](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetPerformance(virtualMachineID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformance", virtualMachineID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetPerformanceContext(ctx context.Context, virtualMachineID string, counter iland.PerformanceCounter, start time.Time, end time.Time) (iland.Performance, error) {
+	ret := m.methodCalled("GetPerformanceContext", ctx, virtualMachineID, counter, start, end)
+	return value[iland.Performance](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetConsoleSession(virtualMachineID string) (iland.ConsoleSession, error) {
+	ret := m.methodCalled("GetConsoleSession", virtualMachineID)
+	return value[iland.ConsoleSession](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetConsoleSessionContext(ctx context.Context, virtualMachineID string) (iland.ConsoleSession, error) {
+	ret := m.methodCalled("GetConsoleSessionContext", ctx, virtualMachineID)
+	return value[iland.ConsoleSession](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetScreenThumbnail(virtualMachineID string) ([]byte, error) {
+	ret := m.methodCalled("GetScreenThumbnail", virtualMachineID)
+	return value[[]byte](ret, 0), value[error](ret, 1)
+}
+
+func (m *VirtualMachineService) GetScreenThumbnailContext(ctx context.Context, virtualMachineID string) ([]byte, error) {
+	ret := m.methodCalled("GetScreenThumbnailContext", ctx, virtualMachineID)
+	return value[[]byte](ret, 0), value[error](ret, 1)
+}
+
+// VpgService is a mock of iland.VpgService.
+type VpgService struct {
+	Mock
+}
+
+// NewVpgService returns a mock that fails t on unexpected calls and checks its
+// expectations when the test ends.
+func NewVpgService(t TestingT) *VpgService {
+	m := &VpgService{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *VpgService) Get(vpgID string) (iland.Vpg, error) {
+	ret := m.methodCalled("Get", vpgID)
+	return value[iland.Vpg](ret, 0), value[error](ret, 1)
+}
+
+func (m *VpgService) GetContext(ctx context.Context, vpgID string) (iland.Vpg, error) {
+	ret := m.methodCalled("GetContext", ctx, vpgID)
+	return value[iland.Vpg](ret, 0), value[error](ret, 1)
+}
+
+func (m *VpgService) GetCheckpoints(vpgID string) ([]iland.VpgCheckpoint, error) {
+	ret := m.methodCalled("GetCheckpoints", vpgID)
+	return value[[]iland.VpgCheckpoint](ret, 0), value[error](ret, 1)
+}
+
+func (m *VpgService) GetCheckpointsContext(ctx context.Context, vpgID string) ([]iland.VpgCheckpoint, error) {
+	ret := m.methodCalled("GetCheckpointsContext", ctx, vpgID)
+	return value[[]iland.VpgCheckpoint](ret, 0), value[error](ret, 1)
+}
+
+func (m *VpgService) ListCheckpoints(ctx context.Context, vpgID string, opts iland.ListOptions) iter.Seq2[iland.VpgCheckpoint, error] {
+	ret := m.methodCalled("ListCheckpoints", ctx, vpgID, opts)
+	return seqValue[iland.VpgCheckpoint](ret, 0)
+}
+
+var (
+	_ iland.ConsoleService         = (*ConsoleService)(nil)
+	_ iland.LocationService        = (*LocationService)(nil)
+	_ iland.TaskService            = (*TaskService)(nil)
+	_ iland.EventService           = (*EventService)(nil)
+	_ iland.VCCBackupTenantService = (*VCCBackupTenantService)(nil)
+	_ iland.VacTenantService       = (*VacTenantService)(nil)
+	_ iland.O365Service            = (*O365Service)(nil)
+	_ iland.CompanyService         = (*CompanyService)(nil)
+	_ iland.UserService            = (*UserService)(nil)
+	_ iland.OrgService             = (*OrgService)(nil)
+	_ iland.CatalogService         = (*CatalogService)(nil)
+	_ iland.VAppTemplateService    = (*VAppTemplateService)(nil)
+	_ iland.VdcService             = (*VdcService)(nil)
+	_ iland.EdgeService            = (*EdgeService)(nil)
+	_ iland.OrgVdcNetworkService   = (*OrgVdcNetworkService)(nil)
+	_ iland.VAppService            = (*VAppService)(nil)
+	_ iland.VAppNetworkService     = (*VAppNetworkService)(nil)
+	_ iland.VirtualMachineService  = (*VirtualMachineService)(nil)
+	_ iland.VpgService             = (*VpgService)(nil)
+)
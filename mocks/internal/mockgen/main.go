@@ -0,0 +1,278 @@
+// Command mockgen generates the mocks package from interfaces.go. It is run
+// by go generate in the mocks directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const ilandImport = "github.com/ilanddev/go-sdk"
+
+func main() {
+	in := flag.String("in", "../interfaces.go", "interfaces file to read")
+	out := flag.String("out", "generated.go", "file to write")
+	flag.Parse()
+	src, err := generate(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.WriteFile(*out, src, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+type iface struct {
+	name    string
+	methods []method
+}
+
+type generator struct {
+	fset    *token.FileSet
+	imports map[string]string
+	used    map[string]bool
+}
+
+func generate(path string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{fset: fset, imports: map[string]string{}, used: map[string]bool{"iland": true}}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		g.imports[name] = importPath
+	}
+	ifaces := []iface{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			ifaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			i, err := g.iface(typeSpec.Name.Name, ifaceType)
+			if err != nil {
+				return nil, err
+			}
+			ifaces = append(ifaces, i)
+		}
+	}
+	names := map[string]bool{}
+	for _, i := range ifaces {
+		names["iland."+i.name] = true
+	}
+
+	body := &bytes.Buffer{}
+	for _, i := range ifaces {
+		g.writeMock(body, i, names)
+	}
+	fmt.Fprintf(body, "var (\n")
+	for _, i := range ifaces {
+		fmt.Fprintf(body, "\t_ iland.%s = (*%s)(nil)\n", i.name, i.name)
+	}
+	fmt.Fprintf(body, ")\n")
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by mockgen from interfaces.go. DO NOT EDIT.\n\npackage mocks\n\nimport (\n")
+	imports := []string{}
+	for name := range g.used {
+		if name == "iland" {
+			continue
+		}
+		imports = append(imports, strconv.Quote(g.imports[name]))
+	}
+	sort.Strings(imports)
+	for _, importPath := range imports {
+		fmt.Fprintf(out, "\t%s\n", importPath)
+	}
+	fmt.Fprintf(out, "\n\tiland %q\n)\n\n", ilandImport)
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) iface(name string, t *ast.InterfaceType) (iface, error) {
+	i := iface{name: name}
+	for _, field := range t.Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return iface{}, fmt.Errorf("%s: embedded interfaces are not supported", name)
+		}
+		m := method{name: field.Names[0].Name}
+		for _, p := range fn.Params.List {
+			typ := g.typeString(p.Type)
+			if len(p.Names) == 0 {
+				m.params = append(m.params, param{fmt.Sprintf("arg%d", len(m.params)), typ})
+			}
+			for _, n := range p.Names {
+				if n.Name == "m" || n.Name == "ret" {
+					return iface{}, fmt.Errorf("%s.%s: parameter name %s is reserved", name, m.name, n.Name)
+				}
+				m.params = append(m.params, param{n.Name, typ})
+			}
+		}
+		if fn.Results != nil {
+			for _, r := range fn.Results.List {
+				typ := g.typeString(r.Type)
+				for n := 0; n < len(r.Names) || n == 0; n++ {
+					m.results = append(m.results, typ)
+				}
+			}
+		}
+		i.methods = append(i.methods, m)
+	}
+	return i, nil
+}
+
+func (g *generator) writeMock(w *bytes.Buffer, i iface, mocked map[string]bool) {
+	// Accessors returning another mocked interface, such as
+	// ConsoleService.VirtualMachine, fall back to a nested mock.
+	accessors := []method{}
+	for _, m := range i.methods {
+		if len(m.params) == 0 && len(m.results) == 1 && mocked[m.results[0]] {
+			accessors = append(accessors, m)
+		}
+	}
+
+	fmt.Fprintf(w, "// %s is a mock of iland.%s.\n", i.name, i.name)
+	fmt.Fprintf(w, "type %s struct {\n\tMock\n", i.name)
+	if len(accessors) > 0 {
+		fmt.Fprintf(w, "\n")
+		for _, m := range accessors {
+			typ := strings.TrimPrefix(m.results[0], "iland.")
+			fmt.Fprintf(w, "\t%s *%s\n", typ, typ)
+		}
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// New%s returns a mock that fails t on unexpected calls and checks its\n// expectations when the test ends.\n", i.name)
+	fmt.Fprintf(w, "func New%s(t TestingT) *%s {\n\tm := &%s{}\n", i.name, i.name, i.name)
+	for _, m := range accessors {
+		typ := strings.TrimPrefix(m.results[0], "iland.")
+		fmt.Fprintf(w, "\tm.%s = New%s(t)\n", typ, typ)
+	}
+	fmt.Fprintf(w, "\tm.Test(t)\n\tt.Cleanup(func() { m.AssertExpectations(t) })\n\treturn m\n}\n\n")
+
+	for _, m := range i.methods {
+		params := make([]string, len(m.params))
+		args := []string{strconv.Quote(m.name)}
+		for n, p := range m.params {
+			params[n] = p.name + " " + p.typ
+			args = append(args, p.name)
+		}
+		results := strings.Join(m.results, ", ")
+		if len(m.results) > 1 {
+			results = "(" + results + ")"
+		}
+		fmt.Fprintf(w, "func (m *%s) %s(%s) %s {\n", i.name, m.name, strings.Join(params, ", "), results)
+		if len(m.results) == 1 && mocked[m.results[0]] {
+			fmt.Fprintf(w, "\tif ret, ok := m.called(%s); ok {\n\t\treturn %s\n\t}\n", strings.Join(args, ", "), g.returnValue(m.results[0], 0))
+			fmt.Fprintf(w, "\treturn m.%s\n}\n\n", strings.TrimPrefix(m.results[0], "iland."))
+			continue
+		}
+		if len(m.results) == 0 {
+			fmt.Fprintf(w, "\tm.methodCalled(%s)\n}\n\n", strings.Join(args, ", "))
+			continue
+		}
+		fmt.Fprintf(w, "\tret := m.methodCalled(%s)\n", strings.Join(args, ", "))
+		values := make([]string, len(m.results))
+		for n, r := range m.results {
+			values[n] = g.returnValue(r, n)
+		}
+		fmt.Fprintf(w, "\treturn %s\n}\n\n", strings.Join(values, ", "))
+	}
+}
+
+func (g *generator) returnValue(typ string, n int) string {
+	if strings.HasPrefix(typ, "iter.Seq2[") && strings.HasSuffix(typ, ", error]") {
+		return fmt.Sprintf("seqValue[%s](ret, %d)", strings.TrimSuffix(strings.TrimPrefix(typ, "iter.Seq2["), ", error]"), n)
+	}
+	return fmt.Sprintf("value[%s](ret, %d)", typ, n)
+}
+
+// typeString prints a type expression from interfaces.go as seen from the
+// mocks package, qualifying the SDK's own types with iland.
+func (g *generator) typeString(expr ast.Expr) string {
+	buf := &bytes.Buffer{}
+	printer.Fprint(buf, g.fset, g.qualify(expr))
+	return buf.String()
+}
+
+func (g *generator) qualify(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(e.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent("iland"), Sel: ast.NewIdent(e.Name)}
+		}
+		return e
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok {
+			g.used[pkg.Name] = true
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualify(e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: g.qualify(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualify(e.Key), Value: g.qualify(e.Value)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: g.qualify(e.Value)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualify(e.Elt)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: g.qualify(e.X), Index: g.qualify(e.Index)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, len(e.Indices))
+		for i, index := range e.Indices {
+			indices[i] = g.qualify(index)
+		}
+		return &ast.IndexListExpr{X: g.qualify(e.X), Indices: indices}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: g.qualifyFields(e.Params), Results: g.qualifyFields(e.Results)}
+	}
+	return expr
+}
+
+func (g *generator) qualifyFields(fields *ast.FieldList) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	out := &ast.FieldList{}
+	for _, f := range fields.List {
+		out.List = append(out.List, &ast.Field{Names: f.Names, Type: g.qualify(f.Type)})
+	}
+	return out
+}
@@ -0,0 +1,338 @@
+// Package mocks provides programmable mocks of ConsoleService and every
+// sub-service interface, generated from interfaces.go.
+//
+// Expectations are set with On and Return; calls that match no expectation
+// return zero values (and fail the test when the mock was created with a
+// TestingT). Arguments are compared with reflect.DeepEqual unless a Matcher
+// is given.
+//
+//	console := mocks.NewConsoleService(t)
+//	console.VirtualMachineService.
+//		On("PowerOnContext", mocks.AnyContext, "vm-1").
+//		Return(iland.Task{ID: "task-1"}, nil).
+//		Once()
+package mocks
+
+//go:generate go run ./internal/mockgen -in ../interfaces.go -out generated.go
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TestingT is the subset of *testing.T used by mocks.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Arguments are the arguments or return values of a call.
+type Arguments []interface{}
+
+// Get returns the i-th value, or nil if there is none.
+func (a Arguments) Get(i int) interface{} {
+	if i < 0 || i >= len(a) {
+		return nil
+	}
+	return a[i]
+}
+
+// Invocation is a recorded call to a mock.
+type Invocation struct {
+	Method string
+	Args   Arguments
+}
+
+// Mock records calls and answers them from expectations. The zero value is
+// ready to use.
+type Mock struct {
+	mu           sync.Mutex
+	t            TestingT
+	expectations []*Call
+	calls        []Invocation
+}
+
+// Call is an expectation set with On.
+type Call struct {
+	mock    *Mock
+	method  string
+	args    Arguments
+	returns Arguments
+	run     func(args Arguments)
+	times   int
+	calls   int
+}
+
+// Test makes calls that match no expectation fail t.
+func (m *Mock) Test(t TestingT) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.t = t
+}
+
+// On adds an expectation for method called with args. Each arg is either a
+// Matcher or a value compared with reflect.DeepEqual. With no args, any
+// arguments match.
+func (m *Mock) On(method string, args ...interface{}) *Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := &Call{mock: m, method: method, args: args}
+	m.expectations = append(m.expectations, c)
+	return c
+}
+
+// Return sets the values returned by the call, in the method's result
+// order. Missing or nil values are returned as zero values.
+func (c *Call) Return(values ...interface{}) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.returns = values
+	return c
+}
+
+// Run sets a function called with the arguments of each matching call,
+// before it returns.
+func (c *Call) Run(fn func(args Arguments)) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.run = fn
+	return c
+}
+
+// Times limits the expectation to n calls, after which it no longer
+// matches.
+func (c *Call) Times(n int) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.times = n
+	return c
+}
+
+// Once is Times(1).
+func (c *Call) Once() *Call {
+	return c.Times(1)
+}
+
+// Calls returns every call made to the mock, in order.
+func (m *Mock) Calls() []Invocation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Invocation(nil), m.calls...)
+}
+
+// AssertExpectations checks that every expectation was met: those limited
+// with Times were called exactly that many times, the rest at least once.
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ok := true
+	for _, c := range m.expectations {
+		if c.times > 0 && c.calls != c.times {
+			t.Errorf("mocks: expected %s to be called %d times, got %d", c, c.times, c.calls)
+			ok = false
+		} else if c.times == 0 && c.calls == 0 {
+			t.Errorf("mocks: expected %s to be called", c)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCalled checks that method was called with matching args.
+func (m *Mock) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	if m.countCalls(method, args) == 0 {
+		t.Errorf("mocks: expected call %s", formatCall(method, args))
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled checks that method was not called with matching args.
+func (m *Mock) AssertNotCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	if n := m.countCalls(method, args); n > 0 {
+		t.Errorf("mocks: unexpected call %s (%d times)", formatCall(method, args), n)
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls checks that method was called n times, with any
+// arguments.
+func (m *Mock) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if got := m.countCalls(method, nil); got != n {
+		t.Errorf("mocks: expected %s to be called %d times, got %d", method, n, got)
+		return false
+	}
+	return true
+}
+
+func (m *Mock) countCalls(method string, args Arguments) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, call := range m.calls {
+		if call.Method == method && argsMatch(args, call.Args) {
+			n++
+		}
+	}
+	return n
+}
+
+// called records a call and returns the values of the first expectation it
+// matches. ok is false if no expectation matched.
+func (m *Mock) called(method string, args ...interface{}) (ret Arguments, ok bool) {
+	m.mu.Lock()
+	m.calls = append(m.calls, Invocation{Method: method, Args: args})
+	var match *Call
+	for _, c := range m.expectations {
+		if c.method != method || (c.times > 0 && c.calls >= c.times) {
+			continue
+		}
+		if argsMatch(c.args, args) {
+			match = c
+			break
+		}
+	}
+	if match == nil {
+		m.mu.Unlock()
+		return nil, false
+	}
+	match.calls++
+	run, returns := match.run, match.returns
+	m.mu.Unlock()
+	if run != nil {
+		run(args)
+	}
+	return returns, true
+}
+
+// methodCalled is called for an unexpected call to fail the test.
+func (m *Mock) methodCalled(method string, args ...interface{}) Arguments {
+	ret, ok := m.called(method, args...)
+	if !ok {
+		m.mu.Lock()
+		t := m.t
+		m.mu.Unlock()
+		if t != nil {
+			t.Helper()
+			t.Errorf("mocks: unexpected call %s", formatCall(method, args))
+		}
+	}
+	return ret
+}
+
+func (c *Call) String() string {
+	return formatCall(c.method, c.args)
+}
+
+func formatCall(method string, args Arguments) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%#v", arg)
+	}
+	return fmt.Sprintf("%s(%s)", method, strings.Join(parts, ", "))
+}
+
+func argsMatch(expected, actual Arguments) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	if len(expected) != len(actual) {
+		return false
+	}
+	for i, want := range expected {
+		if matcher, ok := want.(Matcher); ok {
+			if !matcher.Matches(actual[i]) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(want, actual[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matcher matches an argument.
+type Matcher interface {
+	Matches(arg interface{}) bool
+}
+
+type matcherFunc func(arg interface{}) bool
+
+func (f matcherFunc) Matches(arg interface{}) bool {
+	return f(arg)
+}
+
+// Anything matches any argument.
+var Anything Matcher = matcherFunc(func(interface{}) bool { return true })
+
+// AnyContext matches any context.Context.
+var AnyContext = MatchedBy(func(context.Context) bool { return true })
+
+// AnythingOfType matches arguments whose type, as printed by %T, is
+// typeName, e.g. "iland.ListOptions".
+func AnythingOfType(typeName string) Matcher {
+	return matcherFunc(func(arg interface{}) bool {
+		return fmt.Sprintf("%T", arg) == typeName
+	})
+}
+
+// MatchedBy matches arguments of type T for which fn returns true.
+func MatchedBy[T any](fn func(T) bool) Matcher {
+	return matcherFunc(func(arg interface{}) bool {
+		value, ok := arg.(T)
+		return ok && fn(value)
+	})
+}
+
+// Seq returns an iterator yielding items followed by err, if it is not nil,
+// for use as the return value of List methods.
+func Seq[T any](items []T, err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// value returns the i-th return value as a T, or T's zero value if it is
+// missing or nil.
+func value[T any](ret Arguments, i int) T {
+	var zero T
+	v := ret.Get(i)
+	if v == nil {
+		return zero
+	}
+	typed, ok := v.(T)
+	if !ok {
+		panic(fmt.Sprintf("mocks: return value %d is %T, want %s", i, v, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	return typed
+}
+
+// seqValue is value for iterators, returning an empty iterator instead of
+// nil.
+func seqValue[T any](ret Arguments, i int) iter.Seq2[T, error] {
+	seq := value[iter.Seq2[T, error]](ret, i)
+	if seq == nil {
+		return Seq[T](nil, nil)
+	}
+	return seq
+}
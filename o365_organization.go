@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"iter"
 	"net/http"
 )
 
@@ -38,8 +40,13 @@ type O365Organization struct {
 }
 
 func (s *o365Service) GetOrganization(id string) (O365Organization, error) {
+	return s.GetOrganizationContext(context.Background(), id)
+}
+
+func (s *o365Service) GetOrganizationContext(ctx context.Context, id string) (O365Organization, error) {
+	ctx = withOperation(ctx, "O365.GetOrganization", id)
 	org := O365Organization{}
-	err := s.client.getObject(fmt.Sprintf("/v1/o365-organizations/%s", id), &org)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/o365-organizations/%s", id), &org)
 	if err != nil {
 		return O365Organization{}, err
 	}
@@ -58,30 +65,30 @@ type O365User struct {
 }
 
 func (s *o365Service) GetUsers(id string) ([]O365User, error) {
-	schema := struct {
-		Data     []O365User `json:"data"`
-		Page     int        `json:"page"`
-		PageSize int        `json:"page_size"`
-	}{}
-	limit := 100
-	page := 0
-	users := []O365User{}
-	for {
-		err := s.client.getObject(fmt.Sprintf("/v1/o365-organizations/%s/users?pageSize=%d&page=%d", id, limit, page), &schema)
-		if err != nil {
-			return []O365User{}, err
-		}
-		users = append(users, schema.Data...)
-		if len(schema.Data) < limit {
-			break
-		}
-		page++
+	return s.GetUsersContext(context.Background(), id)
+}
+
+func (s *o365Service) GetUsersContext(ctx context.Context, id string) ([]O365User, error) {
+	ctx = withOperation(ctx, "O365.GetUsers", id)
+	users, err := Collect(s.ListUsers(ctx, id, ListOptions{}))
+	if err != nil {
+		return []O365User{}, err
 	}
 	return users, nil
 }
 
+func (s *o365Service) ListUsers(ctx context.Context, id string, opts ListOptions) iter.Seq2[O365User, error] {
+	ctx = withOperation(ctx, "O365.ListUsers", id)
+	return paginate[O365User](ctx, s.client, pageQuery(fmt.Sprintf("/v1/o365-organizations/%s/users", id)), opts)
+}
+
 func (s *o365Service) GetUserReport(id string) ([]byte, error) {
-	resp, err := s.client.request(fmt.Sprintf("/v1/o365-organizations/%s/users-export", id), http.MethodPost, "application/vnd.ilandcloud.api.v1.0+octet-stream", []byte{})
+	return s.GetUserReportContext(context.Background(), id)
+}
+
+func (s *o365Service) GetUserReportContext(ctx context.Context, id string) ([]byte, error) {
+	ctx = withOperation(ctx, "O365.GetUserReport", id)
+	resp, err := s.client.request(ctx, fmt.Sprintf("/v1/o365-organizations/%s/users-export", id), http.MethodPost, s.client.mediaType("octet-stream"), []byte{})
 	if err != nil {
 		return []byte{}, err
 	}
@@ -0,0 +1,97 @@
+package iland
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Option configures a client created by NewClient or InitClient.
+type Option func(*client) error
+
+// WithBaseURL sets the base URL of the iland cloud REST API, for example
+// "https://api.ilandcloud.com".
+func WithBaseURL(rawURL string) Option {
+	return func(c *client) error {
+		u, err := parseEndpoint(rawURL, "http", "https")
+		if err != nil {
+			return err
+		}
+		c.baseURL = u
+		return nil
+	}
+}
+
+// WithAuthURL sets the OpenID Connect token endpoint used to obtain and
+// refresh access tokens.
+func WithAuthURL(rawURL string) Option {
+	return func(c *client) error {
+		u, err := parseEndpoint(rawURL, "http", "https")
+		if err != nil {
+			return err
+		}
+		c.authURL = u
+		return nil
+	}
+}
+
+// WithEventStreamURL sets the websocket URL used by StreamEvents.
+func WithEventStreamURL(rawURL string) Option {
+	return func(c *client) error {
+		u, err := parseEndpoint(rawURL, "ws", "wss")
+		if err != nil {
+			return err
+		}
+		c.eventStreamURL = u
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for API and token requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) error {
+		if httpClient == nil {
+			return errors.New("iland: http client must not be nil")
+		}
+		c.http = httpClient
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithAPIVersion sets the version used in the Accept media type, for
+// example "1.0" for application/vnd.ilandcloud.api.v1.0+json.
+func WithAPIVersion(version string) Option {
+	return func(c *client) error {
+		if version == "" {
+			return errors.New("iland: api version must not be empty")
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+func parseEndpoint(rawURL string, schemes ...string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("iland: invalid url %q: %s", rawURL, err.Error())
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("iland: url %q has no host", rawURL)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return strings.TrimSuffix(u.String(), "/"), nil
+		}
+	}
+	return "", fmt.Errorf("iland: url %q must use one of the schemes %s", rawURL, strings.Join(schemes, ", "))
+}
@@ -1,7 +1,9 @@
 package iland
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 type Org struct {
@@ -25,8 +27,13 @@ type orgService struct {
 }
 
 func (s *orgService) Get(orgID string) (Org, error) {
+	return s.GetContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetContext(ctx context.Context, orgID string) (Org, error) {
+	ctx = withOperation(ctx, "Org.Get", orgID)
 	org := Org{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s", orgID), &org)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s", orgID), &org)
 	if err != nil {
 		return Org{}, err
 	}
@@ -34,109 +41,204 @@ func (s *orgService) Get(orgID string) (Org, error) {
 }
 
 func (s *orgService) GetVdcs(orgID string) ([]Vdc, error) {
+	return s.GetVdcsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVdcsContext(ctx context.Context, orgID string) ([]Vdc, error) {
+	ctx = withOperation(ctx, "Org.GetVdcs", orgID)
 	schema := struct {
 		Vdcs []Vdc `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vdcs", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vdcs", orgID), &schema)
 	if err != nil {
 		return []Vdc{}, err
 	}
 	return schema.Vdcs, nil
 }
 
+func (s *orgService) ListVdcs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Vdc, error] {
+	ctx = withOperation(ctx, "Org.ListVdcs", orgID)
+	return listAll[Vdc](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vdcs", orgID), opts)
+}
+
 func (s *orgService) GetEdges(orgID string) ([]Edge, error) {
+	return s.GetEdgesContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetEdgesContext(ctx context.Context, orgID string) ([]Edge, error) {
+	ctx = withOperation(ctx, "Org.GetEdges", orgID)
 	schema := struct {
 		Edges []Edge `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/edges", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/edges", orgID), &schema)
 	if err != nil {
 		return []Edge{}, err
 	}
 	return schema.Edges, nil
 }
 
+func (s *orgService) ListEdges(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Edge, error] {
+	ctx = withOperation(ctx, "Org.ListEdges", orgID)
+	return listAll[Edge](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/edges", orgID), opts)
+}
+
 func (s *orgService) GetCatalogs(orgID string) ([]Catalog, error) {
+	return s.GetCatalogsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetCatalogsContext(ctx context.Context, orgID string) ([]Catalog, error) {
+	ctx = withOperation(ctx, "Org.GetCatalogs", orgID)
 	schema := struct {
 		Catalogs []Catalog `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/catalogs", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/catalogs", orgID), &schema)
 	if err != nil {
 		return []Catalog{}, err
 	}
 	return schema.Catalogs, nil
 }
 
+func (s *orgService) ListCatalogs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Catalog, error] {
+	ctx = withOperation(ctx, "Org.ListCatalogs", orgID)
+	return listAll[Catalog](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/catalogs", orgID), opts)
+}
+
 func (s *orgService) GetVAppTemplates(orgID string) ([]VAppTemplate, error) {
+	return s.GetVAppTemplatesContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVAppTemplatesContext(ctx context.Context, orgID string) ([]VAppTemplate, error) {
+	ctx = withOperation(ctx, "Org.GetVAppTemplates", orgID)
 	schema := struct {
 		VAppTemplates []VAppTemplate `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vapp-templates", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vapp-templates", orgID), &schema)
 	if err != nil {
 		return []VAppTemplate{}, err
 	}
 	return schema.VAppTemplates, nil
 }
 
+func (s *orgService) ListVAppTemplates(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VAppTemplate, error] {
+	ctx = withOperation(ctx, "Org.ListVAppTemplates", orgID)
+	return listAll[VAppTemplate](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vapp-templates", orgID), opts)
+}
+
 func (s *orgService) GetMedia(orgID string) ([]Media, error) {
+	return s.GetMediaContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetMediaContext(ctx context.Context, orgID string) ([]Media, error) {
+	ctx = withOperation(ctx, "Org.GetMedia", orgID)
 	schema := struct {
 		Media []Media `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/medias", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/medias", orgID), &schema)
 	if err != nil {
 		return []Media{}, err
 	}
 	return schema.Media, nil
 }
 
+func (s *orgService) ListMedia(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Media, error] {
+	ctx = withOperation(ctx, "Org.ListMedia", orgID)
+	return listAll[Media](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/medias", orgID), opts)
+}
+
 func (s *orgService) GetNetworks(orgID string) ([]OrgVdcNetwork, error) {
+	return s.GetNetworksContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetNetworksContext(ctx context.Context, orgID string) ([]OrgVdcNetwork, error) {
+	ctx = withOperation(ctx, "Org.GetNetworks", orgID)
 	schema := struct {
 		Networks []OrgVdcNetwork `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/org-vdc-networks", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/org-vdc-networks", orgID), &schema)
 	if err != nil {
 		return []OrgVdcNetwork{}, err
 	}
 	return schema.Networks, nil
 }
 
+func (s *orgService) ListNetworks(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[OrgVdcNetwork, error] {
+	ctx = withOperation(ctx, "Org.ListNetworks", orgID)
+	return listAll[OrgVdcNetwork](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/org-vdc-networks", orgID), opts)
+}
+
 func (s *orgService) GetVApps(orgID string) ([]VApp, error) {
+	return s.GetVAppsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVAppsContext(ctx context.Context, orgID string) ([]VApp, error) {
+	ctx = withOperation(ctx, "Org.GetVApps", orgID)
 	schema := struct {
 		VApps []VApp `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vapps", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vapps", orgID), &schema)
 	if err != nil {
 		return []VApp{}, err
 	}
 	return schema.VApps, nil
 }
 
+func (s *orgService) ListVApps(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VApp, error] {
+	ctx = withOperation(ctx, "Org.ListVApps", orgID)
+	return listAll[VApp](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vapps", orgID), opts)
+}
+
 func (s *orgService) GetVirtualMachines(orgID string) ([]VirtualMachine, error) {
+	return s.GetVirtualMachinesContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVirtualMachinesContext(ctx context.Context, orgID string) ([]VirtualMachine, error) {
+	ctx = withOperation(ctx, "Org.GetVirtualMachines", orgID)
 	schema := struct {
 		VirtualMachines []VirtualMachine `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vms", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vms", orgID), &schema)
 	if err != nil {
 		return []VirtualMachine{}, err
 	}
 	return schema.VirtualMachines, nil
 }
 
+func (s *orgService) ListVirtualMachines(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VirtualMachine, error] {
+	ctx = withOperation(ctx, "Org.ListVirtualMachines", orgID)
+	return listAll[VirtualMachine](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vms", orgID), opts)
+}
+
 func (s *orgService) GetVpgs(orgID string) ([]Vpg, error) {
+	return s.GetVpgsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVpgsContext(ctx context.Context, orgID string) ([]Vpg, error) {
+	ctx = withOperation(ctx, "Org.GetVpgs", orgID)
 	schema := struct {
 		Vpgs []Vpg `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vpgs?expand=VPG_VM", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vpgs?expand=VPG_VM", orgID), &schema)
 	if err != nil {
 		return []Vpg{}, err
 	}
 	return schema.Vpgs, nil
 }
 
+func (s *orgService) ListVpgs(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[Vpg, error] {
+	ctx = withOperation(ctx, "Org.ListVpgs", orgID)
+	return listAll[Vpg](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vpgs?expand=VPG_VM", orgID), opts)
+}
+
 func (s *orgService) GetPublicIPs(orgID string) ([]string, error) {
+	return s.GetPublicIPsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetPublicIPsContext(ctx context.Context, orgID string) ([]string, error) {
+	ctx = withOperation(ctx, "Org.GetPublicIPs", orgID)
 	schema := struct {
 		IPs []string `json:"ips"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/public-ips", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/public-ips", orgID), &schema)
 	if err != nil {
 		return []string{}, err
 	}
@@ -153,19 +255,34 @@ type PublicIPAssignment struct {
 }
 
 func (s *orgService) GetPublicIPAssignments(orgID string) ([]PublicIPAssignment, error) {
+	return s.GetPublicIPAssignmentsContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetPublicIPAssignmentsContext(ctx context.Context, orgID string) ([]PublicIPAssignment, error) {
+	ctx = withOperation(ctx, "Org.GetPublicIPAssignments", orgID)
 	schema := struct {
 		Assignments []PublicIPAssignment `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/public-ip-assignments", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/public-ip-assignments", orgID), &schema)
 	if err != nil {
 		return []PublicIPAssignment{}, err
 	}
 	return schema.Assignments, nil
 }
 
+func (s *orgService) ListPublicIPAssignments(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[PublicIPAssignment, error] {
+	ctx = withOperation(ctx, "Org.ListPublicIPAssignments", orgID)
+	return listAll[PublicIPAssignment](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/public-ip-assignments", orgID), opts)
+}
+
 func (s *orgService) GetCurrentBill(vdcID string) (Billing, error) {
+	return s.GetCurrentBillContext(context.Background(), vdcID)
+}
+
+func (s *orgService) GetCurrentBillContext(ctx context.Context, vdcID string) (Billing, error) {
+	ctx = withOperation(ctx, "Org.GetCurrentBill", vdcID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/billing", vdcID), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/billing", vdcID), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -173,8 +290,13 @@ func (s *orgService) GetCurrentBill(vdcID string) (Billing, error) {
 }
 
 func (s *orgService) GetBill(vdcID string, month, year int) (Billing, error) {
+	return s.GetBillContext(context.Background(), vdcID, month, year)
+}
+
+func (s *orgService) GetBillContext(ctx context.Context, vdcID string, month, year int) (Billing, error) {
+	ctx = withOperation(ctx, "Org.GetBill", vdcID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/billing?month=%d&year=%d", vdcID, month, year), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/billing?month=%d&year=%d", vdcID, month, year), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -182,12 +304,22 @@ func (s *orgService) GetBill(vdcID string, month, year int) (Billing, error) {
 }
 
 func (s *orgService) GetVCCFailoverPlans(orgID string) ([]VCCFailoverPlan, error) {
+	return s.GetVCCFailoverPlansContext(context.Background(), orgID)
+}
+
+func (s *orgService) GetVCCFailoverPlansContext(ctx context.Context, orgID string) ([]VCCFailoverPlan, error) {
+	ctx = withOperation(ctx, "Org.GetVCCFailoverPlans", orgID)
 	schema := struct {
 		VCCFailoverPlans []VCCFailoverPlan `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/orgs/%s/vcc-failover-plans", orgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/orgs/%s/vcc-failover-plans", orgID), &schema)
 	if err != nil {
 		return []VCCFailoverPlan{}, err
 	}
 	return schema.VCCFailoverPlans, nil
 }
+
+func (s *orgService) ListVCCFailoverPlans(ctx context.Context, orgID string, opts ListOptions) iter.Seq2[VCCFailoverPlan, error] {
+	ctx = withOperation(ctx, "Org.ListVCCFailoverPlans", orgID)
+	return listAll[VCCFailoverPlan](ctx, s.client, fmt.Sprintf("/v1/orgs/%s/vcc-failover-plans", orgID), opts)
+}
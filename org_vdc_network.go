@@ -1,6 +1,7 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -32,8 +33,13 @@ type orgVdcNetworkService struct {
 }
 
 func (s *orgVdcNetworkService) Get(networkID string) (OrgVdcNetwork, error) {
+	return s.GetContext(context.Background(), networkID)
+}
+
+func (s *orgVdcNetworkService) GetContext(ctx context.Context, networkID string) (OrgVdcNetwork, error) {
+	ctx = withOperation(ctx, "OrgVdcNetwork.Get", networkID)
 	network := OrgVdcNetwork{}
-	err := s.client.getObject(fmt.Sprintf("/v1/org-vdc-networks/%s", networkID), &network)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/org-vdc-networks/%s", networkID), &network)
 	if err != nil {
 		return OrgVdcNetwork{}, err
 	}
@@ -47,11 +53,16 @@ type UpdateOrgVdcNetworkParams struct {
 }
 
 func (s *orgVdcNetworkService) Update(networkID string, params UpdateOrgVdcNetworkParams) (Task, error) {
+	return s.UpdateContext(context.Background(), networkID, params)
+}
+
+func (s *orgVdcNetworkService) UpdateContext(ctx context.Context, networkID string, params UpdateOrgVdcNetworkParams) (Task, error) {
+	ctx = withOperation(ctx, "OrgVdcNetwork.Update", networkID)
 	data, err := json.Marshal(params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/org-vdc-networks/%s", networkID), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/org-vdc-networks/%s", networkID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -0,0 +1,344 @@
+package iland
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResolverOptions configures a Resolver.
+type ResolverOptions struct {
+	// CacheTTL, if positive, caches companies, inventories and listings for
+	// that long. Otherwise every call fetches them afresh.
+	CacheTTL time.Duration
+	// Concurrency is how many companies or orgs are queried at once when
+	// resolving a bare name. It defaults to 8.
+	Concurrency int
+}
+
+// Resolver turns names and paths into entity IDs.
+//
+// A path names the company first and then entities below it, separated by
+// slashes, such as "acme/dal02/prod-vdc/web-vapp/web-01". Levels may be
+// skipped: each name matches the nearest entities below the previous one.
+// Locations also match the part of their ID before the first dot. Each name
+// may also be an ID.
+//
+// The hierarchy comes from the company inventory. Catalogs and vApp
+// templates, which the inventory does not always include, are looked up
+// with the Org, Catalog and Vdc list calls.
+type Resolver struct {
+	console ConsoleService
+	opts    ResolverOptions
+
+	mu    sync.Mutex
+	cache map[string]resolverEntry
+}
+
+type resolverEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewResolver returns a resolver that looks entities up through console.
+func NewResolver(console ConsoleService, opts ResolverOptions) *Resolver {
+	return &Resolver{console: console, opts: opts, cache: map[string]resolverEntry{}}
+}
+
+// Invalidate empties the cache.
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache = map[string]resolverEntry{}
+}
+
+// ResolveID returns the ID of the entity of entityType named by ref.
+func (r *Resolver) ResolveID(ctx context.Context, ref, entityType string) (string, error) {
+	item, err := r.Resolve(ctx, ref, entityType)
+	if err != nil {
+		return "", err
+	}
+	return item.UUID, nil
+}
+
+// Resolve returns the entity named by ref, which is either a path or, if it
+// has no slash, the name of an entity of entityType in any company. An
+// empty entityType accepts any type at the end of a path. Names matching
+// several entities return an *AmbiguousNameError; unknown names an error
+// matching ErrNotFound.
+func (r *Resolver) Resolve(ctx context.Context, ref, entityType string) (InventoryItem, error) {
+	ctx = withOperation(ctx, "Resolver.Resolve", "")
+	if !strings.Contains(ref, "/") {
+		return r.resolveName(ctx, ref, entityType)
+	}
+	return r.resolvePath(ctx, ref, entityType)
+}
+
+func (r *Resolver) resolvePath(ctx context.Context, path, entityType string) (InventoryItem, error) {
+	names := strings.Split(path, "/")
+	for _, name := range names {
+		if name == "" {
+			return InventoryItem{}, fmt.Errorf("iland: invalid path %q", path)
+		}
+	}
+	companies, err := r.companies(ctx)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	matches := []InventoryItem{}
+	for _, company := range companies {
+		if company.ID == names[0] || company.Name == names[0] {
+			matches = append(matches, InventoryItem{UUID: company.ID, Type: EntityCompany, Name: company.Name})
+		}
+	}
+	company, err := single(path, names[0], matches)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	graph, err := r.graph(ctx, company.UUID)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	node, ok := graph.Node(company.Type, company.UUID)
+	if !ok {
+		node = &InventoryNode{InventoryItem: company}
+	}
+	for i, name := range names[1:] {
+		wantType := ""
+		if i == len(names)-2 {
+			wantType = entityType
+		}
+		node, err = r.child(ctx, path, node, name, wantType)
+		if err != nil {
+			return InventoryItem{}, err
+		}
+	}
+	if entityType != "" && node.Type != entityType {
+		return InventoryItem{}, fmt.Errorf("iland: %q is a %s, not a %s", path, node.Type, entityType)
+	}
+	return node.InventoryItem, nil
+}
+
+// child finds name among the nearest matching descendants of node, falling
+// back to the list calls for node's type.
+func (r *Resolver) child(ctx context.Context, path string, node *InventoryNode, name, entityType string) (*InventoryNode, error) {
+	level := node.Children()
+	for len(level) > 0 {
+		matches := []*InventoryNode{}
+		next := []*InventoryNode{}
+		for _, n := range level {
+			if nameMatches(n, name, entityType) {
+				matches = append(matches, n)
+			}
+			next = append(next, n.Children()...)
+		}
+		if len(matches) > 0 {
+			return singleNode(path, name, matches)
+		}
+		level = next
+	}
+	listed, err := r.listed(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	matches := []*InventoryNode{}
+	for _, n := range listed {
+		if nameMatches(n, name, entityType) {
+			matches = append(matches, n)
+		}
+	}
+	return singleNode(path, name, matches)
+}
+
+func (r *Resolver) resolveName(ctx context.Context, name, entityType string) (InventoryItem, error) {
+	if entityType == "" {
+		return InventoryItem{}, fmt.Errorf("iland: resolving %q: an entity type is required for a bare name", name)
+	}
+	companies, err := r.companies(ctx)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	if entityType == EntityCompany {
+		matches := []InventoryItem{}
+		for _, company := range companies {
+			if company.ID == name || company.Name == name {
+				matches = append(matches, InventoryItem{UUID: company.ID, Type: EntityCompany, Name: company.Name})
+			}
+		}
+		return single(name, name, matches)
+	}
+	companyIDs := make([]string, len(companies))
+	for i, company := range companies {
+		companyIDs[i] = company.ID
+	}
+	// A company that cannot be searched may hold another match, so partial
+	// results are not trusted.
+	found, err := fanOut(ctx, "Resolver.Resolve", companyIDs, r.opts.Concurrency, func(ctx context.Context, companyID string) ([]*InventoryNode, error) {
+		graph, err := r.graph(ctx, companyID)
+		if err != nil {
+			return nil, err
+		}
+		found := graph.Filter(func(n *InventoryNode) bool { return nameMatches(n, name, entityType) })
+		if len(found) == 0 && (entityType == EntityIaasCatalog || entityType == EntityIaasVAppTemplate) {
+			return r.findListed(ctx, graph, name, entityType)
+		}
+		return found, nil
+	})
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	node, err := singleNode(name, name, found)
+	if err != nil {
+		return InventoryItem{}, err
+	}
+	return node.InventoryItem, nil
+}
+
+// findListed searches the catalogs and templates of every org in graph.
+func (r *Resolver) findListed(ctx context.Context, graph *InventoryGraph, name, entityType string) ([]*InventoryNode, error) {
+	orgIDs := []string{}
+	for _, org := range graph.OfType(EntityIaasOrganization) {
+		orgIDs = append(orgIDs, org.UUID)
+	}
+	return fanOut(ctx, "Resolver.Resolve", orgIDs, r.opts.Concurrency, func(ctx context.Context, orgID string) ([]*InventoryNode, error) {
+		org, _ := graph.Node(EntityIaasOrganization, orgID)
+		listed, err := r.listed(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+		found := []*InventoryNode{}
+		for _, n := range listed {
+			if nameMatches(n, name, entityType) {
+				found = append(found, n)
+			}
+		}
+		return found, nil
+	})
+}
+
+// listed returns the entities below node that are found with list calls
+// rather than in the inventory.
+func (r *Resolver) listed(ctx context.Context, node *InventoryNode) ([]*InventoryNode, error) {
+	key := "list/" + node.Type + "/" + node.UUID
+	items, err := cached(r, key, func() ([]InventoryItem, error) {
+		items := []InventoryItem{}
+		add := func(id, name, entityType string) {
+			items = append(items, InventoryItem{UUID: id, Name: name, Type: entityType, ParentUUID: node.UUID, ParentType: node.Type})
+		}
+		switch node.Type {
+		case EntityIaasOrganization:
+			for catalog, err := range r.console.Org().ListCatalogs(ctx, node.UUID, ListOptions{}) {
+				if err != nil {
+					return nil, err
+				}
+				add(catalog.ID, catalog.Name, EntityIaasCatalog)
+			}
+			for template, err := range r.console.Org().ListVAppTemplates(ctx, node.UUID, ListOptions{}) {
+				if err != nil {
+					return nil, err
+				}
+				add(template.ID, template.Name, EntityIaasVAppTemplate)
+			}
+		case EntityIaasCatalog:
+			for template, err := range r.console.Catalog().ListVAppTemplates(ctx, node.UUID, ListOptions{}) {
+				if err != nil {
+					return nil, err
+				}
+				add(template.ID, template.Name, EntityIaasVAppTemplate)
+			}
+		case EntityIaasVdc:
+			for edge, err := range r.console.Vdc().ListEdges(ctx, node.UUID, ListOptions{}) {
+				if err != nil {
+					return nil, err
+				}
+				add(edge.ID, edge.Name, EntityIaasEdge)
+			}
+			for network, err := range r.console.Vdc().ListNetworks(ctx, node.UUID, ListOptions{}) {
+				if err != nil {
+					return nil, err
+				}
+				add(network.ID, network.Name, EntityIaasInternalNetwork)
+			}
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*InventoryNode, len(items))
+	for i, item := range items {
+		nodes[i] = &InventoryNode{InventoryItem: item, parent: node}
+	}
+	return nodes, nil
+}
+
+func (r *Resolver) companies(ctx context.Context) ([]Company, error) {
+	return cached(r, "companies", func() ([]Company, error) {
+		return r.console.GetCompaniesContext(ctx)
+	})
+}
+
+func (r *Resolver) graph(ctx context.Context, companyID string) (*InventoryGraph, error) {
+	return cached(r, "graph/"+companyID, func() (*InventoryGraph, error) {
+		return r.console.Company().GetInventoryGraphContext(ctx, companyID)
+	})
+}
+
+// cached returns the cached value for key, calling fetch on a miss.
+func cached[T any](r *Resolver, key string, fetch func() (T, error)) (T, error) {
+	if r.opts.CacheTTL <= 0 {
+		return fetch()
+	}
+	r.mu.Lock()
+	entry, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.value.(T), nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	r.mu.Lock()
+	r.cache[key] = resolverEntry{value: value, expires: time.Now().Add(r.opts.CacheTTL)}
+	r.mu.Unlock()
+	return value, nil
+}
+
+func nameMatches(n *InventoryNode, name, entityType string) bool {
+	if entityType != "" && n.Type != entityType {
+		return false
+	}
+	if n.Name == name || n.UUID == name {
+		return true
+	}
+	if n.Type == EntityIaasLocation {
+		short, _, _ := strings.Cut(n.UUID, ".")
+		return short == name
+	}
+	return false
+}
+
+func singleNode(ref, name string, nodes []*InventoryNode) (*InventoryNode, error) {
+	items := make([]InventoryItem, len(nodes))
+	for i, n := range nodes {
+		items[i] = n.InventoryItem
+	}
+	_, err := single(ref, name, items)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+func single(ref, name string, items []InventoryItem) (InventoryItem, error) {
+	switch len(items) {
+	case 0:
+		return InventoryItem{}, fmt.Errorf("iland: resolving %q: no entity named %q: %w", ref, name, ErrNotFound)
+	case 1:
+		return items[0], nil
+	}
+	return InventoryItem{}, &AmbiguousNameError{Ref: ref, Name: name, Matches: items}
+}
@@ -0,0 +1,154 @@
+package iland_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+// resolverFixture is a company with two vdcs that both hold a vApp named
+// web-vapp with a VM named web-01.
+type resolverFixture struct {
+	company  iland.Company
+	org      iland.Org
+	vdc      iland.Vdc
+	vm       iland.VirtualMachine
+	edge     iland.Edge
+	catalog  iland.Catalog
+	template iland.VAppTemplate
+}
+
+func newResolverFixture(srv *ilandtest.Server) resolverFixture {
+	var f resolverFixture
+	f.company = srv.AddCompany(iland.Company{Name: "acme"})
+	f.org = srv.AddOrg(iland.Org{Name: "acme-org", CompanyID: f.company.ID, LocationID: "dal02.ilandcloud.com"})
+	f.vdc = srv.AddVdc(iland.Vdc{Name: "prod-vdc", OrgID: f.org.ID})
+	devVdc := srv.AddVdc(iland.Vdc{Name: "dev-vdc", OrgID: f.org.ID})
+	vapp := srv.AddVApp(iland.VApp{Name: "web-vapp", VdcID: f.vdc.ID})
+	devVApp := srv.AddVApp(iland.VApp{Name: "web-vapp", VdcID: devVdc.ID})
+	f.vm = srv.AddVirtualMachine(iland.VirtualMachine{Name: "web-01", VAppID: vapp.ID})
+	srv.AddVirtualMachine(iland.VirtualMachine{Name: "web-01", VAppID: devVApp.ID})
+	f.edge = srv.AddEdge(iland.Edge{Name: "gw", VdcID: f.vdc.ID})
+	f.catalog = srv.AddCatalog(iland.Catalog{Name: "golden", OrgID: f.org.ID})
+	f.template = srv.AddVAppTemplate(iland.VAppTemplate{Name: "ubuntu", CatalogID: f.catalog.ID})
+	return f
+}
+
+func TestResolverResolve(t *testing.T) {
+	srv := ilandtest.NewServer()
+	defer srv.Close()
+	f := newResolverFixture(srv)
+	c, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ref        string
+		entityType string
+		want       string
+		wantErr    error
+		wantErrMsg string
+		// wantMatches is the number of matches of an *AmbiguousNameError.
+		wantMatches int
+	}{
+		{name: "full path", ref: "acme/dal02.ilandcloud.com/acme-org/prod-vdc/web-vapp/web-01", entityType: iland.EntityIaasVm, want: f.vm.ID},
+		{name: "short location ID", ref: "acme/dal02/acme-org", entityType: iland.EntityIaasOrganization, want: f.org.ID},
+		{name: "skipped levels", ref: "acme/prod-vdc/web-01", want: f.vm.ID},
+		{name: "ID in path", ref: f.company.ID + "/" + f.vdc.ID + "/gw", entityType: iland.EntityIaasEdge, want: f.edge.ID},
+		{name: "bare name", ref: "prod-vdc", entityType: iland.EntityIaasVdc, want: f.vdc.ID},
+		{name: "catalog from list", ref: "acme/acme-org/golden", entityType: iland.EntityIaasCatalog, want: f.catalog.ID},
+		{name: "template from list", ref: "acme/acme-org/golden/ubuntu", entityType: iland.EntityIaasVAppTemplate, want: f.template.ID},
+		{name: "bare template name from list", ref: "ubuntu", entityType: iland.EntityIaasVAppTemplate, want: f.template.ID},
+		{name: "ambiguous path", ref: "acme/web-vapp", wantErr: iland.ErrAmbiguousName, wantMatches: 2},
+		{name: "ambiguous bare name", ref: "web-01", entityType: iland.EntityIaasVm, wantErr: iland.ErrAmbiguousName, wantMatches: 2},
+		{name: "unknown name", ref: "acme/nope", wantErr: iland.ErrNotFound},
+		{name: "unknown company", ref: "globex/prod-vdc", wantErr: iland.ErrNotFound},
+		{name: "unknown bare name", ref: "nope", entityType: iland.EntityIaasVm, wantErr: iland.ErrNotFound},
+		{name: "empty segment", ref: "acme//prod-vdc", wantErrMsg: "invalid path"},
+		{name: "trailing slash", ref: "acme/prod-vdc/", wantErrMsg: "invalid path"},
+		{name: "wrong type", ref: "acme/prod-vdc", entityType: iland.EntityIaasVm, wantErr: iland.ErrNotFound},
+		{name: "bare name without type", ref: "prod-vdc", wantErrMsg: "entity type is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := iland.NewResolver(c, iland.ResolverOptions{})
+			got, err := r.ResolveID(context.Background(), tt.ref, tt.entityType)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantErrMsg != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErrMsg)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case got != tt.want:
+				t.Errorf("ResolveID(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+			if tt.wantMatches > 0 {
+				var ambiguous *iland.AmbiguousNameError
+				if !errors.As(err, &ambiguous) {
+					t.Fatalf("err = %v, want *AmbiguousNameError", err)
+				}
+				if len(ambiguous.Matches) != tt.wantMatches {
+					t.Errorf("got %d matches, want %d", len(ambiguous.Matches), tt.wantMatches)
+				}
+			}
+		})
+	}
+}
+
+func TestResolverCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		invalidate bool
+		// wantRequests is the number of requests made by the second lookup.
+		wantRequests int
+	}{
+		// The companies and the inventory, then the org's catalogs and
+		// vApp templates.
+		{name: "no cache", wantRequests: 4},
+		{name: "cached", ttl: time.Minute, wantRequests: 0},
+		{name: "invalidated", ttl: time.Minute, invalidate: true, wantRequests: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ilandtest.NewServer()
+			defer srv.Close()
+			f := newResolverFixture(srv)
+			c, err := srv.NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := iland.NewResolver(c, iland.ResolverOptions{CacheTTL: tt.ttl})
+			const ref = "acme/acme-org/golden"
+			if _, err := r.ResolveID(context.Background(), ref, iland.EntityIaasCatalog); err != nil {
+				t.Fatal(err)
+			}
+			if tt.invalidate {
+				r.Invalidate()
+			}
+			before := len(srv.Requests())
+			got, err := r.ResolveID(context.Background(), ref, iland.EntityIaasCatalog)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != f.catalog.ID {
+				t.Errorf("ResolveID = %q, want %q", got, f.catalog.ID)
+			}
+			if n := len(srv.Requests()) - before; n != tt.wantRequests {
+				t.Errorf("second lookup made %d requests, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only GET requests
+// are retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	// MaxBackoff caps each delay. A Retry-After longer than it is not
+	// waited for: the request fails with the *APIError, whose RetryAfter
+	// says when to try again.
+	MaxBackoff time.Duration
+	Multiplier float64
+	// Jitter randomises each delay by up to this fraction in either
+	// direction, e.g. 0.2 for +/-20%.
+	Jitter float64
+	// RetryNonIdempotent allows POST, PUT and DELETE requests to be retried.
+	RetryNonIdempotent bool
+	// OnRetry is called before waiting for each retry.
+	OnRetry func(RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried.
+type RetryAttempt struct {
+	Method  string
+	Path    string
+	Attempt int
+	Delay   time.Duration
+	Err     error
+}
+
+// DefaultRetryPolicy is used by clients that are not configured with
+// WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// NoRetryPolicy disables retries.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy sets the retry policy used for every request made by the
+// client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *client) error {
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+type retryPolicyKey struct{}
+
+// ContextWithRetryPolicy overrides the client retry policy for calls made
+// with the returned context. Use it with RetryNonIdempotent set to opt a
+// POST action into retries.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+func (c *client) retryPolicyFor(ctx context.Context) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	return c.retryPolicy
+}
+
+func (p RetryPolicy) allows(verb string) bool {
+	return verb == http.MethodGet || p.RetryNonIdempotent
+}
+
+// delay returns how long to wait before retrying, and false if the server
+// asked for a longer wait than MaxBackoff allows.
+func (p RetryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxBackoff > 0 && apiErr.RetryAfter > p.MaxBackoff {
+			return 0, false
+		}
+		return apiErr.RetryAfter, true
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff), true
+}
+
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
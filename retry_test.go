@@ -0,0 +1,117 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+	}{
+		{name: "empty", value: "", min: 0, max: 0},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{name: "zero seconds", value: "0", min: 0, max: 0},
+		{name: "negative seconds", value: "-5", min: 0, max: 0},
+		{name: "http date", value: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second},
+		{name: "past http date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0},
+		{name: "garbage", value: "soon", min: 0, max: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRetryAfter(tt.value)
+			if got < tt.min || got > tt.max {
+				t.Errorf("parseRetryAfter(%q) = %s, want between %s and %s", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		want    time.Duration
+		// giveUp means the server asked for a longer wait than MaxBackoff.
+		giveUp bool
+	}{
+		{name: "first attempt", attempt: 1, err: &APIError{StatusCode: http.StatusBadGateway}, want: time.Second},
+		{name: "backoff", attempt: 3, err: &APIError{StatusCode: http.StatusBadGateway}, want: 4 * time.Second},
+		{name: "capped", attempt: 10, err: &APIError{StatusCode: http.StatusBadGateway}, want: 5 * time.Second},
+		{name: "retry after", attempt: 1, err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}, want: 3 * time.Second},
+		{name: "retry after at max", attempt: 1, err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second}, want: 5 * time.Second},
+		{name: "retry after beyond max", attempt: 1, err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 42 * time.Second}, giveUp: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := policy.delay(tt.attempt, tt.err)
+			if ok == tt.giveUp {
+				t.Fatalf("ok = %t, want %t", ok, !tt.giveUp)
+			}
+			if got != tt.want {
+				t.Errorf("delay = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestHonoursRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		verb       string
+		wantCalls  int32
+		wantDelay  time.Duration
+	}{
+		{name: "too many requests", status: http.StatusTooManyRequests, retryAfter: "1", verb: http.MethodGet, wantCalls: 2, wantDelay: time.Second},
+		{name: "unavailable", status: http.StatusServiceUnavailable, retryAfter: "1", verb: http.MethodGet, wantCalls: 2, wantDelay: time.Second},
+		{name: "not retryable", status: http.StatusBadRequest, retryAfter: "1", verb: http.MethodGet, wantCalls: 1},
+		{name: "post not retried", status: http.StatusTooManyRequests, retryAfter: "1", verb: http.MethodPost, wantCalls: 1},
+		{name: "beyond max backoff", status: http.StatusTooManyRequests, retryAfter: "120", verb: http.MethodGet, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			var delays []time.Duration
+			policy := RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Hour,
+				MaxBackoff:     time.Minute,
+				OnRetry:        func(a RetryAttempt) { delays = append(delays, a.Delay) },
+			}
+			ctx := ContextWithRetryPolicy(context.Background(), policy)
+			body, err := c.request(ctx, "/v1/items", tt.verb, c.mediaType("json"), nil)
+			if err == nil {
+				body.Close()
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Fatalf("made %d calls, want %d (err %v)", got, tt.wantCalls, err)
+			}
+			if tt.wantCalls > 1 && (err != nil || len(delays) != 1 || delays[0] != tt.wantDelay) {
+				t.Errorf("err = %v, delays = %v, want one delay of %s", err, delays, tt.wantDelay)
+			}
+			var apiErr *APIError
+			if tt.wantCalls == 1 && (!errors.As(err, &apiErr) || apiErr.StatusCode != tt.status) {
+				t.Errorf("err = %v, want *APIError with status %d", err, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package iland
+
+import (
+	"context"
+	"errors"
+)
+
+// SearchVirtualMachines returns the company's VMs that match query,
+// fetching the VMs of every org concurrently. If some locations, orgs or
+// metadata lookups fail, the matches found elsewhere are returned with a
+// *PartialError.
+func (s *companyService) SearchVirtualMachines(ctx context.Context, companyID string, query VirtualMachineQuery) ([]VirtualMachine, error) {
+	ctx = withOperation(ctx, "Company.SearchVirtualMachines", companyID)
+	return search(ctx, s, "Company.SearchVirtualMachines", companyID, query.q, searchFuncs[VirtualMachine]{
+		list:     s.client.Org().GetVirtualMachinesContext,
+		metadata: s.client.VirtualMachine().GetMetadataContext,
+		id:       func(vm VirtualMachine) string { return vm.ID },
+		name:     func(vm VirtualMachine) string { return vm.Name },
+	})
+}
+
+// SearchVApps returns the company's vApps that match query. It works like
+// SearchVirtualMachines.
+func (s *companyService) SearchVApps(ctx context.Context, companyID string, query VAppQuery) ([]VApp, error) {
+	ctx = withOperation(ctx, "Company.SearchVApps", companyID)
+	return search(ctx, s, "Company.SearchVApps", companyID, query.q, searchFuncs[VApp]{
+		list:     s.client.Org().GetVAppsContext,
+		metadata: s.client.VApp().GetMetadataContext,
+		id:       func(vapp VApp) string { return vapp.ID },
+		name:     func(vapp VApp) string { return vapp.Name },
+	})
+}
+
+// SearchEdges returns the company's edges that match query. It works like
+// SearchVirtualMachines.
+func (s *companyService) SearchEdges(ctx context.Context, companyID string, query EdgeQuery) ([]Edge, error) {
+	ctx = withOperation(ctx, "Company.SearchEdges", companyID)
+	return search(ctx, s, "Company.SearchEdges", companyID, query.q, searchFuncs[Edge]{
+		list: s.client.Org().GetEdgesContext,
+		id:   func(edge Edge) string { return edge.ID },
+		name: func(edge Edge) string { return edge.Name },
+	})
+}
+
+// searchFuncs fetches and identifies the entities of one search.
+type searchFuncs[T any] struct {
+	list     func(ctx context.Context, orgID string) ([]T, error)
+	metadata func(ctx context.Context, id string) ([]Metadata, error)
+	id       func(T) string
+	name     func(T) string
+}
+
+func search[T any](ctx context.Context, s *companyService, op, companyID string, q searchQuery[T], funcs searchFuncs[T]) ([]T, error) {
+	orgs, orgsErr := s.GetOrgsContext(ctx, companyID)
+	var partial *PartialError
+	if orgsErr != nil && !errors.As(orgsErr, &partial) {
+		return []T{}, orgsErr
+	}
+	orgIDs := make([]string, len(orgs))
+	for i, org := range orgs {
+		orgIDs[i] = org.ID
+	}
+	concurrency := s.client.fanOutConcurrency
+	items, listErr := fanOut(ctx, op, orgIDs, concurrency, func(ctx context.Context, orgID string) ([]T, error) {
+		all, err := funcs.list(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		matched := []T{}
+		for _, item := range all {
+			if q.matches(item) {
+				matched = append(matched, item)
+			}
+		}
+		return matched, nil
+	})
+	var metadataErr error
+	if len(q.metadata) > 0 && funcs.metadata != nil {
+		byID := map[string]T{}
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = funcs.id(item)
+			byID[ids[i]] = item
+		}
+		items, metadataErr = fanOut(ctx, op, ids, concurrency, func(ctx context.Context, id string) ([]T, error) {
+			metadata, err := funcs.metadata(ctx, id)
+			if err != nil || !q.matchesMetadata(metadata) {
+				return nil, err
+			}
+			return []T{byID[id]}, nil
+		})
+	}
+	return q.sort(items, funcs.name, funcs.id), joinPartial(op, orgsErr, listErr, metadataErr)
+}
+
+// joinPartial merges the *PartialErrors of the stages of one call into a
+// single *PartialError, or returns nil if none failed.
+func joinPartial(op string, errs ...error) error {
+	joined := &PartialError{Op: op, Errors: map[string]error{}}
+	for _, err := range errs {
+		var partial *PartialError
+		if !errors.As(err, &partial) {
+			continue
+		}
+		joined.Total += partial.Total
+		for key, err := range partial.Errors {
+			joined.Errors[key] = err
+		}
+	}
+	if len(joined.Errors) == 0 {
+		return nil
+	}
+	return joined
+}
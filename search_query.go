@@ -0,0 +1,290 @@
+package iland
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// VirtualMachineQuery selects VMs for CompanyService.SearchVirtualMachines.
+// Like TaskQuery it is built by chaining methods on a zero
+// VirtualMachineQuery, each of which returns a modified copy. Every
+// criterion must match:
+//
+//	q := iland.VirtualMachineQuery{}.
+//		Status("POWERED_OFF").
+//		OperatingSystem("windows").
+//		HardwareVersionBelow(14).
+//		SortBy(func(a, b iland.VirtualMachine) int { return b.MemoryMB - a.MemoryMB })
+type VirtualMachineQuery struct {
+	q searchQuery[VirtualMachine]
+}
+
+// Status restricts the query to VMs with one of the statuses, such as
+// "POWERED_ON" or "POWERED_OFF".
+func (q VirtualMachineQuery) Status(statuses ...string) VirtualMachineQuery {
+	statuses = slices.Clone(statuses)
+	q.q = q.q.where(func(vm VirtualMachine) bool { return slices.Contains(statuses, vm.Status) })
+	return q
+}
+
+// OperatingSystem restricts the query to VMs whose guest OS name or
+// description contains os, ignoring case.
+func (q VirtualMachineQuery) OperatingSystem(os string) VirtualMachineQuery {
+	os = strings.ToLower(os)
+	q.q = q.q.where(func(vm VirtualMachine) bool {
+		return strings.Contains(strings.ToLower(vm.OperatingSystemName), os) ||
+			strings.Contains(strings.ToLower(vm.OperatingSystemDescription), os)
+	})
+	return q
+}
+
+// CPUs restricts the query to VMs with between min and max CPUs inclusive.
+// A max of zero is unbounded.
+func (q VirtualMachineQuery) CPUs(min, max int) VirtualMachineQuery {
+	q.q = q.q.where(func(vm VirtualMachine) bool { return inRange(vm.CPUCount, min, max) })
+	return q
+}
+
+// MemoryMB restricts the query to VMs with between min and max MB of
+// memory inclusive. A max of zero is unbounded.
+func (q VirtualMachineQuery) MemoryMB(min, max int) VirtualMachineQuery {
+	q.q = q.q.where(func(vm VirtualMachine) bool { return inRange(vm.MemoryMB, min, max) })
+	return q
+}
+
+// HardwareVersionBelow restricts the query to VMs whose hardware version,
+// such as "vmx-13", is lower than version. VMs with an unrecognised
+// version never match.
+func (q VirtualMachineQuery) HardwareVersionBelow(version int) VirtualMachineQuery {
+	q.q = q.q.where(func(vm VirtualMachine) bool {
+		v, ok := parseHardwareVersion(vm.HardwareVersion)
+		return ok && v < version
+	})
+	return q
+}
+
+// MediaInserted restricts the query to VMs with or without media inserted.
+func (q VirtualMachineQuery) MediaInserted(inserted bool) VirtualMachineQuery {
+	q.q = q.q.where(func(vm VirtualMachine) bool { return vm.MediaInserted == inserted })
+	return q
+}
+
+// Location restricts the query to VMs in one of the locations.
+func (q VirtualMachineQuery) Location(locationIDs ...string) VirtualMachineQuery {
+	locationIDs = slices.Clone(locationIDs)
+	q.q = q.q.where(func(vm VirtualMachine) bool { return slices.Contains(locationIDs, vm.LocationID) })
+	return q
+}
+
+// Vdc restricts the query to VMs in one of the vdcs.
+func (q VirtualMachineQuery) Vdc(vdcIDs ...string) VirtualMachineQuery {
+	vdcIDs = slices.Clone(vdcIDs)
+	q.q = q.q.where(func(vm VirtualMachine) bool { return slices.Contains(vdcIDs, vm.VdcID) })
+	return q
+}
+
+// Metadata restricts the query to VMs with a metadata entry for key and,
+// unless value is nil, that value. Values are compared as text, so 14 and
+// "14" are equal. Metadata is fetched only for VMs that match every other
+// criterion.
+func (q VirtualMachineQuery) Metadata(key string, value interface{}) VirtualMachineQuery {
+	q.q = q.q.withMetadata(key, value)
+	return q
+}
+
+// Where restricts the query to VMs for which match returns true.
+func (q VirtualMachineQuery) Where(match func(VirtualMachine) bool) VirtualMachineQuery {
+	q.q = q.q.where(match)
+	return q
+}
+
+// SortBy orders the results by compare, which returns a negative number
+// when a sorts before b. Results are ordered by name otherwise.
+func (q VirtualMachineQuery) SortBy(compare func(a, b VirtualMachine) int) VirtualMachineQuery {
+	q.q.order = compare
+	return q
+}
+
+// Limit returns at most n results, after sorting.
+func (q VirtualMachineQuery) Limit(n int) VirtualMachineQuery {
+	q.q.limit = n
+	return q
+}
+
+// VAppQuery selects vApps for CompanyService.SearchVApps. It is built like
+// VirtualMachineQuery.
+type VAppQuery struct {
+	q searchQuery[VApp]
+}
+
+// Status restricts the query to vApps with one of the statuses.
+func (q VAppQuery) Status(statuses ...string) VAppQuery {
+	statuses = slices.Clone(statuses)
+	q.q = q.q.where(func(vapp VApp) bool { return slices.Contains(statuses, vapp.Status) })
+	return q
+}
+
+// Expired restricts the query to vApps whose lease has or has not expired.
+func (q VAppQuery) Expired(expired bool) VAppQuery {
+	q.q = q.q.where(func(vapp VApp) bool { return vapp.IsExpired == expired })
+	return q
+}
+
+// Location restricts the query to vApps in one of the locations.
+func (q VAppQuery) Location(locationIDs ...string) VAppQuery {
+	locationIDs = slices.Clone(locationIDs)
+	q.q = q.q.where(func(vapp VApp) bool { return slices.Contains(locationIDs, vapp.LocationID) })
+	return q
+}
+
+// Vdc restricts the query to vApps in one of the vdcs.
+func (q VAppQuery) Vdc(vdcIDs ...string) VAppQuery {
+	vdcIDs = slices.Clone(vdcIDs)
+	q.q = q.q.where(func(vapp VApp) bool { return slices.Contains(vdcIDs, vapp.VdcID) })
+	return q
+}
+
+// Metadata works like VirtualMachineQuery.Metadata.
+func (q VAppQuery) Metadata(key string, value interface{}) VAppQuery {
+	q.q = q.q.withMetadata(key, value)
+	return q
+}
+
+// Where restricts the query to vApps for which match returns true.
+func (q VAppQuery) Where(match func(VApp) bool) VAppQuery {
+	q.q = q.q.where(match)
+	return q
+}
+
+// SortBy orders the results by compare. Results are ordered by name
+// otherwise.
+func (q VAppQuery) SortBy(compare func(a, b VApp) int) VAppQuery {
+	q.q.order = compare
+	return q
+}
+
+// Limit returns at most n results, after sorting.
+func (q VAppQuery) Limit(n int) VAppQuery {
+	q.q.limit = n
+	return q
+}
+
+// EdgeQuery selects edges for CompanyService.SearchEdges. It is built like
+// VirtualMachineQuery. Edges have no metadata.
+type EdgeQuery struct {
+	q searchQuery[Edge]
+}
+
+// Status restricts the query to edges with one of the statuses.
+func (q EdgeQuery) Status(statuses ...int) EdgeQuery {
+	statuses = slices.Clone(statuses)
+	q.q = q.q.where(func(edge Edge) bool { return slices.Contains(statuses, edge.Status) })
+	return q
+}
+
+// Location restricts the query to edges in one of the locations.
+func (q EdgeQuery) Location(locationIDs ...string) EdgeQuery {
+	locationIDs = slices.Clone(locationIDs)
+	q.q = q.q.where(func(edge Edge) bool { return slices.Contains(locationIDs, edge.LocationID) })
+	return q
+}
+
+// Vdc restricts the query to edges in one of the vdcs.
+func (q EdgeQuery) Vdc(vdcIDs ...string) EdgeQuery {
+	vdcIDs = slices.Clone(vdcIDs)
+	q.q = q.q.where(func(edge Edge) bool { return slices.Contains(vdcIDs, edge.VdcID) })
+	return q
+}
+
+// Where restricts the query to edges for which match returns true.
+func (q EdgeQuery) Where(match func(Edge) bool) EdgeQuery {
+	q.q = q.q.where(match)
+	return q
+}
+
+// SortBy orders the results by compare. Results are ordered by name
+// otherwise.
+func (q EdgeQuery) SortBy(compare func(a, b Edge) int) EdgeQuery {
+	q.q.order = compare
+	return q
+}
+
+// Limit returns at most n results, after sorting.
+func (q EdgeQuery) Limit(n int) EdgeQuery {
+	q.q.limit = n
+	return q
+}
+
+// searchQuery holds the criteria shared by the search queries.
+type searchQuery[T any] struct {
+	match    []func(T) bool
+	metadata []metadataMatch
+	order    func(a, b T) int
+	limit    int
+}
+
+type metadataMatch struct {
+	key   string
+	value interface{}
+}
+
+func (q searchQuery[T]) where(match func(T) bool) searchQuery[T] {
+	q.match = append(slices.Clip(q.match), match)
+	return q
+}
+
+func (q searchQuery[T]) withMetadata(key string, value interface{}) searchQuery[T] {
+	q.metadata = append(slices.Clip(q.metadata), metadataMatch{key, value})
+	return q
+}
+
+func (q searchQuery[T]) matches(item T) bool {
+	for _, match := range q.match {
+		if !match(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q searchQuery[T]) matchesMetadata(metadata []Metadata) bool {
+	for _, want := range q.metadata {
+		found := slices.ContainsFunc(metadata, func(m Metadata) bool {
+			return m.Key == want.key && (want.value == nil || fmt.Sprint(m.Value) == fmt.Sprint(want.value))
+		})
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sort orders items by the query's order, or by name and then ID, and
+// applies the limit.
+func (q searchQuery[T]) sort(items []T, name, id func(T) string) []T {
+	order := q.order
+	if order == nil {
+		order = func(a, b T) int {
+			return cmp.Or(cmp.Compare(name(a), name(b)), cmp.Compare(id(a), id(b)))
+		}
+	}
+	slices.SortStableFunc(items, order)
+	if q.limit > 0 && len(items) > q.limit {
+		items = items[:q.limit]
+	}
+	return items
+}
+
+func inRange(n, min, max int) bool {
+	return n >= min && (max == 0 || n <= max)
+}
+
+// parseHardwareVersion returns the number of a hardware version such as
+// "vmx-14".
+func parseHardwareVersion(version string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimPrefix(version, "vmx-"))
+	return n, err == nil
+}
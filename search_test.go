@@ -0,0 +1,93 @@
+package iland_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+func TestSearchVirtualMachines(t *testing.T) {
+	srv := ilandtest.NewServer()
+	defer srv.Close()
+	company := srv.AddCompany(iland.Company{Name: "acme"})
+	dal := srv.AddOrg(iland.Org{Name: "dal", CompanyID: company.ID, LocationID: "dal02.ilandcloud.com"})
+	lon := srv.AddOrg(iland.Org{Name: "lon", CompanyID: company.ID, LocationID: "lon02.ilandcloud.com"})
+	dalVApp := srv.AddVApp(iland.VApp{VdcID: srv.AddVdc(iland.Vdc{OrgID: dal.ID}).ID})
+	lonVApp := srv.AddVApp(iland.VApp{VdcID: srv.AddVdc(iland.Vdc{OrgID: lon.ID}).ID})
+	for _, vm := range []iland.VirtualMachine{
+		{ID: "vm-win13", Name: "win13", VAppID: dalVApp.ID, Status: "POWERED_OFF", OperatingSystemDescription: "Microsoft Windows Server 2016", HardwareVersion: "vmx-13"},
+		{ID: "vm-win11", Name: "win11", VAppID: lonVApp.ID, Status: "POWERED_OFF", OperatingSystemDescription: "Microsoft Windows Server 2019", HardwareVersion: "vmx-11"},
+		{ID: "vm-win14", Name: "win14", VAppID: lonVApp.ID, Status: "POWERED_OFF", OperatingSystemDescription: "Microsoft Windows Server 2019", HardwareVersion: "vmx-14"},
+		{ID: "vm-winx", Name: "winx", VAppID: dalVApp.ID, Status: "POWERED_OFF", OperatingSystemDescription: "Windows", HardwareVersion: "unknown"},
+		{ID: "vm-linux", Name: "linux", VAppID: dalVApp.ID, Status: "POWERED_ON", OperatingSystemDescription: "Ubuntu", HardwareVersion: "vmx-10"},
+		// Two VMs with the same name are ordered by ID.
+		{ID: "vm-dup-b", Name: "dup", VAppID: lonVApp.ID, Status: "POWERED_ON", HardwareVersion: "vmx-19"},
+		{ID: "vm-dup-a", Name: "dup", VAppID: dalVApp.ID, Status: "POWERED_ON", HardwareVersion: "vmx-19"},
+	} {
+		srv.AddVirtualMachine(vm)
+	}
+	srv.SetMetadata("vm-win13", []iland.Metadata{{Key: "tier", Value: 14}, {Key: "env", Value: "prod"}})
+	srv.SetMetadata("vm-win11", []iland.Metadata{{Key: "tier", Value: "14"}})
+	srv.SetMetadata("vm-win14", []iland.Metadata{{Key: "tier", Value: 15}})
+	c, err := srv.NewClient(iland.WithRetryPolicy(iland.NoRetryPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	windows := iland.VirtualMachineQuery{}.Status("POWERED_OFF").OperatingSystem("windows")
+
+	tests := []struct {
+		name  string
+		query iland.VirtualMachineQuery
+		// failOrg is the org whose VM list fails, if any.
+		failOrg string
+		want    []string
+	}{
+		{name: "every VM by name then ID", query: iland.VirtualMachineQuery{}, want: []string{"vm-dup-a", "vm-dup-b", "vm-linux", "vm-win11", "vm-win13", "vm-win14", "vm-winx"}},
+		{name: "status and OS", query: windows, want: []string{"vm-win11", "vm-win13", "vm-win14", "vm-winx"}},
+		{name: "hardware version skips unparsable", query: windows.HardwareVersionBelow(14), want: []string{"vm-win11", "vm-win13"}},
+		{name: "unparsable never matches", query: iland.VirtualMachineQuery{}.HardwareVersionBelow(1000), want: []string{"vm-dup-a", "vm-dup-b", "vm-linux", "vm-win11", "vm-win13", "vm-win14"}},
+		{name: "location", query: windows.Location(lon.LocationID), want: []string{"vm-win11", "vm-win14"}},
+		{name: "metadata string matches number", query: iland.VirtualMachineQuery{}.Metadata("tier", "14"), want: []string{"vm-win11", "vm-win13"}},
+		{name: "metadata number matches string", query: iland.VirtualMachineQuery{}.Metadata("tier", 14), want: []string{"vm-win11", "vm-win13"}},
+		{name: "metadata key only", query: iland.VirtualMachineQuery{}.Metadata("env", nil), want: []string{"vm-win13"}},
+		{name: "every metadata entry must match", query: iland.VirtualMachineQuery{}.Metadata("tier", 14).Metadata("env", "prod"), want: []string{"vm-win13"}},
+		{
+			name:  "custom order and limit",
+			query: windows.SortBy(func(a, b iland.VirtualMachine) int { return -strings.Compare(a.Name, b.Name) }).Limit(2),
+			want:  []string{"vm-winx", "vm-win14"},
+		},
+		{name: "failing org", query: windows, failOrg: lon.ID, want: []string{"vm-win13", "vm-winx"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.failOrg != "" {
+				remove := srv.InjectFault(ilandtest.Fault{Path: "/v1/orgs/" + tt.failOrg + "/vms", Status: 500})
+				defer remove()
+			}
+			vms, err := c.Company().SearchVirtualMachines(context.Background(), company.ID, tt.query)
+			if tt.failOrg != "" {
+				var partial *iland.PartialError
+				if !errors.As(err, &partial) {
+					t.Fatalf("err = %v, want *PartialError", err)
+				}
+				if _, ok := partial.Errors[tt.failOrg]; !ok || len(partial.Errors) != 1 {
+					t.Errorf("PartialError.Errors = %v, want only %s", partial.Errors, tt.failOrg)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, vm := range vms {
+				got = append(got, vm.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
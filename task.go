@@ -1,7 +1,12 @@
 package iland
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"sync"
 	"time"
 )
 
@@ -24,6 +29,15 @@ type Task struct {
 	LocationID   string `json:"location_id"`
 	StartTime    int    `json:"start_time"`
 	EndTime      int    `json:"end_time"`
+	// Question and Choices are set while Status is WaitingOnUser.
+	Question string       `json:"question"`
+	Choices  []TaskChoice `json:"choices"`
+}
+
+// TaskChoice is a possible answer to a task waiting on user input.
+type TaskChoice struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
 }
 
 type taskService struct {
@@ -31,8 +45,13 @@ type taskService struct {
 }
 
 func (s *taskService) Get(taskID string) (Task, error) {
+	return s.GetContext(context.Background(), taskID)
+}
+
+func (s *taskService) GetContext(ctx context.Context, taskID string) (Task, error) {
+	ctx = withOperation(ctx, "Task.Get", taskID)
 	task := Task{}
-	err := s.client.getObject(fmt.Sprintf("/v1/tasks/%s", taskID), &task)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/tasks/%s", taskID), &task)
 	if err != nil {
 		return Task{}, err
 	}
@@ -40,20 +59,303 @@ func (s *taskService) Get(taskID string) (Task, error) {
 }
 
 func (s *taskService) Track(taskID string) (Task, error) {
+	return s.TrackContext(context.Background(), taskID)
+}
+
+// TrackContext waits for the task to complete, polling with the default
+// WaitOptions. Unlike Wait, a task that ends in error or is cancelled is
+// returned without an error, so check its Status; errors are only returned
+// when the task could not be fetched or ctx is done.
+func (s *taskService) TrackContext(ctx context.Context, taskID string) (Task, error) {
+	ctx = withOperation(ctx, "Task.Track", taskID)
+	return trackResult(s.Wait(ctx, taskID, WaitOptions{}))
+}
+
+// WaitOptions controls how Wait polls a task. The zero value polls every 2
+// seconds, backing off by 1.5x up to 30 seconds, with no timeout.
+type WaitOptions struct {
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+	// Multiplier grows the poll interval after each poll. Values below 1
+	// are treated as 1.
+	Multiplier float64
+	// Timeout bounds the whole wait, in addition to any ctx deadline.
+	Timeout time.Duration
+	// OnProgress, if set, is called whenever the task's status or progress
+	// changes. WaitAll and WaitAny never call it concurrently.
+	OnProgress func(Task)
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = 2 * time.Second
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = 30 * time.Second
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = o.PollInterval
+	}
+	if o.Multiplier == 0 {
+		o.Multiplier = 1.5
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = 1
+	}
+	return o
+}
+
+// backoff returns the poll interval that follows interval.
+func (o WaitOptions) backoff(interval time.Duration) time.Duration {
+	interval = time.Duration(float64(interval) * o.Multiplier)
+	if interval > o.MaxPollInterval {
+		interval = o.MaxPollInterval
+	}
+	return interval
+}
+
+// Wait polls the task until it completes. It returns a *TaskError along with
+// the task if it ended in error or was cancelled. Each poll is retried under
+// the client's RetryPolicy, and the wait fails if a poll still fails. If ctx
+// is done or the timeout passes first, the last task seen is returned with
+// ctx's error.
+func (s *taskService) Wait(ctx context.Context, taskID string, opts WaitOptions) (Task, error) {
+	ctx = withOperation(ctx, "Task.Wait", taskID)
+	opts = opts.withDefaults()
+	interval := opts.PollInterval
+	return waitTask(ctx, taskID, opts, s.GetContext, nil, func(Task, bool) time.Duration {
+		delay := interval
+		interval = opts.backoff(interval)
+		return delay
+	})
+}
+
+// waitTask fetches a task with get until it completes, and returns as Wait
+// does. Between fetches it sleeps for the delay returned by next, or until
+// wake is signalled; next is told whether the previous sleep was cut short
+// by wake. A nil wake never fires. opts must already have its defaults.
+func waitTask(ctx context.Context, taskID string, opts WaitOptions, get func(context.Context, string) (Task, error), wake <-chan struct{}, next func(task Task, woken bool) time.Duration) (Task, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	last := Task{}
+	woken := false
 	for {
-		time.Sleep(time.Second * 5)
-		task, err := s.Get(taskID)
+		task, err := get(ctx, taskID)
 		if err != nil {
-			return Task{}, err
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return last, err
+		}
+		if opts.OnProgress != nil && (task.Status != last.Status || task.Progress != last.Progress) {
+			opts.OnProgress(task)
 		}
-		if !task.Active && task.Synced {
-			return task, nil
+		last = task
+		if taskDone(task) {
+			return task, taskErr(task)
 		}
+		timer := time.NewTimer(next(task, woken))
+		woken = false
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, ctx.Err()
+		case <-wake:
+			woken = true
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// WaitAll waits for every task concurrently and returns them in the order
+// given. Tasks that failed are joined into the returned error as
+// *TaskError values; any other error stops the wait.
+func (s *taskService) WaitAll(ctx context.Context, taskIDs []string, opts WaitOptions) ([]Task, error) {
+	return waitAll(ctx, taskIDs, opts, s.Wait)
+}
+
+// WaitAny waits for the first of the tasks to complete and returns it, with
+// a *TaskError if it failed. If the wait for a task fails, for example
+// because polling it failed once retries were exhausted, WaitAny keeps
+// waiting for the others. It fails only when every wait has failed, with
+// their errors joined, or when ctx is done.
+func (s *taskService) WaitAny(ctx context.Context, taskIDs []string, opts WaitOptions) (Task, error) {
+	return waitAny(ctx, taskIDs, opts, s.Wait)
+}
+
+type waitFunc func(ctx context.Context, taskID string, opts WaitOptions) (Task, error)
+
+func waitAll(ctx context.Context, taskIDs []string, opts WaitOptions, wait waitFunc) ([]Task, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts.OnProgress = serialize(opts.OnProgress)
+	tasks := make([]Task, len(taskIDs))
+	errs := make([]error, len(taskIDs))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		fatalErr error
+	)
+	for i, taskID := range taskIDs {
+		wg.Add(1)
+		go func(i int, taskID string) {
+			defer wg.Done()
+			tasks[i], errs[i] = wait(ctx, taskID, opts)
+			var taskErr *TaskError
+			if errs[i] != nil && !errors.As(errs[i], &taskErr) {
+				mu.Lock()
+				if fatalErr == nil {
+					fatalErr = errs[i]
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}(i, taskID)
+	}
+	wg.Wait()
+	if fatalErr != nil {
+		return tasks, fatalErr
+	}
+	return tasks, errors.Join(errs...)
+}
+
+func waitAny(ctx context.Context, taskIDs []string, opts WaitOptions, wait waitFunc) (Task, error) {
+	if len(taskIDs) == 0 {
+		return Task{}, errors.New("iland: WaitAny requires at least one task")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opts.OnProgress = serialize(opts.OnProgress)
+	type result struct {
+		task Task
+		err  error
+	}
+	results := make(chan result, len(taskIDs))
+	for _, taskID := range taskIDs {
+		go func(taskID string) {
+			task, err := wait(ctx, taskID, opts)
+			results <- result{task, err}
+		}(taskID)
+	}
+	errs := make([]error, 0, len(taskIDs))
+	for range taskIDs {
+		r := <-results
+		var taskErr *TaskError
+		if r.err == nil || errors.As(r.err, &taskErr) {
+			return r.task, r.err
+		}
+		errs = append(errs, r.err)
+	}
+	if ctx.Err() != nil {
+		return Task{}, ctx.Err()
+	}
+	return Task{}, errors.Join(errs...)
+}
+
+func taskDone(task Task) bool {
+	return !task.Active && task.Synced
+}
+
+// trackResult turns the *TaskError of a wait back into the completed task,
+// which is what Track has always returned.
+func trackResult(task Task, err error) (Task, error) {
+	var taskErr *TaskError
+	if errors.As(err, &taskErr) {
+		return taskErr.Task, nil
+	}
+	return task, err
+}
+
+func taskErr(task Task) error {
+	if task.Status == Error || task.Status == Cancelled {
+		return &TaskError{Task: task}
+	}
+	return nil
+}
+
+func serialize(fn func(Task)) func(Task) {
+	if fn == nil {
+		return nil
+	}
+	var mu sync.Mutex
+	return func(task Task) {
+		mu.Lock()
+		defer mu.Unlock()
+		fn(task)
 	}
 }
 
 func (s *taskService) Query(entityID, entityType string, childTasks bool) ([]Task, error) {
+	return s.QueryContext(context.Background(), entityID, entityType, childTasks)
+}
+
+func (s *taskService) QueryContext(ctx context.Context, entityID, entityType string, childTasks bool) ([]Task, error) {
+	ctx = withOperation(ctx, "Task.Query", entityID)
 	tasks := []Task{}
-	err := s.client.getObject(fmt.Sprintf("/v1/tasks?entityUuid=%s&entityType=%s&includeDescendantTasks=%t&sync=false&limit=10", entityID, entityType, childTasks), &tasks)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/tasks?entityUuid=%s&entityType=%s&includeDescendantTasks=%t&sync=false&limit=10", entityID, entityType, childTasks), &tasks)
 	return tasks, err
 }
+
+// List returns every task on the entity, and on its descendants if
+// childTasks is set. Unlike Query it is not limited to the latest ten.
+func (s *taskService) List(ctx context.Context, entityID, entityType string, childTasks bool, opts ListOptions) iter.Seq2[Task, error] {
+	ctx = withOperation(ctx, "Task.List", entityID)
+	query := TaskQuery{}.Entity(entityID, entityType)
+	if childTasks {
+		query = query.WithDescendants()
+	}
+	return s.Search(ctx, query, opts)
+}
+
+// Search returns every task matching query. Tasks are fetched a page at a
+// time as the iterator advances, opts.PageSize at once.
+func (s *taskService) Search(ctx context.Context, query TaskQuery, opts ListOptions) iter.Seq2[Task, error] {
+	ctx = withOperation(ctx, "Task.Search", query.entityID)
+	return paginate[Task](ctx, s.client, query.endpoint, opts)
+}
+
+func (s *taskService) postAction(ctx context.Context, taskID, action string, params []byte) (Task, error) {
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/tasks/%s/actions/%s", taskID, action), params)
+	if err != nil {
+		return Task{}, err
+	}
+	task := Task{}
+	err = unmarshalBody(resp, &task)
+	if err != nil {
+		return Task{}, err
+	}
+	return task, nil
+}
+
+func (s *taskService) Cancel(taskID string) (Task, error) {
+	return s.CancelContext(context.Background(), taskID)
+}
+
+func (s *taskService) CancelContext(ctx context.Context, taskID string) (Task, error) {
+	ctx = withOperation(ctx, "Task.Cancel", taskID)
+	return s.postAction(ctx, taskID, "cancel", []byte{})
+}
+
+func (s *taskService) Respond(taskID, choiceID string) (Task, error) {
+	return s.RespondContext(context.Background(), taskID, choiceID)
+}
+
+// RespondContext answers a task waiting on user input with one of its
+// Choices.
+func (s *taskService) RespondContext(ctx context.Context, taskID, choiceID string) (Task, error) {
+	ctx = withOperation(ctx, "Task.Respond", taskID)
+	params := struct {
+		ChoiceID string `json:"choice_id"`
+	}{
+		ChoiceID: choiceID,
+	}
+	data, err := json.Marshal(&params)
+	if err != nil {
+		return Task{}, err
+	}
+	return s.postAction(ctx, taskID, "respond", data)
+}
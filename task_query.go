@@ -0,0 +1,120 @@
+package iland
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TaskQuery selects tasks for TaskService.Search. It is built by chaining
+// methods on a zero TaskQuery, each of which returns a modified copy:
+//
+//	q := iland.TaskQuery{}.
+//		Entity(orgID, iland.EntityIaasOrganization).
+//		WithDescendants().
+//		Status(iland.Running, iland.Queued).
+//		Since(time.Now().Add(-time.Hour))
+type TaskQuery struct {
+	entityID    string
+	entityType  string
+	descendants bool
+	sync        bool
+	statuses    []string
+	operations  []string
+	user        string
+	locationID  string
+	since       time.Time
+	until       time.Time
+}
+
+// Entity restricts the query to tasks on one entity.
+func (q TaskQuery) Entity(entityID, entityType string) TaskQuery {
+	q.entityID = entityID
+	q.entityType = entityType
+	return q
+}
+
+// WithDescendants includes tasks on the entity's descendants.
+func (q TaskQuery) WithDescendants() TaskQuery {
+	q.descendants = true
+	return q
+}
+
+// Synced asks the API to sync the tasks with the underlying platform
+// before answering.
+func (q TaskQuery) Synced() TaskQuery {
+	q.sync = true
+	return q
+}
+
+// Status restricts the query to tasks with one of the statuses, such as
+// Running or Error.
+func (q TaskQuery) Status(statuses ...string) TaskQuery {
+	q.statuses = append(append([]string{}, q.statuses...), statuses...)
+	return q
+}
+
+// Operation restricts the query to tasks of one of the operations.
+func (q TaskQuery) Operation(operations ...string) TaskQuery {
+	q.operations = append(append([]string{}, q.operations...), operations...)
+	return q
+}
+
+// User restricts the query to tasks started by username.
+func (q TaskQuery) User(username string) TaskQuery {
+	q.user = username
+	return q
+}
+
+// Location restricts the query to tasks in one location.
+func (q TaskQuery) Location(locationID string) TaskQuery {
+	q.locationID = locationID
+	return q
+}
+
+// Since restricts the query to tasks started at or after t.
+func (q TaskQuery) Since(t time.Time) TaskQuery {
+	q.since = t
+	return q
+}
+
+// Until restricts the query to tasks started before t.
+func (q TaskQuery) Until(t time.Time) TaskQuery {
+	q.until = t
+	return q
+}
+
+// endpoint returns the URL of one page of the query's tasks, limit at a
+// time.
+func (q TaskQuery) endpoint(page, limit int) string {
+	query := url.Values{}
+	if q.entityID != "" {
+		query.Set("entityUuid", q.entityID)
+	}
+	if q.entityType != "" {
+		query.Set("entityType", q.entityType)
+	}
+	query.Set("includeDescendantTasks", strconv.FormatBool(q.descendants))
+	query.Set("sync", strconv.FormatBool(q.sync))
+	for _, status := range q.statuses {
+		query.Add("status", status)
+	}
+	for _, operation := range q.operations {
+		query.Add("operation", operation)
+	}
+	if q.user != "" {
+		query.Set("username", q.user)
+	}
+	if q.locationID != "" {
+		query.Set("locationId", q.locationID)
+	}
+	if !q.since.IsZero() {
+		query.Set("startDate", strconv.FormatInt(q.since.UnixMilli(), 10))
+	}
+	if !q.until.IsZero() {
+		query.Set("endDate", strconv.FormatInt(q.until.UnixMilli(), 10))
+	}
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("page", strconv.Itoa(page))
+	return "/v1/tasks?" + query.Encode()
+}
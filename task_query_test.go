@@ -0,0 +1,62 @@
+package iland_test
+
+import (
+	"context"
+	"testing"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+func TestTaskSearchPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		tasks     int
+		pageSize  int
+		wantPages int
+	}{
+		{name: "one page", tasks: 3, pageSize: 10, wantPages: 1},
+		{name: "several pages", tasks: 25, pageSize: 10, wantPages: 3},
+		{name: "exact multiple", tasks: 20, pageSize: 10, wantPages: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ilandtest.NewServer(ilandtest.WithTaskDuration(0))
+			defer srv.Close()
+			company := srv.AddCompany(iland.Company{})
+			org := srv.AddOrg(iland.Org{CompanyID: company.ID, LocationID: "dal02.ilandcloud.com"})
+			vdc := srv.AddVdc(iland.Vdc{OrgID: org.ID})
+			vapp := srv.AddVApp(iland.VApp{VdcID: vdc.ID})
+			vm := srv.AddVirtualMachine(iland.VirtualMachine{VAppID: vapp.ID})
+			c, err := srv.NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < tt.tasks; i++ {
+				if _, err := c.VirtualMachine().PowerOn(vm.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			before := len(srv.Requests())
+			query := iland.TaskQuery{}.Entity(vm.ID, iland.EntityIaasVm)
+			tasks, err := iland.Collect(c.Task().Search(context.Background(), query, iland.ListOptions{PageSize: tt.pageSize}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tasks) != tt.tasks {
+				t.Errorf("got %d tasks, want %d", len(tasks), tt.tasks)
+			}
+			seen := map[string]bool{}
+			for _, task := range tasks {
+				if seen[task.ID] {
+					t.Errorf("task %s returned twice", task.ID)
+				}
+				seen[task.ID] = true
+			}
+			if pages := len(srv.Requests()) - before; pages != tt.wantPages {
+				t.Errorf("made %d requests, want %d", pages, tt.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeWait returns a waitFunc that finishes each task after its delay with
+// its error, or returns ctx's error if ctx is done first. Tasks without a
+// delay wait for ctx.
+func fakeWait(delays map[string]time.Duration, errs map[string]error) waitFunc {
+	return func(ctx context.Context, taskID string, opts WaitOptions) (Task, error) {
+		delay, ok := delays[taskID]
+		if !ok {
+			<-ctx.Done()
+			return Task{}, ctx.Err()
+		}
+		select {
+		case <-ctx.Done():
+			return Task{}, ctx.Err()
+		case <-time.After(delay):
+		}
+		return Task{ID: taskID}, errs[taskID]
+	}
+}
+
+var errPoll = errors.New("poll failed")
+
+func TestWaitAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		delays  map[string]time.Duration
+		errs    map[string]error
+		timeout time.Duration
+		want    string
+		wantErr error
+	}{
+		{name: "first to finish", delays: map[string]time.Duration{"a": 50 * time.Millisecond, "b": time.Millisecond}, want: "b"},
+		{name: "task error", delays: map[string]time.Duration{"a": time.Millisecond}, errs: map[string]error{"a": &TaskError{}}, want: "a", wantErr: &TaskError{}},
+		{name: "poll error does not stop the others", delays: map[string]time.Duration{"a": time.Millisecond, "b": 20 * time.Millisecond}, errs: map[string]error{"a": errPoll}, want: "b"},
+		{name: "every wait failed", delays: map[string]time.Duration{"a": time.Millisecond, "b": time.Millisecond}, errs: map[string]error{"a": errPoll, "b": errPoll}, wantErr: errPoll},
+		{name: "cancelled", delays: map[string]time.Duration{"a": time.Millisecond}, errs: map[string]error{"a": errPoll}, timeout: 20 * time.Millisecond, wantErr: context.DeadlineExceeded},
+		{name: "cancelled before any", timeout: 20 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			task, err := waitAny(ctx, []string{"a", "b"}, WaitOptions{}, fakeWait(tt.delays, tt.errs))
+			if task.ID != tt.want {
+				t.Errorf("task = %q, want %q", task.ID, tt.want)
+			}
+			if !errorMatches(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		delays  map[string]time.Duration
+		errs    map[string]error
+		timeout time.Duration
+		wantErr error
+	}{
+		{name: "all succeed", delays: map[string]time.Duration{"a": time.Millisecond, "b": 5 * time.Millisecond}},
+		{name: "task errors are joined", delays: map[string]time.Duration{"a": time.Millisecond, "b": time.Millisecond}, errs: map[string]error{"b": &TaskError{}}, wantErr: &TaskError{}},
+		{name: "poll error stops the wait", delays: map[string]time.Duration{"a": time.Millisecond}, errs: map[string]error{"a": errPoll}, wantErr: errPoll},
+		{name: "cancelled", delays: map[string]time.Duration{"a": time.Millisecond}, timeout: 20 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			done := make(chan struct{})
+			var err error
+			go func() {
+				defer close(done)
+				_, err = waitAll(ctx, []string{"a", "b"}, WaitOptions{}, fakeWait(tt.delays, tt.errs))
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("waitAll did not return")
+			}
+			if !errorMatches(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// errorMatches reports whether err is nil when want is, or matches want by
+// errors.Is or, for a *TaskError, errors.As.
+func errorMatches(err, want error) bool {
+	var taskErr *TaskError
+	if errors.As(want, &taskErr) {
+		return errors.As(err, &taskErr)
+	}
+	if want == nil {
+		return err == nil
+	}
+	return errors.Is(err, want)
+}
+
+func TestTrack(t *testing.T) {
+	for _, status := range []string{Success, Error, Cancelled} {
+		t.Run(status, func(t *testing.T) {
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(Task{ID: "task-1", Status: status, Synced: true})
+			}), WithRetryPolicy(NoRetryPolicy))
+
+			task, err := c.Task().Track("task-1")
+			if err != nil {
+				t.Fatalf("Track: err = %v, want nil", err)
+			}
+			if task.Status != status {
+				t.Errorf("Track: status = %q, want %q", task.Status, status)
+			}
+
+			_, err = c.Task().Wait(context.Background(), "task-1", WaitOptions{})
+			var taskErr *TaskError
+			if failed := status != Success; errors.As(err, &taskErr) != failed {
+				t.Errorf("Wait: err = %v, want *TaskError: %t", err, failed)
+			}
+		})
+	}
+}
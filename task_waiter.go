@@ -0,0 +1,223 @@
+package iland
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultSafetyPollInterval = time.Minute
+
+// TaskWaiterOptions configures a TaskWaiter.
+type TaskWaiterOptions struct {
+	// SafetyPollInterval is how often each wait polls while the event stream
+	// is connected, to recover from events missed during a reconnect. It
+	// defaults to one minute.
+	SafetyPollInterval time.Duration
+}
+
+// TaskWaiter is a TaskService whose waits are resolved by the event stream
+// instead of polling. All waits share one websocket connection; while it is
+// down they fall back to polling with their WaitOptions.
+type TaskWaiter struct {
+	TaskService
+
+	opts   TaskWaiterOptions
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	connected bool
+	waiters   map[string]map[chan struct{}]struct{}
+}
+
+// eventSubscriber is implemented by the client, which reports the state of
+// its event stream connection.
+type eventSubscriber interface {
+	subscribeEvents(ctx context.Context, companyID string, onEvent func(Event), onState func(connected bool))
+}
+
+// NewTaskWaiter starts listening to the event stream of companyID (every
+// company the user can see if empty) and returns a waiter. Close it to
+// disconnect.
+func NewTaskWaiter(console ConsoleService, companyID string, opts TaskWaiterOptions) *TaskWaiter {
+	if opts.SafetyPollInterval <= 0 {
+		opts.SafetyPollInterval = defaultSafetyPollInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &TaskWaiter{
+		TaskService: console.Task(),
+		opts:        opts,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		waiters:     map[string]map[chan struct{}]struct{}{},
+	}
+	go func() {
+		defer close(w.done)
+		subscribeEvents(ctx, console, companyID, w.notify, w.setConnected)
+	}()
+	return w
+}
+
+// Close disconnects from the event stream. Waits in progress continue by
+// polling.
+func (w *TaskWaiter) Close() {
+	w.cancel()
+	<-w.done
+	w.setConnected(false)
+}
+
+// Connected reports whether the event stream is currently connected.
+func (w *TaskWaiter) Connected() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.connected
+}
+
+func (w *TaskWaiter) Track(taskID string) (Task, error) {
+	return w.TrackContext(context.Background(), taskID)
+}
+
+// TrackContext is TaskService.TrackContext using an event-driven wait.
+func (w *TaskWaiter) TrackContext(ctx context.Context, taskID string) (Task, error) {
+	ctx = withOperation(ctx, "Task.Track", taskID)
+	return trackResult(w.Wait(ctx, taskID, WaitOptions{}))
+}
+
+// Wait waits for the task to complete, fetching it when an event for the
+// task or its entity arrives, and otherwise polling only every
+// SafetyPollInterval while the stream is connected. It returns like
+// TaskService.Wait.
+func (w *TaskWaiter) Wait(ctx context.Context, taskID string, opts WaitOptions) (Task, error) {
+	ctx = withOperation(ctx, "Task.Wait", taskID)
+	opts = opts.withDefaults()
+	wake := make(chan struct{}, 1)
+	w.register(taskID, wake)
+	defer w.unregister(taskID, wake)
+	entityID := ""
+	defer func() {
+		if entityID != "" {
+			w.unregister(entityID, wake)
+		}
+	}()
+	interval := opts.PollInterval
+	return waitTask(ctx, taskID, opts, w.TaskService.GetContext, wake, func(task Task, woken bool) time.Duration {
+		if entityID == "" && task.EntityID != "" {
+			entityID = task.EntityID
+			w.register(entityID, wake)
+		}
+		delay := w.opts.SafetyPollInterval
+		if !w.Connected() {
+			delay = interval
+			interval = opts.backoff(interval)
+		}
+		if woken && delay > opts.PollInterval {
+			// An event arrived, or the stream reconnected, but the task was
+			// not yet complete, most likely because it had not synced; look
+			// again shortly.
+			delay = opts.PollInterval
+		}
+		return delay
+	})
+}
+
+// WaitAll is TaskService.WaitAll using event-driven waits.
+func (w *TaskWaiter) WaitAll(ctx context.Context, taskIDs []string, opts WaitOptions) ([]Task, error) {
+	return waitAll(ctx, taskIDs, opts, w.Wait)
+}
+
+// WaitAny is TaskService.WaitAny using event-driven waits.
+func (w *TaskWaiter) WaitAny(ctx context.Context, taskIDs []string, opts WaitOptions) (Task, error) {
+	return waitAny(ctx, taskIDs, opts, w.Wait)
+}
+
+func (w *TaskWaiter) register(key string, wake chan struct{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.waiters[key] == nil {
+		w.waiters[key] = map[chan struct{}]struct{}{}
+	}
+	w.waiters[key][wake] = struct{}{}
+}
+
+func (w *TaskWaiter) unregister(key string, wake chan struct{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.waiters[key], wake)
+	if len(w.waiters[key]) == 0 {
+		delete(w.waiters, key)
+	}
+}
+
+func (w *TaskWaiter) notify(event Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, key := range []string{event.TaskID, event.EntityID} {
+		if key == "" {
+			continue
+		}
+		for wake := range w.waiters[key] {
+			signal(wake)
+		}
+	}
+}
+
+// setConnected records the stream state, waking every wait when it changes
+// so that they switch between polling and listening.
+func (w *TaskWaiter) setConnected(connected bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.connected == connected {
+		return
+	}
+	w.connected = connected
+	for _, wakes := range w.waiters {
+		for wake := range wakes {
+			signal(wake)
+		}
+	}
+}
+
+// signal wakes a wait without blocking; a pending wake-up is enough.
+func signal(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *client) subscribeEvents(ctx context.Context, companyID string, onEvent func(Event), onState func(connected bool)) {
+	stream := c.newEventStream(ctx, companyID, EventStreamOptions{})
+	stream.onState = func(state EventStreamState) {
+		onState(state == StreamConnected)
+	}
+	go stream.run(nil, "")
+	for event := range stream.Events() {
+		onEvent(event)
+	}
+}
+
+// subscribeEvents follows console's event stream until ctx is done. Consoles
+// other than the client, such as mocks, are followed through
+// StreamEventsContext.
+func subscribeEvents(ctx context.Context, console ConsoleService, companyID string, onEvent func(Event), onState func(connected bool)) {
+	if subscriber, ok := console.(eventSubscriber); ok {
+		subscriber.subscribeEvents(ctx, companyID, onEvent, onState)
+		return
+	}
+	for {
+		events, err := console.StreamEventsContext(ctx, companyID)
+		if err == nil && events != nil {
+			onState(true)
+			for event := range events {
+				onEvent(event)
+			}
+			onState(false)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second * 5):
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package iland_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+	"github.com/ilanddev/go-sdk/ilandtest"
+)
+
+func TestTaskWaiterWait(t *testing.T) {
+	tests := []struct {
+		name string
+		// otherCompany makes the waiter listen to another company, so the
+		// task's event never reaches it.
+		otherCompany bool
+		safetyPoll   time.Duration
+	}{
+		// Polling is too slow to finish in time, so only the task's event
+		// can end the wait.
+		{name: "woken by event", safetyPoll: time.Hour},
+		// No event arrives, so only the safety poll can end the wait.
+		{name: "safety poll without events", otherCompany: true, safetyPoll: 50 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := ilandtest.NewServer(ilandtest.WithTaskDuration(100 * time.Millisecond))
+			defer srv.Close()
+			company := srv.AddCompany(iland.Company{})
+			org := srv.AddOrg(iland.Org{CompanyID: company.ID, LocationID: "dal02.ilandcloud.com"})
+			vdc := srv.AddVdc(iland.Vdc{OrgID: org.ID})
+			vapp := srv.AddVApp(iland.VApp{VdcID: vdc.ID})
+			vm := srv.AddVirtualMachine(iland.VirtualMachine{VAppID: vapp.ID})
+			c, err := srv.NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			streamCompany := company.ID
+			if tt.otherCompany {
+				streamCompany = srv.AddCompany(iland.Company{}).ID
+			}
+			w := iland.NewTaskWaiter(c, streamCompany, iland.TaskWaiterOptions{SafetyPollInterval: tt.safetyPoll})
+			defer w.Close()
+			waitFor(t, "event stream", w.Connected)
+
+			task, err := c.VirtualMachine().PowerOff(vm.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			got, err := w.Wait(ctx, task.ID, iland.WaitOptions{PollInterval: time.Hour})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != iland.Success {
+				t.Errorf("status = %q, want %q", got.Status, iland.Success)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package iland
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	ExpiresIn    int64     `json:"expires_in"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token has an access token that does not expire
+// within the next minute. A zero Expiry never expires.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+const tokenExpiryDelta = 60 * time.Second
+
+type TokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	GrantType    string `json:"grant_type"`
+}
+
+type RefreshTokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+	GrantType    string `json:"grant_type"`
+}
+
+// TokenSource supplies access tokens with the same semantics as
+// golang.org/x/oauth2.TokenSource: each call returns a usable token or an
+// error, and implementations must be safe for concurrent use. The client
+// caches the returned token until it expires or the API rejects it.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is implemented by token sources that can abort a token
+// request when the calling context is cancelled. All built-in sources
+// implement it.
+type ContextTokenSource interface {
+	TokenSource
+	TokenContext(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function, such as the Token method of an
+// oauth2.TokenSource wrapped to convert its result, to a TokenSource.
+type TokenSourceFunc func() (*Token, error)
+
+func (f TokenSourceFunc) Token() (*Token, error) {
+	return f()
+}
+
+// TokenConfig holds the settings shared by the built-in token sources.
+// AuthURL and HTTPClient default to the iland token endpoint and
+// http.DefaultClient.
+type TokenConfig struct {
+	AuthURL      string
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+	UserAgent    string
+}
+
+// PasswordTokenSource returns a source using the resource owner password
+// grant. Once a refresh token has been issued it is tried first.
+func PasswordTokenSource(config TokenConfig, username, password string) TokenSource {
+	return &passwordTokenSource{config: config, username: username, password: password}
+}
+
+// ClientCredentialsTokenSource returns a source using the client
+// credentials grant.
+func ClientCredentialsTokenSource(config TokenConfig) TokenSource {
+	return &clientCredentialsTokenSource{config: config}
+}
+
+// RefreshTokenSource returns a source that exchanges refreshToken for
+// access tokens, keeping track of rotated refresh tokens.
+func RefreshTokenSource(config TokenConfig, refreshToken string) TokenSource {
+	return &refreshTokenSource{config: config, refreshToken: refreshToken}
+}
+
+// StaticTokenSource returns a source that always returns t. The token can
+// never be refreshed, so its Expiry is ignored and it is used until the API
+// rejects it with ErrUnauthorized.
+func StaticTokenSource(t *Token) TokenSource {
+	return &staticTokenSource{token: t}
+}
+
+// WithTokenSource sets the source used to obtain access tokens, replacing
+// the password grant configured by NewClient.
+func WithTokenSource(source TokenSource) Option {
+	return func(c *client) error {
+		if source == nil {
+			return errors.New("iland: token source must not be nil")
+		}
+		c.tokenSource = source
+		return nil
+	}
+}
+
+// WithUsername sets the username used by calls scoped to the current user,
+// such as GetCompanies and GetOrgs, when authenticating with a token source.
+func WithUsername(username string) Option {
+	return func(c *client) error {
+		c.username = username
+		return nil
+	}
+}
+
+type passwordTokenSource struct {
+	config   TokenConfig
+	username string
+	password string
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func (s *passwordTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *passwordTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	refreshToken := s.refreshToken
+	s.mu.Unlock()
+	if refreshToken != "" {
+		t, err := s.config.refresh(ctx, refreshToken)
+		if err == nil {
+			s.setRefreshToken(t.RefreshToken)
+			return t, nil
+		}
+	}
+	tokenRequest := TokenRequest{s.config.ClientID, s.config.ClientSecret, s.username, s.password, "password"}
+	form := url.Values{}
+	form.Add("username", tokenRequest.Username)
+	form.Add("password", tokenRequest.Password)
+	form.Add("grant_type", tokenRequest.GrantType)
+	t, err := s.config.exchange(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	s.setRefreshToken(t.RefreshToken)
+	return t, nil
+}
+
+func (s *passwordTokenSource) setRefreshToken(refreshToken string) {
+	s.mu.Lock()
+	s.refreshToken = refreshToken
+	s.mu.Unlock()
+}
+
+type clientCredentialsTokenSource struct {
+	config TokenConfig
+}
+
+func (s *clientCredentialsTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *clientCredentialsTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	form := url.Values{}
+	form.Add("grant_type", "client_credentials")
+	return s.config.exchange(ctx, form)
+}
+
+type refreshTokenSource struct {
+	config TokenConfig
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+func (s *refreshTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *refreshTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	refreshToken := s.refreshToken
+	s.mu.Unlock()
+	if refreshToken == "" {
+		return nil, errors.New("iland: no refresh token available")
+	}
+	t, err := s.config.refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken != "" {
+		s.mu.Lock()
+		s.refreshToken = t.RefreshToken
+		s.mu.Unlock()
+	}
+	return t, nil
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s *staticTokenSource) Token() (*Token, error) {
+	return s.token, nil
+}
+
+func (s *staticTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	return s.token, nil
+}
+
+func (config TokenConfig) refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	tokenRequest := RefreshTokenRequest{config.ClientID, config.ClientSecret, refreshToken, "refresh_token"}
+	form := url.Values{}
+	form.Add("refresh_token", tokenRequest.RefreshToken)
+	form.Add("grant_type", tokenRequest.GrantType)
+	t, err := config.exchange(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = refreshToken
+	}
+	return t, nil
+}
+
+func (config TokenConfig) exchange(ctx context.Context, form url.Values) (*Token, error) {
+	authURL := config.AuthURL
+	if authURL == "" {
+		authURL = defaultAuthURL
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	form.Set("client_id", config.ClientID)
+	form.Set("client_secret", config.ClientSecret)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if config.UserAgent != "" {
+		req.Header.Set("User-Agent", config.UserAgent)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError(resp)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var t Token
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return nil, err
+	}
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return &t, nil
+}
+
+func (c *client) tokenConfig() TokenConfig {
+	return TokenConfig{
+		AuthURL:      c.authURL,
+		ClientID:     c.clientID,
+		ClientSecret: c.clientSecret,
+		HTTPClient:   c.http,
+		UserAgent:    c.userAgent,
+	}
+}
+
+// tokenCall is an in-flight token fetch shared by every caller that needs
+// a token while it runs.
+type tokenCall struct {
+	done        chan struct{}
+	accessToken string
+	err         error
+}
+
+// accessToken returns a valid access token, fetching a new one from the
+// token source when necessary. Concurrent callers share a single in-flight
+// fetch.
+func (c *client) accessToken(ctx context.Context) (string, error) {
+	for {
+		c.tokenMu.Lock()
+		if c.token.AccessToken != "" && !c.isTokenExpired() {
+			accessToken := c.token.AccessToken
+			c.tokenMu.Unlock()
+			return accessToken, nil
+		}
+		call := c.tokenCall
+		if call == nil {
+			call = &tokenCall{done: make(chan struct{})}
+			c.tokenCall = call
+			hadToken := c.token.AccessToken != ""
+			c.tokenMu.Unlock()
+			token, err := c.fetchToken(ctx, hadToken)
+			c.tokenMu.Lock()
+			if err == nil {
+				c.token = *token
+				c.tokenExpiration = tokenExpiration(token)
+				call.accessToken = token.AccessToken
+			}
+			call.err = err
+			c.tokenCall = nil
+			c.tokenMu.Unlock()
+			close(call.done)
+			return call.accessToken, call.err
+		}
+		c.tokenMu.Unlock()
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-call.done:
+		}
+		// A fetch aborted by another caller's context is retried with ours.
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			continue
+		}
+		return call.accessToken, call.err
+	}
+}
+
+// invalidateToken forces the next request to obtain a new token, unless the
+// token has already been replaced since accessToken was used.
+func (c *client) invalidateToken(accessToken string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.token.AccessToken == accessToken {
+		c.tokenExpiration = time.Time{}
+	}
+}
+
+func (c *client) fetchToken(ctx context.Context, hadToken bool) (*Token, error) {
+	var token *Token
+	var err error
+	if source, ok := c.tokenSource.(ContextTokenSource); ok {
+		token, err = source.TokenContext(ctx)
+	} else {
+		token, err = c.tokenSource.Token()
+	}
+	if err == nil && (token == nil || token.AccessToken == "") {
+		err = errors.New("iland: token source returned an empty token")
+	}
+	if _, static := c.tokenSource.(*staticTokenSource); err == nil && static {
+		t := *token
+		t.Expiry = time.Time{}
+		token = &t
+	}
+	if err == nil && !token.Expiry.IsZero() && time.Now().After(token.Expiry) {
+		err = errors.New("iland: token source returned an expired token")
+	}
+	if err != nil {
+		if hadToken {
+			return nil, fmt.Errorf("Error refreshing iland cloud API token. %w", err)
+		}
+		return nil, fmt.Errorf("Error retrieving iland cloud API token. %w", err)
+	}
+	return token, nil
+}
+
+func tokenExpiration(t *Token) time.Time {
+	if t.Expiry.IsZero() {
+		return time.Now().Add(100 * 365 * 24 * time.Hour)
+	}
+	return t.Expiry.Add(-tokenExpiryDelta)
+}
+
+func (c *client) RefreshTokenIfNecessary() error {
+	return c.RefreshTokenIfNecessaryContext(context.Background())
+}
+
+func (c *client) RefreshTokenIfNecessaryContext(ctx context.Context) error {
+	_, err := c.accessToken(ctx)
+	return err
+}
+
+func (c *client) isTokenExpired() bool {
+	if time.Now().After(c.tokenExpiration) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package iland
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenCache persists a token between processes.
+type TokenCache interface {
+	// Load returns the cached token, or nil if nothing is cached.
+	Load() (*Token, error)
+	Save(t *Token) error
+}
+
+const (
+	tokenCacheVersion    = 1
+	tokenCacheSaltSize   = 16
+	tokenCacheIterations = 600000
+)
+
+// FileTokenCache stores a token in a file encrypted with AES-256-GCM. The
+// key is derived from a passphrase with PBKDF2-HMAC-SHA256 and a random
+// salt, which is stored in the file header.
+type FileTokenCache struct {
+	path       string
+	passphrase []byte
+	iterations int
+
+	mu   sync.Mutex
+	salt []byte
+	key  []byte
+}
+
+// NewFileTokenCache returns a cache stored at path and encrypted with a key
+// derived from passphrase.
+func NewFileTokenCache(path string, passphrase []byte) (*FileTokenCache, error) {
+	if path == "" {
+		return nil, errors.New("iland: token cache path must not be empty")
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("iland: token cache passphrase must not be empty")
+	}
+	return &FileTokenCache{path: path, passphrase: append([]byte{}, passphrase...), iterations: tokenCacheIterations}, nil
+}
+
+// DefaultTokenCachePath returns a per-user cache file path for the named
+// profile, e.g. ~/.cache/iland/default.token on Linux.
+func DefaultTokenCachePath(profile string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "iland", profile+".token"), nil
+}
+
+func (f *FileTokenCache) Load() (*Token, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 1+tokenCacheSaltSize || data[0] != tokenCacheVersion {
+		return nil, errors.New("iland: token cache is corrupt or from an unsupported version")
+	}
+	salt, data := data[1:1+tokenCacheSaltSize], data[1+tokenCacheSaltSize:]
+	gcm, err := f.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("iland: token cache is corrupt")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("iland: token cache could not be decrypted")
+	}
+	t := &Token{}
+	err = json.Unmarshal(plaintext, t)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (f *FileTokenCache) Save(t *Token) error {
+	plaintext, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	salt, err := f.saveSalt()
+	if err != nil {
+		return err
+	}
+	gcm, err := f.aead(salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+	header := append([]byte{tokenCacheVersion}, salt...)
+	data := gcm.Seal(append(header, nonce...), nonce, plaintext, nil)
+	err = os.MkdirAll(filepath.Dir(f.path), 0700)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(0600)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// saveSalt returns the salt of the last key derived, so that saving a
+// loaded token does not derive a new key, or a new random salt.
+func (f *FileTokenCache) saveSalt() ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.salt != nil {
+		return f.salt, nil
+	}
+	salt := make([]byte, tokenCacheSaltSize)
+	_, err := io.ReadFull(rand.Reader, salt)
+	return salt, err
+}
+
+func (f *FileTokenCache) aead(salt []byte) (cipher.AEAD, error) {
+	f.mu.Lock()
+	if f.key == nil || !bytes.Equal(salt, f.salt) {
+		key, err := pbkdf2.Key(sha256.New, string(f.passphrase), salt, f.iterations, 32)
+		if err != nil {
+			f.mu.Unlock()
+			return nil, err
+		}
+		f.key = key
+		f.salt = append([]byte{}, salt...)
+	}
+	key := f.key
+	f.mu.Unlock()
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// CachedTokenSource returns a source that reuses the token stored in cache.
+// A cached access token is returned while it is valid; otherwise its refresh
+// token is exchanged using config. The fallback source is only used when no
+// usable refresh token is available. Every new token is written back to the
+// cache. Failures to read or write the cache are not fatal and are logged
+// to logger as warnings, or to slog.Default() if logger is nil.
+func CachedTokenSource(cache TokenCache, config TokenConfig, fallback TokenSource, logger *slog.Logger) TokenSource {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &cachedTokenSource{cache: cache, config: config, fallback: fallback, logger: logger}
+}
+
+type cachedTokenSource struct {
+	cache    TokenCache
+	config   TokenConfig
+	fallback TokenSource
+	logger   *slog.Logger
+
+	mu     sync.Mutex
+	loaded bool
+	last   *Token
+}
+
+func (s *cachedTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *cachedTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.loaded {
+		s.loaded = true
+		cached, err := s.cache.Load()
+		if err != nil {
+			s.logger.Warn("iland token cache could not be read", slog.String("error", err.Error()))
+		}
+		if err == nil && cached != nil {
+			s.last = cached
+			if cached.Valid() {
+				return cached, nil
+			}
+		}
+	}
+	if s.last != nil && s.last.RefreshToken != "" {
+		t, err := s.config.refresh(ctx, s.last.RefreshToken)
+		if err == nil {
+			s.store(t)
+			return t, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	if s.fallback == nil {
+		return nil, errors.New("iland: no cached token available")
+	}
+	var t *Token
+	var err error
+	if source, ok := s.fallback.(ContextTokenSource); ok {
+		t, err = source.TokenContext(ctx)
+	} else {
+		t, err = s.fallback.Token()
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.store(t)
+	return t, nil
+}
+
+func (s *cachedTokenSource) store(t *Token) {
+	s.last = t
+	err := s.cache.Save(t)
+	if err != nil {
+		s.logger.Warn("iland token cache could not be written", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,127 @@
+package iland
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileTokenCacheKey(t *testing.T) {
+	// The first 32 bytes of the PBKDF2-HMAC-SHA256 vectors of RFC 7914,
+	// section 11.
+	tests := []struct {
+		passphrase string
+		salt       string
+		iterations int
+		want       string
+	}{
+		{"passwd", "salt", 1, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc"},
+		{"Password", "NaCl", 80000, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.passphrase, func(t *testing.T) {
+			f, err := NewFileTokenCache(filepath.Join(t.TempDir(), "token"), []byte(tt.passphrase))
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.iterations = tt.iterations
+			if _, err := f.aead([]byte(tt.salt)); err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(f.key); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileTokenCache(t *testing.T) {
+	token := &Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour).Round(0).UTC()}
+	tests := []struct {
+		name       string
+		passphrase string
+		corrupt    func([]byte) []byte
+		wantErr    string
+	}{
+		{name: "round trip", passphrase: "secret"},
+		{name: "wrong passphrase", passphrase: "other", wantErr: "could not be decrypted"},
+		{name: "unsalted file", passphrase: "secret", corrupt: func(data []byte) []byte { return data[1+tokenCacheSaltSize:] }, wantErr: "unsupported version"},
+		{name: "changed salt", passphrase: "secret", corrupt: func(data []byte) []byte { data[1] ^= 0xff; return data }, wantErr: "could not be decrypted"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "default.token")
+			writer, err := NewFileTokenCache(path, []byte("secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			writer.iterations = 1000
+			if err := writer.Save(token); err != nil {
+				t.Fatal(err)
+			}
+			if tt.corrupt != nil {
+				data, _ := os.ReadFile(path)
+				os.WriteFile(path, tt.corrupt(data), 0600)
+			}
+			reader, _ := NewFileTokenCache(path, []byte(tt.passphrase))
+			reader.iterations = 1000
+			got, err := reader.Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.AccessToken != token.AccessToken || got.RefreshToken != token.RefreshToken || !got.Expiry.Equal(token.Expiry) {
+				t.Errorf("got %+v, want %+v", got, token)
+			}
+		})
+	}
+}
+
+func TestFileTokenCacheSaltsEachFile(t *testing.T) {
+	dir := t.TempDir()
+	var salts [][]byte
+	for _, name := range []string{"a.token", "b.token"} {
+		cache, _ := NewFileTokenCache(filepath.Join(dir, name), []byte("secret"))
+		cache.iterations = 1000
+		if err := cache.Save(&Token{AccessToken: "access"}); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		salts = append(salts, data[1:1+tokenCacheSaltSize])
+	}
+	if bytes.Equal(salts[0], salts[1]) {
+		t.Error("both files use the same salt")
+	}
+}
+
+type failingTokenCache struct{}
+
+func (failingTokenCache) Load() (*Token, error) { return nil, errors.New("disk unreadable") }
+func (failingTokenCache) Save(*Token) error     { return errors.New("disk full") }
+
+func TestCachedTokenSourceLogsCacheErrors(t *testing.T) {
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logs, nil))
+	fallback := StaticTokenSource(&Token{AccessToken: "access"})
+	source := CachedTokenSource(failingTokenCache{}, TokenConfig{}, fallback, logger)
+	token, err := source.Token()
+	if err != nil || token.AccessToken != "access" {
+		t.Fatalf("token = %v, err = %v", token, err)
+	}
+	for _, want := range []string{"disk unreadable", "disk full"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log does not mention %q:\n%s", want, logs)
+		}
+	}
+}
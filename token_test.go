@@ -0,0 +1,93 @@
+package iland
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaticTokenIgnoresExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		expiry  time.Time
+		accept  bool
+		wantErr error
+	}{
+		{name: "valid", expiry: time.Now().Add(time.Hour), accept: true},
+		{name: "expired but accepted", expiry: time.Now().Add(-time.Hour), accept: true},
+		{name: "expired and rejected", expiry: time.Now().Add(-time.Hour), accept: false, wantErr: ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &Token{AccessToken: "static", Expiry: tt.expiry}
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tt.accept {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}), WithTokenSource(StaticTokenSource(token)))
+			err := c.getObject(context.Background(), "/v1/items", &struct{}{})
+			if tt.wantErr == nil && err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnauthorizedRefreshIsSingleFlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests int
+	}{
+		{name: "one request", requests: 1},
+		{name: "concurrent requests", requests: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetches atomic.Int32
+			source := TokenSourceFunc(func() (*Token, error) {
+				// Give waiting callers time to pile up behind the fetch.
+				time.Sleep(10 * time.Millisecond)
+				if fetches.Add(1) == 1 {
+					return &Token{AccessToken: "old"}, nil
+				}
+				return &Token{AccessToken: "new"}, nil
+			})
+			c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.Header.Get("Authorization"), " new") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}), WithTokenSource(source))
+			var wg sync.WaitGroup
+			errs := make(chan error, tt.requests)
+			for i := 0; i < tt.requests; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs <- c.getObject(context.Background(), "/v1/items", &struct{}{})
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := fetches.Load(); got != 2 {
+				t.Errorf("fetched %d tokens, want 2", got)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 type User struct {
@@ -26,8 +28,13 @@ type userService struct {
 }
 
 func (s *userService) Get(username string) (User, error) {
+	return s.GetContext(context.Background(), username)
+}
+
+func (s *userService) GetContext(ctx context.Context, username string) (User, error) {
+	ctx = withOperation(ctx, "User.Get", username)
 	user := User{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s", username), &user)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s", username), &user)
 	if err != nil {
 		return User{}, err
 	}
@@ -35,7 +42,12 @@ func (s *userService) Get(username string) (User, error) {
 }
 
 func (s *userService) Delete(username string) error {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/users/%s", username))
+	return s.DeleteContext(context.Background(), username)
+}
+
+func (s *userService) DeleteContext(ctx context.Context, username string) error {
+	ctx = withOperation(ctx, "User.Delete", username)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/users/%s", username))
 	if err != nil {
 		return err
 	}
@@ -54,11 +66,16 @@ type UpdateUserParams struct {
 }
 
 func (s *userService) Update(username string, params UpdateUserParams) (User, error) {
+	return s.UpdateContext(context.Background(), username, params)
+}
+
+func (s *userService) UpdateContext(ctx context.Context, username string, params UpdateUserParams) (User, error) {
+	ctx = withOperation(ctx, "User.Update", username)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return User{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/users/%s", username), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/users/%s", username), data)
 	if err != nil {
 		return User{}, err
 	}
@@ -71,28 +88,53 @@ func (s *userService) Update(username string, params UpdateUserParams) (User, er
 }
 
 func (s *userService) GetCompanies(username string) ([]Company, error) {
+	return s.GetCompaniesContext(context.Background(), username)
+}
+
+func (s *userService) GetCompaniesContext(ctx context.Context, username string) ([]Company, error) {
+	ctx = withOperation(ctx, "User.GetCompanies", username)
 	schema := struct {
 		Companies []Company `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s/companies", username), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s/companies", username), &schema)
 	if err != nil {
 		return []Company{}, err
 	}
 	return schema.Companies, nil
 }
 
+func (s *userService) ListCompanies(ctx context.Context, username string, opts ListOptions) iter.Seq2[Company, error] {
+	ctx = withOperation(ctx, "User.ListCompanies", username)
+	return listAll[Company](ctx, s.client, fmt.Sprintf("/v1/users/%s/companies", username), opts)
+}
+
 func (s *userService) GetOrgs(username string) ([]Org, error) {
+	return s.GetOrgsContext(context.Background(), username)
+}
+
+func (s *userService) GetOrgsContext(ctx context.Context, username string) ([]Org, error) {
+	ctx = withOperation(ctx, "User.GetOrgs", username)
 	schema := struct {
 		Orgs []Org `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s/orgs", username), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s/orgs", username), &schema)
 	if err != nil {
 		return []Org{}, err
 	}
 	return schema.Orgs, nil
 }
 
+func (s *userService) ListOrgs(ctx context.Context, username string, opts ListOptions) iter.Seq2[Org, error] {
+	ctx = withOperation(ctx, "User.ListOrgs", username)
+	return listAll[Org](ctx, s.client, fmt.Sprintf("/v1/users/%s/orgs", username), opts)
+}
+
 func (s *userService) AssignRole(username, companyID, roleID string) error {
+	return s.AssignRoleContext(context.Background(), username, companyID, roleID)
+}
+
+func (s *userService) AssignRoleContext(ctx context.Context, username, companyID, roleID string) error {
+	ctx = withOperation(ctx, "User.AssignRole", username)
 	params := struct {
 		RoleUUID string `json:"role_uuid"`
 	}{
@@ -102,13 +144,18 @@ func (s *userService) AssignRole(username, companyID, roleID string) error {
 	if err != nil {
 		return err
 	}
-	_, err = s.client.Put(fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID), data)
+	_, err = s.client.PutContext(ctx, fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID), data)
 	return err
 }
 
 func (s *userService) GetRole(username, companyID string) (Role, error) {
+	return s.GetRoleContext(context.Background(), username, companyID)
+}
+
+func (s *userService) GetRoleContext(ctx context.Context, username, companyID string) (Role, error) {
+	ctx = withOperation(ctx, "User.GetRole", username)
 	role := Role{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID), &role)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID), &role)
 	if err != nil {
 		return Role{}, err
 	}
@@ -116,21 +163,41 @@ func (s *userService) GetRole(username, companyID string) (Role, error) {
 }
 
 func (s *userService) DeleteRole(username, companyID string) error {
-	_, err := s.client.Delete(fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID))
+	return s.DeleteRoleContext(context.Background(), username, companyID)
+}
+
+func (s *userService) DeleteRoleContext(ctx context.Context, username, companyID string) error {
+	ctx = withOperation(ctx, "User.DeleteRole", username)
+	_, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/users/%s/roles/%s", username, companyID))
 	return err
 }
 
 func (s *userService) GetUserCompanyVacTenants(username, companyID string) ([]VacTenant, error) {
+	return s.GetUserCompanyVacTenantsContext(context.Background(), username, companyID)
+}
+
+func (s *userService) GetUserCompanyVacTenantsContext(ctx context.Context, username, companyID string) ([]VacTenant, error) {
+	ctx = withOperation(ctx, "User.GetUserCompanyVacTenants", username)
 	schema := struct {
 		Tenants []VacTenant `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/users/%s/companies/%s/vac-companies", username, companyID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/users/%s/companies/%s/vac-companies", username, companyID), &schema)
 	if err != nil {
 		return []VacTenant{}, err
 	}
 	return schema.Tenants, nil
 }
 
+func (s *userService) ListUserCompanyVacTenants(ctx context.Context, username, companyID string, opts ListOptions) iter.Seq2[VacTenant, error] {
+	ctx = withOperation(ctx, "User.ListUserCompanyVacTenants", username)
+	return listAll[VacTenant](ctx, s.client, fmt.Sprintf("/v1/users/%s/companies/%s/vac-companies", username, companyID), opts)
+}
+
 func (s *userService) GetCompanyVacTenants(companyID string) ([]VacTenant, error) {
-	return s.GetUserCompanyVacTenants(s.client.username, companyID)
+	return s.GetCompanyVacTenantsContext(context.Background(), companyID)
+}
+
+func (s *userService) GetCompanyVacTenantsContext(ctx context.Context, companyID string) ([]VacTenant, error) {
+	ctx = withOperation(ctx, "User.GetCompanyVacTenants", companyID)
+	return s.GetUserCompanyVacTenantsContext(ctx, s.client.username, companyID)
 }
@@ -1,6 +1,9 @@
 package iland
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type VacTenant struct {
 	ID                              string              `json:"uuid"`
@@ -80,8 +83,13 @@ type vacTenantService struct {
 }
 
 func (s *vacTenantService) Get(id string) (VacTenant, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+func (s *vacTenantService) GetContext(ctx context.Context, id string) (VacTenant, error) {
+	ctx = withOperation(ctx, "VacTenant.Get", id)
 	vacTenant := VacTenant{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vac-companies/%s", id), &vacTenant)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vac-companies/%s", id), &vacTenant)
 	if err != nil {
 		return VacTenant{}, err
 	}
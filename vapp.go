@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -27,8 +29,8 @@ type vappService struct {
 	client *client
 }
 
-func (s *vappService) postAction(vappID, action string, params []byte) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vapps/%s/actions/%s", vappID, action), params)
+func (s *vappService) postAction(ctx context.Context, vappID, action string, params []byte) (Task, error) {
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vapps/%s/actions/%s", vappID, action), params)
 	if err != nil {
 		return Task{}, err
 	}
@@ -41,8 +43,13 @@ func (s *vappService) postAction(vappID, action string, params []byte) (Task, er
 }
 
 func (s *vappService) Get(vappID string) (VApp, error) {
+	return s.GetContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetContext(ctx context.Context, vappID string) (VApp, error) {
+	ctx = withOperation(ctx, "VApp.Get", vappID)
 	vapp := VApp{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s", vappID), &vapp)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s", vappID), &vapp)
 	if err != nil {
 		return VApp{}, err
 	}
@@ -50,7 +57,12 @@ func (s *vappService) Get(vappID string) (VApp, error) {
 }
 
 func (s *vappService) Delete(vappID string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vapps/%s", vappID))
+	return s.DeleteContext(context.Background(), vappID)
+}
+
+func (s *vappService) DeleteContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Delete", vappID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vapps/%s", vappID))
 	if err != nil {
 		return Task{}, err
 	}
@@ -63,29 +75,54 @@ func (s *vappService) Delete(vappID string) (Task, error) {
 }
 
 func (s *vappService) GetVirtualMachines(vappID string) ([]VirtualMachine, error) {
+	return s.GetVirtualMachinesContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetVirtualMachinesContext(ctx context.Context, vappID string) ([]VirtualMachine, error) {
+	ctx = withOperation(ctx, "VApp.GetVirtualMachines", vappID)
 	schema := struct {
 		VirtualMachines []VirtualMachine `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/vms", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/vms", vappID), &schema)
 	if err != nil {
 		return []VirtualMachine{}, err
 	}
 	return schema.VirtualMachines, nil
 }
 
+func (s *vappService) ListVirtualMachines(ctx context.Context, vappID string, opts ListOptions) iter.Seq2[VirtualMachine, error] {
+	ctx = withOperation(ctx, "VApp.ListVirtualMachines", vappID)
+	return listAll[VirtualMachine](ctx, s.client, fmt.Sprintf("/v1/vapps/%s/vms", vappID), opts)
+}
+
 func (s *vappService) GetNetworks(vappID string) ([]VAppNetwork, error) {
+	return s.GetNetworksContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetNetworksContext(ctx context.Context, vappID string) ([]VAppNetwork, error) {
+	ctx = withOperation(ctx, "VApp.GetNetworks", vappID)
 	schema := struct {
 		Networks []VAppNetwork `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/networks", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/networks", vappID), &schema)
 	if err != nil {
 		return []VAppNetwork{}, err
 	}
 	return schema.Networks, nil
 }
 
+func (s *vappService) ListNetworks(ctx context.Context, vappID string, opts ListOptions) iter.Seq2[VAppNetwork, error] {
+	ctx = withOperation(ctx, "VApp.ListNetworks", vappID)
+	return listAll[VAppNetwork](ctx, s.client, fmt.Sprintf("/v1/vapps/%s/networks", vappID), opts)
+}
+
 func (s *vappService) AddOrgNetwork(vappID, orgVdcNetworkID string) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vapps/%s/org-vdc-network/%s", vappID, orgVdcNetworkID), []byte{})
+	return s.AddOrgNetworkContext(context.Background(), vappID, orgVdcNetworkID)
+}
+
+func (s *vappService) AddOrgNetworkContext(ctx context.Context, vappID, orgVdcNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.AddOrgNetwork", vappID)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vapps/%s/org-vdc-network/%s", vappID, orgVdcNetworkID), []byte{})
 	if err != nil {
 		return Task{}, err
 	}
@@ -98,6 +135,11 @@ func (s *vappService) AddOrgNetwork(vappID, orgVdcNetworkID string) (Task, error
 }
 
 func (s *vappService) UpdateName(vappID, name string) (Task, error) {
+	return s.UpdateNameContext(context.Background(), vappID, name)
+}
+
+func (s *vappService) UpdateNameContext(ctx context.Context, vappID, name string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.UpdateName", vappID)
 	params := struct {
 		Name string `json:"name"`
 	}{
@@ -107,10 +149,15 @@ func (s *vappService) UpdateName(vappID, name string) (Task, error) {
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "update-name", data)
+	return s.postAction(ctx, vappID, "update-name", data)
 }
 
 func (s *vappService) UpdateDescription(vappID, description string) (Task, error) {
+	return s.UpdateDescriptionContext(context.Background(), vappID, description)
+}
+
+func (s *vappService) UpdateDescriptionContext(ctx context.Context, vappID, description string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.UpdateDescription", vappID)
 	params := struct {
 		Description string `json:"description"`
 	}{
@@ -120,7 +167,7 @@ func (s *vappService) UpdateDescription(vappID, description string) (Task, error
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "update-name", data)
+	return s.postAction(ctx, vappID, "update-name", data)
 }
 
 type CopyVAppParams struct {
@@ -129,11 +176,16 @@ type CopyVAppParams struct {
 }
 
 func (s *vappService) Copy(vappID string, params CopyVAppParams) (Task, error) {
+	return s.CopyContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) CopyContext(ctx context.Context, vappID string, params CopyVAppParams) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Copy", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "copy", data)
+	return s.postAction(ctx, vappID, "copy", data)
 }
 
 type MoveVAppParams struct {
@@ -142,11 +194,16 @@ type MoveVAppParams struct {
 }
 
 func (s *vappService) Move(vappID string, params MoveVAppParams) (Task, error) {
+	return s.MoveContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) MoveContext(ctx context.Context, vappID string, params MoveVAppParams) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Move", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "move", data)
+	return s.postAction(ctx, vappID, "move", data)
 }
 
 type BuildVirtualMachineParams struct {
@@ -168,11 +225,16 @@ type BuildNicParams struct {
 }
 
 func (s *vappService) BuildVirtualMachines(vappID string, params []BuildVirtualMachineParams) (Task, error) {
+	return s.BuildVirtualMachinesContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) BuildVirtualMachinesContext(ctx context.Context, vappID string, params []BuildVirtualMachineParams) (Task, error) {
+	ctx = withOperation(ctx, "VApp.BuildVirtualMachines", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "build-vms", data)
+	return s.postAction(ctx, vappID, "build-vms", data)
 }
 
 type AddTemplateVirtualMachineParams struct {
@@ -187,11 +249,16 @@ type AddTemplateVirtualMachineParams struct {
 }
 
 func (s *vappService) AddTemplateVirtualMachines(vappID string, params []AddTemplateVirtualMachineParams) (Task, error) {
+	return s.AddTemplateVirtualMachinesContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) AddTemplateVirtualMachinesContext(ctx context.Context, vappID string, params []AddTemplateVirtualMachineParams) (Task, error) {
+	ctx = withOperation(ctx, "VApp.AddTemplateVirtualMachines", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "add-vms-from-templates", data)
+	return s.postAction(ctx, vappID, "add-vms-from-templates", data)
 }
 
 type CreateVAppNetworkParams struct {
@@ -207,40 +274,80 @@ type CreateVAppNetworkParams struct {
 }
 
 func (s *vappService) CreateNetwork(vappID string, params CreateVAppNetworkParams) (Task, error) {
+	return s.CreateNetworkContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) CreateNetworkContext(ctx context.Context, vappID string, params CreateVAppNetworkParams) (Task, error) {
+	ctx = withOperation(ctx, "VApp.CreateNetwork", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "add-vapp-network", data)
+	return s.postAction(ctx, vappID, "add-vapp-network", data)
 }
 
 func (s *vappService) PowerOn(vappID string) (Task, error) {
-	return s.postAction(vappID, "poweron", []byte{})
+	return s.PowerOnContext(context.Background(), vappID)
+}
+
+func (s *vappService) PowerOnContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.PowerOn", vappID)
+	return s.postAction(ctx, vappID, "poweron", []byte{})
 }
 
 func (s *vappService) PowerOff(vappID string) (Task, error) {
-	return s.postAction(vappID, "poweroff", []byte{})
+	return s.PowerOffContext(context.Background(), vappID)
+}
+
+func (s *vappService) PowerOffContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.PowerOff", vappID)
+	return s.postAction(ctx, vappID, "poweroff", []byte{})
 }
 
 func (s *vappService) Shutdown(vappID string) (Task, error) {
-	return s.postAction(vappID, "shutdown", []byte{})
+	return s.ShutdownContext(context.Background(), vappID)
+}
+
+func (s *vappService) ShutdownContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Shutdown", vappID)
+	return s.postAction(ctx, vappID, "shutdown", []byte{})
 }
 
 func (s *vappService) Reboot(vappID string) (Task, error) {
-	return s.postAction(vappID, "reboot", []byte{})
+	return s.RebootContext(context.Background(), vappID)
+}
+
+func (s *vappService) RebootContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Reboot", vappID)
+	return s.postAction(ctx, vappID, "reboot", []byte{})
 }
 
 func (s *vappService) Reset(vappID string) (Task, error) {
-	return s.postAction(vappID, "reset", []byte{})
+	return s.ResetContext(context.Background(), vappID)
+}
+
+func (s *vappService) ResetContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Reset", vappID)
+	return s.postAction(ctx, vappID, "reset", []byte{})
 }
 
 func (s *vappService) Suspend(vappID string) (Task, error) {
-	return s.postAction(vappID, "suspend", []byte{})
+	return s.SuspendContext(context.Background(), vappID)
+}
+
+func (s *vappService) SuspendContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.Suspend", vappID)
+	return s.postAction(ctx, vappID, "suspend", []byte{})
 }
 
 func (s *vappService) GetCurrentBill(vappID string) (Billing, error) {
+	return s.GetCurrentBillContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetCurrentBillContext(ctx context.Context, vappID string) (Billing, error) {
+	ctx = withOperation(ctx, "VApp.GetCurrentBill", vappID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/billing", vappID), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/billing", vappID), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -248,8 +355,13 @@ func (s *vappService) GetCurrentBill(vappID string) (Billing, error) {
 }
 
 func (s *vappService) GetBill(vappID string, month, year int) (Billing, error) {
+	return s.GetBillContext(context.Background(), vappID, month, year)
+}
+
+func (s *vappService) GetBillContext(ctx context.Context, vappID string, month, year int) (Billing, error) {
+	ctx = withOperation(ctx, "VApp.GetBill", vappID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/billing?month=%d&year=%d", vappID, month, year), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/billing?month=%d&year=%d", vappID, month, year), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -257,10 +369,15 @@ func (s *vappService) GetBill(vappID string, month, year int) (Billing, error) {
 }
 
 func (s *vappService) GetAvailableStorageProfiles(vappID string) ([]StorageProfile, error) {
+	return s.GetAvailableStorageProfilesContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetAvailableStorageProfilesContext(ctx context.Context, vappID string) ([]StorageProfile, error) {
+	ctx = withOperation(ctx, "VApp.GetAvailableStorageProfiles", vappID)
 	schema := struct {
 		StorageProfiles []StorageProfile `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/available-storage-profiles", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/available-storage-profiles", vappID), &schema)
 	if err != nil {
 		return []StorageProfile{}, err
 	}
@@ -268,10 +385,15 @@ func (s *vappService) GetAvailableStorageProfiles(vappID string) ([]StorageProfi
 }
 
 func (s *vappService) GetMetadata(vappID string) ([]Metadata, error) {
+	return s.GetMetadataContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetMetadataContext(ctx context.Context, vappID string) ([]Metadata, error) {
+	ctx = withOperation(ctx, "VApp.GetMetadata", vappID)
 	schema := struct {
 		Metadata []Metadata `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/metadata", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/metadata", vappID), &schema)
 	if err != nil {
 		return []Metadata{}, err
 	}
@@ -279,11 +401,16 @@ func (s *vappService) GetMetadata(vappID string) ([]Metadata, error) {
 }
 
 func (s *vappService) UpdateMetadata(vappID string, metadata []Metadata) (Task, error) {
+	return s.UpdateMetadataContext(context.Background(), vappID, metadata)
+}
+
+func (s *vappService) UpdateMetadataContext(ctx context.Context, vappID string, metadata []Metadata) (Task, error) {
+	ctx = withOperation(ctx, "VApp.UpdateMetadata", vappID)
 	payload, err := json.Marshal(&metadata)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/vapps/%s/metadata", vappID), payload)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/vapps/%s/metadata", vappID), payload)
 	if err != nil {
 		return Task{}, err
 	}
@@ -296,7 +423,12 @@ func (s *vappService) UpdateMetadata(vappID string, metadata []Metadata) (Task,
 }
 
 func (s *vappService) DeleteMetadata(vappID, metadataKey string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vapps/%s/metadata/%s", vappID, metadataKey))
+	return s.DeleteMetadataContext(context.Background(), vappID, metadataKey)
+}
+
+func (s *vappService) DeleteMetadataContext(ctx context.Context, vappID, metadataKey string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.DeleteMetadata", vappID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vapps/%s/metadata/%s", vappID, metadataKey))
 	if err != nil {
 		return Task{}, err
 	}
@@ -309,10 +441,15 @@ func (s *vappService) DeleteMetadata(vappID, metadataKey string) (Task, error) {
 }
 
 func (s *vappService) HasSnapshot(vappID string) (bool, error) {
+	return s.HasSnapshotContext(context.Background(), vappID)
+}
+
+func (s *vappService) HasSnapshotContext(ctx context.Context, vappID string) (bool, error) {
+	ctx = withOperation(ctx, "VApp.HasSnapshot", vappID)
 	schema := struct {
 		HasSnapshot bool `json:"has_snapshot"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/has-snapshot", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/has-snapshot", vappID), &schema)
 	if err != nil {
 		return false, err
 	}
@@ -320,8 +457,13 @@ func (s *vappService) HasSnapshot(vappID string) (bool, error) {
 }
 
 func (s *vappService) GetSnapshot(vappID string) (Snapshot, error) {
+	return s.GetSnapshotContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetSnapshotContext(ctx context.Context, vappID string) (Snapshot, error) {
+	ctx = withOperation(ctx, "VApp.GetSnapshot", vappID)
 	snapshot := Snapshot{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/snapshot", vappID), &snapshot)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/snapshot", vappID), &snapshot)
 	if err != nil {
 		return Snapshot{}, err
 	}
@@ -329,6 +471,11 @@ func (s *vappService) GetSnapshot(vappID string) (Snapshot, error) {
 }
 
 func (s *vappService) CreateSnapshot(vappID string) (Task, error) {
+	return s.CreateSnapshotContext(context.Background(), vappID)
+}
+
+func (s *vappService) CreateSnapshotContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.CreateSnapshot", vappID)
 	params := struct {
 		Memory  bool `json:"memory"`
 		Quiesce bool `json:"quiesce"`
@@ -340,15 +487,25 @@ func (s *vappService) CreateSnapshot(vappID string) (Task, error) {
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "create-snapshot", data)
+	return s.postAction(ctx, vappID, "create-snapshot", data)
 }
 
 func (s *vappService) RestoreSnapshot(vappID string) (Task, error) {
-	return s.postAction(vappID, "restore-snapshot", []byte{})
+	return s.RestoreSnapshotContext(context.Background(), vappID)
+}
+
+func (s *vappService) RestoreSnapshotContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.RestoreSnapshot", vappID)
+	return s.postAction(ctx, vappID, "restore-snapshot", []byte{})
 }
 
 func (s *vappService) RemoveSnapshot(vappID string) (Task, error) {
-	return s.postAction(vappID, "remove-snapshot", []byte{})
+	return s.RemoveSnapshotContext(context.Background(), vappID)
+}
+
+func (s *vappService) RemoveSnapshotContext(ctx context.Context, vappID string) (Task, error) {
+	ctx = withOperation(ctx, "VApp.RemoveSnapshot", vappID)
+	return s.postAction(ctx, vappID, "remove-snapshot", []byte{})
 }
 
 type VAppStartupSetting struct {
@@ -370,10 +527,15 @@ type vappStartupSettings struct {
 }
 
 func (s *vappService) GetStartupSettings(vappID string) ([]VAppStartupSetting, error) {
+	return s.GetStartupSettingsContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetStartupSettingsContext(ctx context.Context, vappID string) ([]VAppStartupSetting, error) {
+	ctx = withOperation(ctx, "VApp.GetStartupSettings", vappID)
 	schema := struct {
 		Settings []vappStartupSettings `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/startup-section", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/startup-section", vappID), &schema)
 	if err != nil {
 		return []VAppStartupSetting{}, err
 	}
@@ -393,18 +555,28 @@ func (s *vappService) GetStartupSettings(vappID string) ([]VAppStartupSetting, e
 }
 
 func (s *vappService) UpdateStartupSettings(vappID string, params []VAppStartupSetting) (Task, error) {
+	return s.UpdateStartupSettingsContext(context.Background(), vappID, params)
+}
+
+func (s *vappService) UpdateStartupSettingsContext(ctx context.Context, vappID string, params []VAppStartupSetting) (Task, error) {
+	ctx = withOperation(ctx, "VApp.UpdateStartupSettings", vappID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappID, "update-startup-section", data)
+	return s.postAction(ctx, vappID, "update-startup-section", data)
 }
 
 func (s *vappService) GetPerformanceCounters(vappID string) ([]PerformanceCounter, error) {
+	return s.GetPerformanceCountersContext(context.Background(), vappID)
+}
+
+func (s *vappService) GetPerformanceCountersContext(ctx context.Context, vappID string) ([]PerformanceCounter, error) {
+	ctx = withOperation(ctx, "VApp.GetPerformanceCounters", vappID)
 	schema := struct {
 		Counters []PerformanceCounter `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/performance-counters", vappID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/performance-counters", vappID), &schema)
 	if err != nil {
 		return []PerformanceCounter{}, err
 	}
@@ -412,10 +584,15 @@ func (s *vappService) GetPerformanceCounters(vappID string) ([]PerformanceCounte
 }
 
 func (s *vappService) GetPerformance(vappID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	return s.GetPerformanceContext(context.Background(), vappID, counter, start, end)
+}
+
+func (s *vappService) GetPerformanceContext(ctx context.Context, vappID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	ctx = withOperation(ctx, "VApp.GetPerformance", vappID)
 	startNano := getUnixMilliseconds(start)
 	endNano := getUnixMilliseconds(end)
 	performance := Performance{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/performance/%s::%s::%s?start=%d&end=%d", vappID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/performance/%s::%s::%s?start=%d&end=%d", vappID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
 	if err != nil {
 		return Performance{}, err
 	}
@@ -433,8 +610,13 @@ type VAppSummary struct {
 }
 
 func (s *vappService) GetSummary(vdcID string) (VAppSummary, error) {
+	return s.GetSummaryContext(context.Background(), vdcID)
+}
+
+func (s *vappService) GetSummaryContext(ctx context.Context, vdcID string) (VAppSummary, error) {
+	ctx = withOperation(ctx, "VApp.GetSummary", vdcID)
 	summary := VAppSummary{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapps/%s/summary", vdcID), &summary)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapps/%s/summary", vdcID), &summary)
 	if err != nil {
 		return VAppSummary{}, err
 	}
@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 type VAppNetwork struct {
@@ -32,8 +34,8 @@ type vappNetworkService struct {
 	client *client
 }
 
-func (s *vappNetworkService) postAction(vappNetworkID, action string, payload []byte) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vapp-networks/%s/actions/%s", vappNetworkID, action), payload)
+func (s *vappNetworkService) postAction(ctx context.Context, vappNetworkID, action string, payload []byte) (Task, error) {
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vapp-networks/%s/actions/%s", vappNetworkID, action), payload)
 	if err != nil {
 		return Task{}, err
 	}
@@ -46,8 +48,13 @@ func (s *vappNetworkService) postAction(vappNetworkID, action string, payload []
 }
 
 func (s *vappNetworkService) Get(vappNetworkID string) (VAppNetwork, error) {
+	return s.GetContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) GetContext(ctx context.Context, vappNetworkID string) (VAppNetwork, error) {
+	ctx = withOperation(ctx, "VAppNetwork.Get", vappNetworkID)
 	network := VAppNetwork{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID), &network)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID), &network)
 	if err != nil {
 		return VAppNetwork{}, err
 	}
@@ -65,11 +72,16 @@ type UpdateVAppNetworkParams struct {
 }
 
 func (s *vappNetworkService) Update(vappNetworkID string, params UpdateVAppNetworkParams) (Task, error) {
+	return s.UpdateContext(context.Background(), vappNetworkID, params)
+}
+
+func (s *vappNetworkService) UpdateContext(ctx context.Context, vappNetworkID string, params UpdateVAppNetworkParams) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.Update", vappNetworkID)
 	data, err := json.Marshal(params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID), data)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -82,7 +94,12 @@ func (s *vappNetworkService) Update(vappNetworkID string, params UpdateVAppNetwo
 }
 
 func (s *vappNetworkService) Delete(vappNetworkID string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID))
+	return s.DeleteContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) DeleteContext(ctx context.Context, vappNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.Delete", vappNetworkID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vapp-networks/%s", vappNetworkID))
 	if err != nil {
 		return Task{}, err
 	}
@@ -95,11 +112,16 @@ func (s *vappNetworkService) Delete(vappNetworkID string) (Task, error) {
 }
 
 func (s *vappNetworkService) UpdateDHCP(vappNetworkID string, params DHCP) (Task, error) {
+	return s.UpdateDHCPContext(context.Background(), vappNetworkID, params)
+}
+
+func (s *vappNetworkService) UpdateDHCPContext(ctx context.Context, vappNetworkID string, params DHCP) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.UpdateDHCP", vappNetworkID)
 	payload, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappNetworkID, "update-dhcp", payload)
+	return s.postAction(ctx, vappNetworkID, "update-dhcp", payload)
 }
 
 type VAppNetworkFirewall struct {
@@ -130,8 +152,13 @@ type VAppNetworkFirewallRule struct {
 }
 
 func (s *vappNetworkService) GetFirewall(vappNetworkID string) (VAppNetworkFirewall, error) {
+	return s.GetFirewallContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) GetFirewallContext(ctx context.Context, vappNetworkID string) (VAppNetworkFirewall, error) {
+	ctx = withOperation(ctx, "VAppNetwork.GetFirewall", vappNetworkID)
 	firewall := VAppNetworkFirewall{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-networks/%s/firewall", vappNetworkID), &firewall)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-networks/%s/firewall", vappNetworkID), &firewall)
 	if err != nil {
 		return VAppNetworkFirewall{}, err
 	}
@@ -139,38 +166,53 @@ func (s *vappNetworkService) GetFirewall(vappNetworkID string) (VAppNetworkFirew
 }
 
 func (s *vappNetworkService) EnableFirewall(vappNetworkID string) (Task, error) {
-	fw, err := s.GetFirewall(vappNetworkID)
+	return s.EnableFirewallContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) EnableFirewallContext(ctx context.Context, vappNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.EnableFirewall", vappNetworkID)
+	fw, err := s.GetFirewallContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	fw.Enabled = true
-	return s.updateFirewall(vappNetworkID, fw)
+	return s.updateFirewall(ctx, vappNetworkID, fw)
 }
 
 func (s *vappNetworkService) DisableFirewall(vappNetworkID string) (Task, error) {
-	fw, err := s.GetFirewall(vappNetworkID)
+	return s.DisableFirewallContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) DisableFirewallContext(ctx context.Context, vappNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.DisableFirewall", vappNetworkID)
+	fw, err := s.GetFirewallContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	fw.Enabled = false
-	return s.updateFirewall(vappNetworkID, fw)
+	return s.updateFirewall(ctx, vappNetworkID, fw)
 }
 
 func (s *vappNetworkService) UpdateFirewallRules(vappNetworkID string, rules []VAppNetworkFirewallRule) (Task, error) {
-	firewall, err := s.GetFirewall(vappNetworkID)
+	return s.UpdateFirewallRulesContext(context.Background(), vappNetworkID, rules)
+}
+
+func (s *vappNetworkService) UpdateFirewallRulesContext(ctx context.Context, vappNetworkID string, rules []VAppNetworkFirewallRule) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.UpdateFirewallRules", vappNetworkID)
+	firewall, err := s.GetFirewallContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	firewall.Rules = rules
-	return s.updateFirewall(vappNetworkID, firewall)
+	return s.updateFirewall(ctx, vappNetworkID, firewall)
 }
 
-func (s *vappNetworkService) updateFirewall(vappNetworkID string, params VAppNetworkFirewall) (Task, error) {
+func (s *vappNetworkService) updateFirewall(ctx context.Context, vappNetworkID string, params VAppNetworkFirewall) (Task, error) {
 	payload, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappNetworkID, "update-firewall", payload)
+	return s.postAction(ctx, vappNetworkID, "update-firewall", payload)
 }
 
 type VAppNetworkNAT struct {
@@ -199,35 +241,50 @@ type PortForwardingRule struct {
 }
 
 func (s *vappNetworkService) GetNAT(vappNetworkID string) (VAppNetworkNAT, error) {
+	return s.GetNATContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) GetNATContext(ctx context.Context, vappNetworkID string) (VAppNetworkNAT, error) {
+	ctx = withOperation(ctx, "VAppNetwork.GetNAT", vappNetworkID)
 	nat := VAppNetworkNAT{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-networks/%s/nat", vappNetworkID), &nat)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-networks/%s/nat", vappNetworkID), &nat)
 	if err != nil {
 		return VAppNetworkNAT{}, err
 	}
 	return nat, nil
 }
 
-func (s *vappNetworkService) updateNAT(vappNetworkID string, params VAppNetworkNAT) (Task, error) {
+func (s *vappNetworkService) updateNAT(ctx context.Context, vappNetworkID string, params VAppNetworkNAT) (Task, error) {
 	payload, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(vappNetworkID, "update-nat", payload)
+	return s.postAction(ctx, vappNetworkID, "update-nat", payload)
 }
 
 func (s *vappNetworkService) UpdateNATIPTranslationRules(vappNetworkID string, rules []IPTranslationRule) (Task, error) {
-	nat, err := s.GetNAT(vappNetworkID)
+	return s.UpdateNATIPTranslationRulesContext(context.Background(), vappNetworkID, rules)
+}
+
+func (s *vappNetworkService) UpdateNATIPTranslationRulesContext(ctx context.Context, vappNetworkID string, rules []IPTranslationRule) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.UpdateNATIPTranslationRules", vappNetworkID)
+	nat, err := s.GetNATContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	nat.Type = "ipTranslation"
 	nat.PortForwardingRules = &[]PortForwardingRule{}
 	nat.IPTranslationRules = &rules
-	return s.updateNAT(vappNetworkID, nat)
+	return s.updateNAT(ctx, vappNetworkID, nat)
 }
 
 func (s *vappNetworkService) UpdateNATPortForwardingRules(vappNetworkID string, rules []PortForwardingRule) (Task, error) {
-	nat, err := s.GetNAT(vappNetworkID)
+	return s.UpdateNATPortForwardingRulesContext(context.Background(), vappNetworkID, rules)
+}
+
+func (s *vappNetworkService) UpdateNATPortForwardingRulesContext(ctx context.Context, vappNetworkID string, rules []PortForwardingRule) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.UpdateNATPortForwardingRules", vappNetworkID)
+	nat, err := s.GetNATContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
@@ -235,25 +292,35 @@ func (s *vappNetworkService) UpdateNATPortForwardingRules(vappNetworkID string,
 	nat.IPTranslationRules = &[]IPTranslationRule{}
 	nat.EnabledMasquerade = true
 	nat.PortForwardingRules = &rules
-	return s.updateNAT(vappNetworkID, nat)
+	return s.updateNAT(ctx, vappNetworkID, nat)
 }
 
 func (s *vappNetworkService) EnableNAT(vappNetworkID string) (Task, error) {
-	nat, err := s.GetNAT(vappNetworkID)
+	return s.EnableNATContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) EnableNATContext(ctx context.Context, vappNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.EnableNAT", vappNetworkID)
+	nat, err := s.GetNATContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	nat.Enabled = true
-	return s.updateNAT(vappNetworkID, nat)
+	return s.updateNAT(ctx, vappNetworkID, nat)
 }
 
 func (s *vappNetworkService) DisableNAT(vappNetworkID string) (Task, error) {
-	nat, err := s.GetNAT(vappNetworkID)
+	return s.DisableNATContext(context.Background(), vappNetworkID)
+}
+
+func (s *vappNetworkService) DisableNATContext(ctx context.Context, vappNetworkID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppNetwork.DisableNAT", vappNetworkID)
+	nat, err := s.GetNATContext(ctx, vappNetworkID)
 	if err != nil {
 		return Task{}, err
 	}
 	nat.Enabled = false
-	return s.updateNAT(vappNetworkID, nat)
+	return s.updateNAT(ctx, vappNetworkID, nat)
 }
 
 type VirtualMachineInterface struct {
@@ -268,12 +335,22 @@ type VirtualMachineInterface struct {
 }
 
 func (s *vappNetworkService) GetInterfaces(vappNetwork string) ([]VirtualMachineInterface, error) {
+	return s.GetInterfacesContext(context.Background(), vappNetwork)
+}
+
+func (s *vappNetworkService) GetInterfacesContext(ctx context.Context, vappNetwork string) ([]VirtualMachineInterface, error) {
+	ctx = withOperation(ctx, "VAppNetwork.GetInterfaces", vappNetwork)
 	schema := struct {
 		Interfaces []VirtualMachineInterface `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-networks/%s/vm-interfaces", vappNetwork), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-networks/%s/vm-interfaces", vappNetwork), &schema)
 	if err != nil {
 		return []VirtualMachineInterface{}, err
 	}
 	return schema.Interfaces, nil
 }
+
+func (s *vappNetworkService) ListInterfaces(ctx context.Context, vappNetwork string, opts ListOptions) iter.Seq2[VirtualMachineInterface, error] {
+	ctx = withOperation(ctx, "VAppNetwork.ListInterfaces", vappNetwork)
+	return listAll[VirtualMachineInterface](ctx, s.client, fmt.Sprintf("/v1/vapp-networks/%s/vm-interfaces", vappNetwork), opts)
+}
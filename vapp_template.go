@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 type VAppTemplate struct {
@@ -40,8 +42,13 @@ type vappTemplateService struct {
 }
 
 func (s *vappTemplateService) Get(vappTemplateID string) (VAppTemplate, error) {
+	return s.GetContext(context.Background(), vappTemplateID)
+}
+
+func (s *vappTemplateService) GetContext(ctx context.Context, vappTemplateID string) (VAppTemplate, error) {
+	ctx = withOperation(ctx, "VAppTemplate.Get", vappTemplateID)
 	vappTemplate := VAppTemplate{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID), &vappTemplate)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID), &vappTemplate)
 	if err != nil {
 		return VAppTemplate{}, err
 	}
@@ -54,11 +61,16 @@ type UpdateVAppTemplateParams struct {
 }
 
 func (s *vappTemplateService) Update(vappTemplateID string, params UpdateVAppTemplateParams) (Task, error) {
+	return s.UpdateContext(context.Background(), vappTemplateID, params)
+}
+
+func (s *vappTemplateService) UpdateContext(ctx context.Context, vappTemplateID string, params UpdateVAppTemplateParams) (Task, error) {
+	ctx = withOperation(ctx, "VAppTemplate.Update", vappTemplateID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID), data)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -71,7 +83,12 @@ func (s *vappTemplateService) Update(vappTemplateID string, params UpdateVAppTem
 }
 
 func (s *vappTemplateService) Delete(vappTemplateID string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID))
+	return s.DeleteContext(context.Background(), vappTemplateID)
+}
+
+func (s *vappTemplateService) DeleteContext(ctx context.Context, vappTemplateID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppTemplate.Delete", vappTemplateID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vapp-templates/%s", vappTemplateID))
 	if err != nil {
 		return Task{}, err
 	}
@@ -84,16 +101,26 @@ func (s *vappTemplateService) Delete(vappTemplateID string) (Task, error) {
 }
 
 func (s *vappTemplateService) GetVirtualMachines(vappTemplateID string) ([]VirtualMachineTemplate, error) {
+	return s.GetVirtualMachinesContext(context.Background(), vappTemplateID)
+}
+
+func (s *vappTemplateService) GetVirtualMachinesContext(ctx context.Context, vappTemplateID string) ([]VirtualMachineTemplate, error) {
+	ctx = withOperation(ctx, "VAppTemplate.GetVirtualMachines", vappTemplateID)
 	schema := struct {
 		VirtualMachines []VirtualMachineTemplate `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-templates/%s/vms", vappTemplateID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-templates/%s/vms", vappTemplateID), &schema)
 	if err != nil {
 		return []VirtualMachineTemplate{}, err
 	}
 	return schema.VirtualMachines, nil
 }
 
+func (s *vappTemplateService) ListVirtualMachines(ctx context.Context, vappTemplateID string, opts ListOptions) iter.Seq2[VirtualMachineTemplate, error] {
+	ctx = withOperation(ctx, "VAppTemplate.ListVirtualMachines", vappTemplateID)
+	return listAll[VirtualMachineTemplate](ctx, s.client, fmt.Sprintf("/v1/vapp-templates/%s/vms", vappTemplateID), opts)
+}
+
 type VAppTemplateConfig struct {
 	ID              string                         `json:"uuid"`
 	Name            string                         `json:"name"`
@@ -143,8 +170,13 @@ type NetworkTemplateConfig struct {
 }
 
 func (s *vappTemplateService) GetConfig(vappTemplateID string) (VAppTemplateConfig, error) {
+	return s.GetConfigContext(context.Background(), vappTemplateID)
+}
+
+func (s *vappTemplateService) GetConfigContext(ctx context.Context, vappTemplateID string) (VAppTemplateConfig, error) {
+	ctx = withOperation(ctx, "VAppTemplate.GetConfig", vappTemplateID)
 	config := VAppTemplateConfig{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vapp-templates/%s/configuration", vappTemplateID), &config)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vapp-templates/%s/configuration", vappTemplateID), &config)
 	if err != nil {
 		return VAppTemplateConfig{}, err
 	}
@@ -152,7 +184,12 @@ func (s *vappTemplateService) GetConfig(vappTemplateID string) (VAppTemplateConf
 }
 
 func (s *vappTemplateService) SyncSubscription(vappTemplateID string) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vapp-templates/%s/actions/sync", vappTemplateID), []byte{})
+	return s.SyncSubscriptionContext(context.Background(), vappTemplateID)
+}
+
+func (s *vappTemplateService) SyncSubscriptionContext(ctx context.Context, vappTemplateID string) (Task, error) {
+	ctx = withOperation(ctx, "VAppTemplate.SyncSubscription", vappTemplateID)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vapp-templates/%s/actions/sync", vappTemplateID), []byte{})
 	if err != nil {
 		return Task{}, err
 	}
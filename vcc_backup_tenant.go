@@ -1,6 +1,9 @@
 package iland
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type VCCBackupTenant struct {
 	ID                   string             `json:"uuid"`
@@ -41,8 +44,13 @@ type vccBackupTenantService struct {
 }
 
 func (s *vccBackupTenantService) Get(vccBackupTenantID string) (VCCBackupTenant, error) {
+	return s.GetContext(context.Background(), vccBackupTenantID)
+}
+
+func (s *vccBackupTenantService) GetContext(ctx context.Context, vccBackupTenantID string) (VCCBackupTenant, error) {
+	ctx = withOperation(ctx, "VCCBackupTenant.Get", vccBackupTenantID)
 	vccBackupTenant := VCCBackupTenant{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vcc-backup-tenants/%s", vccBackupTenantID), &vccBackupTenant)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vcc-backup-tenants/%s", vccBackupTenantID), &vccBackupTenant)
 	if err != nil {
 		return VCCBackupTenant{}, err
 	}
@@ -1,6 +1,9 @@
 package iland
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type VCCFailoverPlan struct {
 	ID              string                          `json:"uuid"`
@@ -27,8 +30,13 @@ type vccFailoverPlanService struct {
 }
 
 func (s *vccFailoverPlanService) Get(id string) (VCCFailoverPlan, error) {
+	return s.GetContext(context.Background(), id)
+}
+
+func (s *vccFailoverPlanService) GetContext(ctx context.Context, id string) (VCCFailoverPlan, error) {
+	ctx = withOperation(ctx, "VCCFailoverPlan.Get", id)
 	obj := VCCFailoverPlan{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vcc-failover-plan/%s", id), &obj)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vcc-failover-plan/%s", id), &obj)
 	if err != nil {
 		return VCCFailoverPlan{}, err
 	}
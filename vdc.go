@@ -1,8 +1,10 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -36,8 +38,13 @@ type vdcService struct {
 }
 
 func (s *vdcService) Get(vdcID string) (Vdc, error) {
+	return s.GetContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetContext(ctx context.Context, vdcID string) (Vdc, error) {
+	ctx = withOperation(ctx, "Vdc.Get", vdcID)
 	vdc := Vdc{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s", vdcID), &vdc)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s", vdcID), &vdc)
 	if err != nil {
 		return Vdc{}, err
 	}
@@ -45,10 +52,15 @@ func (s *vdcService) Get(vdcID string) (Vdc, error) {
 }
 
 func (s *vdcService) GetStorageProfiles(vdcID string) ([]StorageProfile, error) {
+	return s.GetStorageProfilesContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetStorageProfilesContext(ctx context.Context, vdcID string) ([]StorageProfile, error) {
+	ctx = withOperation(ctx, "Vdc.GetStorageProfiles", vdcID)
 	schema := struct {
 		StorageProfiles []StorageProfile `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/storage-profiles", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/storage-profiles", vdcID), &schema)
 	if err != nil {
 		return []StorageProfile{}, err
 	}
@@ -72,8 +84,13 @@ type VdcSummary struct {
 }
 
 func (s *vdcService) GetSummary(vdcID string) (VdcSummary, error) {
+	return s.GetSummaryContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetSummaryContext(ctx context.Context, vdcID string) (VdcSummary, error) {
+	ctx = withOperation(ctx, "Vdc.GetSummary", vdcID)
 	summary := VdcSummary{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/summary", vdcID), &summary)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/summary", vdcID), &summary)
 	if err != nil {
 		return VdcSummary{}, err
 	}
@@ -81,52 +98,97 @@ func (s *vdcService) GetSummary(vdcID string) (VdcSummary, error) {
 }
 
 func (s *vdcService) GetVApps(vdcID string) ([]VApp, error) {
+	return s.GetVAppsContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetVAppsContext(ctx context.Context, vdcID string) ([]VApp, error) {
+	ctx = withOperation(ctx, "Vdc.GetVApps", vdcID)
 	schema := struct {
 		VApps []VApp `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/vapps", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/vapps", vdcID), &schema)
 	if err != nil {
 		return []VApp{}, err
 	}
 	return schema.VApps, nil
 }
 
+func (s *vdcService) ListVApps(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[VApp, error] {
+	ctx = withOperation(ctx, "Vdc.ListVApps", vdcID)
+	return listAll[VApp](ctx, s.client, fmt.Sprintf("/v1/vdcs/%s/vapps", vdcID), opts)
+}
+
 func (s *vdcService) GetVirtualMachines(vdcID string) ([]VirtualMachine, error) {
+	return s.GetVirtualMachinesContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetVirtualMachinesContext(ctx context.Context, vdcID string) ([]VirtualMachine, error) {
+	ctx = withOperation(ctx, "Vdc.GetVirtualMachines", vdcID)
 	schema := struct {
 		VirtualMachines []VirtualMachine `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/vms", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/vms", vdcID), &schema)
 	if err != nil {
 		return []VirtualMachine{}, err
 	}
 	return schema.VirtualMachines, nil
 }
 
+func (s *vdcService) ListVirtualMachines(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[VirtualMachine, error] {
+	ctx = withOperation(ctx, "Vdc.ListVirtualMachines", vdcID)
+	return listAll[VirtualMachine](ctx, s.client, fmt.Sprintf("/v1/vdcs/%s/vms", vdcID), opts)
+}
+
 func (s *vdcService) GetEdges(vdcID string) ([]Edge, error) {
+	return s.GetEdgesContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetEdgesContext(ctx context.Context, vdcID string) ([]Edge, error) {
+	ctx = withOperation(ctx, "Vdc.GetEdges", vdcID)
 	schema := struct {
 		Edges []Edge `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/edges", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/edges", vdcID), &schema)
 	if err != nil {
 		return []Edge{}, err
 	}
 	return schema.Edges, nil
 }
 
+func (s *vdcService) ListEdges(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[Edge, error] {
+	ctx = withOperation(ctx, "Vdc.ListEdges", vdcID)
+	return listAll[Edge](ctx, s.client, fmt.Sprintf("/v1/vdcs/%s/edges", vdcID), opts)
+}
+
 func (s *vdcService) GetNetworks(vdcID string) ([]OrgVdcNetwork, error) {
+	return s.GetNetworksContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetNetworksContext(ctx context.Context, vdcID string) ([]OrgVdcNetwork, error) {
+	ctx = withOperation(ctx, "Vdc.GetNetworks", vdcID)
 	schema := struct {
 		Networks []OrgVdcNetwork `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/org-vdc-networks", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/org-vdc-networks", vdcID), &schema)
 	if err != nil {
 		return []OrgVdcNetwork{}, err
 	}
 	return schema.Networks, nil
 }
 
+func (s *vdcService) ListNetworks(ctx context.Context, vdcID string, opts ListOptions) iter.Seq2[OrgVdcNetwork, error] {
+	ctx = withOperation(ctx, "Vdc.ListNetworks", vdcID)
+	return listAll[OrgVdcNetwork](ctx, s.client, fmt.Sprintf("/v1/vdcs/%s/org-vdc-networks", vdcID), opts)
+}
+
 func (s *vdcService) GetCurrentBill(vdcID string) (Billing, error) {
+	return s.GetCurrentBillContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetCurrentBillContext(ctx context.Context, vdcID string) (Billing, error) {
+	ctx = withOperation(ctx, "Vdc.GetCurrentBill", vdcID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/billing", vdcID), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/billing", vdcID), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -134,8 +196,13 @@ func (s *vdcService) GetCurrentBill(vdcID string) (Billing, error) {
 }
 
 func (s *vdcService) GetBill(vdcID string, month, year int) (Billing, error) {
+	return s.GetBillContext(context.Background(), vdcID, month, year)
+}
+
+func (s *vdcService) GetBillContext(ctx context.Context, vdcID string, month, year int) (Billing, error) {
+	ctx = withOperation(ctx, "Vdc.GetBill", vdcID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/billing?month=%d&year=%d", vdcID, month, year), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/billing?month=%d&year=%d", vdcID, month, year), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -143,10 +210,15 @@ func (s *vdcService) GetBill(vdcID string, month, year int) (Billing, error) {
 }
 
 func (s *vdcService) GetCurrentVAppBill(vdcID string) ([]Billing, error) {
+	return s.GetCurrentVAppBillContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetCurrentVAppBillContext(ctx context.Context, vdcID string) ([]Billing, error) {
+	ctx = withOperation(ctx, "Vdc.GetCurrentVAppBill", vdcID)
 	schema := struct {
 		Billing []Billing `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/vapp-bills", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/vapp-bills", vdcID), &schema)
 	if err != nil {
 		return []Billing{}, err
 	}
@@ -154,10 +226,15 @@ func (s *vdcService) GetCurrentVAppBill(vdcID string) ([]Billing, error) {
 }
 
 func (s *vdcService) GetVAppBill(vdcID string, month, year int) ([]Billing, error) {
+	return s.GetVAppBillContext(context.Background(), vdcID, month, year)
+}
+
+func (s *vdcService) GetVAppBillContext(ctx context.Context, vdcID string, month, year int) ([]Billing, error) {
+	ctx = withOperation(ctx, "Vdc.GetVAppBill", vdcID)
 	schema := struct {
 		Billing []Billing `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/vapp-bills?month=%d&year=%d", vdcID, month, year), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/vapp-bills?month=%d&year=%d", vdcID, month, year), &schema)
 	if err != nil {
 		return []Billing{}, err
 	}
@@ -165,10 +242,15 @@ func (s *vdcService) GetVAppBill(vdcID string, month, year int) ([]Billing, erro
 }
 
 func (s *vdcService) GetPerformanceCounters(vdcID string) ([]PerformanceCounter, error) {
+	return s.GetPerformanceCountersContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetPerformanceCountersContext(ctx context.Context, vdcID string) ([]PerformanceCounter, error) {
+	ctx = withOperation(ctx, "Vdc.GetPerformanceCounters", vdcID)
 	schema := struct {
 		Counters []PerformanceCounter `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/performance-counters", vdcID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/performance-counters", vdcID), &schema)
 	if err != nil {
 		return []PerformanceCounter{}, err
 	}
@@ -176,10 +258,15 @@ func (s *vdcService) GetPerformanceCounters(vdcID string) ([]PerformanceCounter,
 }
 
 func (s *vdcService) GetPerformance(vdcID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	return s.GetPerformanceContext(context.Background(), vdcID, counter, start, end)
+}
+
+func (s *vdcService) GetPerformanceContext(ctx context.Context, vdcID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	ctx = withOperation(ctx, "Vdc.GetPerformance", vdcID)
 	startNano := getUnixMilliseconds(start)
 	endNano := getUnixMilliseconds(end)
 	performance := Performance{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vdcs/%s/performance/%s::%s::%s?start=%d&end=%d", vdcID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vdcs/%s/performance/%s::%s::%s?start=%d&end=%d", vdcID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
 	if err != nil {
 		return Performance{}, err
 	}
@@ -193,11 +280,16 @@ type BuildVAppParams struct {
 }
 
 func (s *vdcService) BuildVApp(vdcID string, params BuildVAppParams) (Task, error) {
+	return s.BuildVAppContext(context.Background(), vdcID, params)
+}
+
+func (s *vdcService) BuildVAppContext(ctx context.Context, vdcID string, params BuildVAppParams) (Task, error) {
+	ctx = withOperation(ctx, "Vdc.BuildVApp", vdcID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vdcs/%s/actions/build-vapp", vdcID), data)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vdcs/%s/actions/build-vapp", vdcID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -234,11 +326,16 @@ type VAppTemplateNicParams struct {
 }
 
 func (s *vdcService) DeployVAppTemplate(vdcID string, params DeployVAppTemplateParams) (Task, error) {
+	return s.DeployVAppTemplateContext(context.Background(), vdcID, params)
+}
+
+func (s *vdcService) DeployVAppTemplateContext(ctx context.Context, vdcID string, params DeployVAppTemplateParams) (Task, error) {
+	ctx = withOperation(ctx, "Vdc.DeployVAppTemplate", vdcID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vdcs/%s/actions/add-vapp-from-template", vdcID), data)
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vdcs/%s/actions/add-vapp-from-template", vdcID), data)
 	if err != nil {
 		return Task{}, err
 	}
@@ -257,7 +354,12 @@ type VdcBackupStats struct {
 }
 
 func (s *vdcService) GetBackupStats(vdcID string) (VdcBackupStats, error) {
-	resp, err := s.client.Get(fmt.Sprintf("/v1/vdcs/%s/backup-group-summary-stats", vdcID))
+	return s.GetBackupStatsContext(context.Background(), vdcID)
+}
+
+func (s *vdcService) GetBackupStatsContext(ctx context.Context, vdcID string) (VdcBackupStats, error) {
+	ctx = withOperation(ctx, "Vdc.GetBackupStats", vdcID)
+	resp, err := s.client.GetContext(ctx, fmt.Sprintf("/v1/vdcs/%s/backup-group-summary-stats", vdcID))
 	if err != nil {
 		return VdcBackupStats{}, err
 	}
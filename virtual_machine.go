@@ -1,9 +1,11 @@
 package iland
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"iter"
 	"time"
 )
 
@@ -42,8 +44,8 @@ type virtualMachineService struct {
 	client *client
 }
 
-func (s *virtualMachineService) postAction(virtualMachineID, action string, params []byte) (Task, error) {
-	resp, err := s.client.Post(fmt.Sprintf("/v1/vms/%s/actions/%s", virtualMachineID, action), params)
+func (s *virtualMachineService) postAction(ctx context.Context, virtualMachineID, action string, params []byte) (Task, error) {
+	resp, err := s.client.PostContext(ctx, fmt.Sprintf("/v1/vms/%s/actions/%s", virtualMachineID, action), params)
 	if err != nil {
 		return Task{}, err
 	}
@@ -56,8 +58,13 @@ func (s *virtualMachineService) postAction(virtualMachineID, action string, para
 }
 
 func (s *virtualMachineService) Get(virtualMachineID string) (VirtualMachine, error) {
+	return s.GetContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetContext(ctx context.Context, virtualMachineID string) (VirtualMachine, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Get", virtualMachineID)
 	virtualMachine := VirtualMachine{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s", virtualMachineID), &virtualMachine)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s", virtualMachineID), &virtualMachine)
 	if err != nil {
 		return VirtualMachine{}, err
 	}
@@ -65,7 +72,12 @@ func (s *virtualMachineService) Get(virtualMachineID string) (VirtualMachine, er
 }
 
 func (s *virtualMachineService) Delete(virtualMachineID string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vms/%s", virtualMachineID))
+	return s.DeleteContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) DeleteContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Delete", virtualMachineID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vms/%s", virtualMachineID))
 	if err != nil {
 		return Task{}, err
 	}
@@ -78,6 +90,11 @@ func (s *virtualMachineService) Delete(virtualMachineID string) (Task, error) {
 }
 
 func (s *virtualMachineService) UpdateName(virtualMachineID, name string) (Task, error) {
+	return s.UpdateNameContext(context.Background(), virtualMachineID, name)
+}
+
+func (s *virtualMachineService) UpdateNameContext(ctx context.Context, virtualMachineID, name string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateName", virtualMachineID)
 	params := struct {
 		Name string `json:"name"`
 	}{
@@ -87,10 +104,15 @@ func (s *virtualMachineService) UpdateName(virtualMachineID, name string) (Task,
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-name", data)
+	return s.postAction(ctx, virtualMachineID, "update-name", data)
 }
 
 func (s *virtualMachineService) UpdateDescription(virtualMachineID, description string) (Task, error) {
+	return s.UpdateDescriptionContext(context.Background(), virtualMachineID, description)
+}
+
+func (s *virtualMachineService) UpdateDescriptionContext(ctx context.Context, virtualMachineID, description string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateDescription", virtualMachineID)
 	params := struct {
 		Description string `json:"description"`
 	}{
@@ -100,35 +122,70 @@ func (s *virtualMachineService) UpdateDescription(virtualMachineID, description
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-description", data)
+	return s.postAction(ctx, virtualMachineID, "update-description", data)
 }
 
 func (s *virtualMachineService) PowerOn(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "poweron", []byte{})
+	return s.PowerOnContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) PowerOnContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.PowerOn", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "poweron", []byte{})
 }
 
 func (s *virtualMachineService) PowerOnForceCustomization(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "poweron?forceGuestCustomization=true", []byte{})
+	return s.PowerOnForceCustomizationContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) PowerOnForceCustomizationContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.PowerOnForceCustomization", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "poweron?forceGuestCustomization=true", []byte{})
 }
 
 func (s *virtualMachineService) PowerOff(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "poweroff", []byte{})
+	return s.PowerOffContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) PowerOffContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.PowerOff", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "poweroff", []byte{})
 }
 
 func (s *virtualMachineService) Reboot(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "reboot", []byte{})
+	return s.RebootContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) RebootContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Reboot", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "reboot", []byte{})
 }
 
 func (s *virtualMachineService) Reset(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "reset", []byte{})
+	return s.ResetContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) ResetContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Reset", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "reset", []byte{})
 }
 
 func (s *virtualMachineService) Shutdown(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "shutdown", []byte{})
+	return s.ShutdownContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) ShutdownContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Shutdown", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "shutdown", []byte{})
 }
 
 func (s *virtualMachineService) Suspend(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "suspend", []byte{})
+	return s.SuspendContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) SuspendContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Suspend", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "suspend", []byte{})
 }
 
 type CopyVirtualMachineParams struct {
@@ -137,11 +194,16 @@ type CopyVirtualMachineParams struct {
 }
 
 func (s *virtualMachineService) Copy(virtualMachineID string, params CopyVirtualMachineParams) (Task, error) {
+	return s.CopyContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) CopyContext(ctx context.Context, virtualMachineID string, params CopyVirtualMachineParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Copy", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "copy", data)
+	return s.postAction(ctx, virtualMachineID, "copy", data)
 }
 
 type MoveVirtualMachineParams struct {
@@ -150,11 +212,16 @@ type MoveVirtualMachineParams struct {
 }
 
 func (s *virtualMachineService) Move(virtualMachineID string, params MoveVirtualMachineParams) (Task, error) {
+	return s.MoveContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) MoveContext(ctx context.Context, virtualMachineID string, params MoveVirtualMachineParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Move", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "move", data)
+	return s.postAction(ctx, virtualMachineID, "move", data)
 }
 
 type Summary struct {
@@ -167,8 +234,13 @@ type Summary struct {
 }
 
 func (s *virtualMachineService) GetSummary(virtualMachineID string) (Summary, error) {
+	return s.GetSummaryContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetSummaryContext(ctx context.Context, virtualMachineID string) (Summary, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetSummary", virtualMachineID)
 	summary := Summary{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/summary", virtualMachineID), &summary)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/summary", virtualMachineID), &summary)
 	if err != nil {
 		return Summary{}, err
 	}
@@ -176,10 +248,15 @@ func (s *virtualMachineService) GetSummary(virtualMachineID string) (Summary, er
 }
 
 func (s *virtualMachineService) GetAvailableStorageProfiles(virtualMachineID string) ([]StorageProfile, error) {
+	return s.GetAvailableStorageProfilesContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetAvailableStorageProfilesContext(ctx context.Context, virtualMachineID string) ([]StorageProfile, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetAvailableStorageProfiles", virtualMachineID)
 	schema := struct {
 		StorageProfiles []StorageProfile `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/available-storage-profiles", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/available-storage-profiles", virtualMachineID), &schema)
 	if err != nil {
 		return []StorageProfile{}, err
 	}
@@ -187,6 +264,11 @@ func (s *virtualMachineService) GetAvailableStorageProfiles(virtualMachineID str
 }
 
 func (s *virtualMachineService) ChangeStorageProfile(virtualMachineID, storageProfileID string) (Task, error) {
+	return s.ChangeStorageProfileContext(context.Background(), virtualMachineID, storageProfileID)
+}
+
+func (s *virtualMachineService) ChangeStorageProfileContext(ctx context.Context, virtualMachineID, storageProfileID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.ChangeStorageProfile", virtualMachineID)
 	schema := struct {
 		StorageProfileID string `json:"storage_profile"`
 	}{
@@ -196,18 +278,33 @@ func (s *virtualMachineService) ChangeStorageProfile(virtualMachineID, storagePr
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "relocate", data)
+	return s.postAction(ctx, virtualMachineID, "relocate", data)
 }
 
 func (s *virtualMachineService) EnableNestedHypervisor(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "enable-nested-hypervisor", []byte{})
+	return s.EnableNestedHypervisorContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) EnableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.EnableNestedHypervisor", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "enable-nested-hypervisor", []byte{})
 }
 
 func (s *virtualMachineService) DisableNestedHypervisor(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "disable-nested-hypervisor", []byte{})
+	return s.DisableNestedHypervisorContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) DisableNestedHypervisorContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.DisableNestedHypervisor", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "disable-nested-hypervisor", []byte{})
 }
 
 func (s *virtualMachineService) InsertMedia(virtualMachineID, mediaID string) (Task, error) {
+	return s.InsertMediaContext(context.Background(), virtualMachineID, mediaID)
+}
+
+func (s *virtualMachineService) InsertMediaContext(ctx context.Context, virtualMachineID, mediaID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.InsertMedia", virtualMachineID)
 	schema := struct {
 		MediaID string `json:"media"`
 	}{
@@ -217,11 +314,16 @@ func (s *virtualMachineService) InsertMedia(virtualMachineID, mediaID string) (T
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "insert-media", data)
+	return s.postAction(ctx, virtualMachineID, "insert-media", data)
 }
 
 func (s *virtualMachineService) EjectMedia(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "eject-media", []byte{})
+	return s.EjectMediaContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) EjectMediaContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.EjectMedia", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "eject-media", []byte{})
 }
 
 type GuestCustomization struct {
@@ -245,8 +347,13 @@ type GuestCustomization struct {
 }
 
 func (s *virtualMachineService) GetGuestCustomization(virtualMachineID string) (GuestCustomization, error) {
+	return s.GetGuestCustomizationContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetGuestCustomizationContext(ctx context.Context, virtualMachineID string) (GuestCustomization, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetGuestCustomization", virtualMachineID)
 	guestCustomization := GuestCustomization{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/guest-customization", virtualMachineID), &guestCustomization)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/guest-customization", virtualMachineID), &guestCustomization)
 	if err != nil {
 		return GuestCustomization{}, err
 	}
@@ -254,11 +361,16 @@ func (s *virtualMachineService) GetGuestCustomization(virtualMachineID string) (
 }
 
 func (s *virtualMachineService) UpdateGuestCustomization(virtualMachineID string, params GuestCustomization) (Task, error) {
+	return s.UpdateGuestCustomizationContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateGuestCustomizationContext(ctx context.Context, virtualMachineID string, params GuestCustomization) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateGuestCustomization", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-guest-customization", data)
+	return s.postAction(ctx, virtualMachineID, "update-guest-customization", data)
 }
 
 type HotAdd struct {
@@ -267,8 +379,13 @@ type HotAdd struct {
 }
 
 func (s *virtualMachineService) GetHotAdd(virtualMachineID string) (HotAdd, error) {
+	return s.GetHotAddContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetHotAddContext(ctx context.Context, virtualMachineID string) (HotAdd, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetHotAdd", virtualMachineID)
 	hotAdd := HotAdd{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/capabilities", virtualMachineID), &hotAdd)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/capabilities", virtualMachineID), &hotAdd)
 	if err != nil {
 		return HotAdd{}, err
 	}
@@ -276,11 +393,16 @@ func (s *virtualMachineService) GetHotAdd(virtualMachineID string) (HotAdd, erro
 }
 
 func (s *virtualMachineService) UpdateHotAdd(virtualMachineID string, params HotAdd) (Task, error) {
+	return s.UpdateHotAddContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateHotAddContext(ctx context.Context, virtualMachineID string, params HotAdd) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateHotAdd", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-capabilities", data)
+	return s.postAction(ctx, virtualMachineID, "update-capabilities", data)
 }
 
 type BootOptions struct {
@@ -289,8 +411,13 @@ type BootOptions struct {
 }
 
 func (s *virtualMachineService) GetBootOptions(virtualMachineID string) (BootOptions, error) {
+	return s.GetBootOptionsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetBootOptionsContext(ctx context.Context, virtualMachineID string) (BootOptions, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetBootOptions", virtualMachineID)
 	bootOptions := BootOptions{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/boot-options", virtualMachineID), &bootOptions)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/boot-options", virtualMachineID), &bootOptions)
 	if err != nil {
 		return BootOptions{}, err
 	}
@@ -298,15 +425,25 @@ func (s *virtualMachineService) GetBootOptions(virtualMachineID string) (BootOpt
 }
 
 func (s *virtualMachineService) UpdateBootOptions(virtualMachineID string, params BootOptions) (Task, error) {
+	return s.UpdateBootOptionsContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateBootOptionsContext(ctx context.Context, virtualMachineID string, params BootOptions) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateBootOptions", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-boot-options", data)
+	return s.postAction(ctx, virtualMachineID, "update-boot-options", data)
 }
 
 func (s *virtualMachineService) UpdateHardwareVersion(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "update-virtual-hardware-version", []byte{})
+	return s.UpdateHardwareVersionContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) UpdateHardwareVersionContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateHardwareVersion", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "update-virtual-hardware-version", []byte{})
 }
 
 type VMwareTools struct {
@@ -316,8 +453,13 @@ type VMwareTools struct {
 }
 
 func (s *virtualMachineService) GetVMwareTools(virtualMachineID string) (VMwareTools, error) {
+	return s.GetVMwareToolsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetVMwareToolsContext(ctx context.Context, virtualMachineID string) (VMwareTools, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetVMwareTools", virtualMachineID)
 	tools := VMwareTools{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/guest-tools", virtualMachineID), &tools)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/guest-tools", virtualMachineID), &tools)
 	if err != nil {
 		return VMwareTools{}, err
 	}
@@ -325,11 +467,21 @@ func (s *virtualMachineService) GetVMwareTools(virtualMachineID string) (VMwareT
 }
 
 func (s *virtualMachineService) UpgradeVMwareTools(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "upgrade-guest-tools", []byte{})
+	return s.UpgradeVMwareToolsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) UpgradeVMwareToolsContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpgradeVMwareTools", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "upgrade-guest-tools", []byte{})
 }
 
 func (s *virtualMachineService) InstallVMwareTools(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "install-vmware-tools", []byte{})
+	return s.InstallVMwareToolsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) InstallVMwareToolsContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.InstallVMwareTools", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "install-vmware-tools", []byte{})
 }
 
 type ReconfigureParams struct {
@@ -342,11 +494,16 @@ type ReconfigureParams struct {
 }
 
 func (s *virtualMachineService) Reconfigure(virtualMachineID string, params ReconfigureParams) (Task, error) {
+	return s.ReconfigureContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) ReconfigureContext(ctx context.Context, virtualMachineID string, params ReconfigureParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.Reconfigure", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "reconfigure", data)
+	return s.postAction(ctx, virtualMachineID, "reconfigure", data)
 }
 
 type Disk struct {
@@ -357,10 +514,15 @@ type Disk struct {
 }
 
 func (s *virtualMachineService) GetDisks(virtualMachineID string) ([]Disk, error) {
+	return s.GetDisksContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetDisksContext(ctx context.Context, virtualMachineID string) ([]Disk, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetDisks", virtualMachineID)
 	schema := struct {
 		Disks []Disk `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/virtual-disks", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/virtual-disks", virtualMachineID), &schema)
 	if err != nil {
 		return []Disk{}, err
 	}
@@ -375,31 +537,51 @@ type DiskParams struct {
 }
 
 func (s *virtualMachineService) AddDisk(virtualMachineID string, params DiskParams) (Task, error) {
+	return s.AddDiskContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) AddDiskContext(ctx context.Context, virtualMachineID string, params DiskParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.AddDisk", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "add-virtual-disk", data)
+	return s.postAction(ctx, virtualMachineID, "add-virtual-disk", data)
 }
 
 func (s *virtualMachineService) UpdateDisk(virtualMachineID string, params DiskParams) (Task, error) {
+	return s.UpdateDiskContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateDiskContext(ctx context.Context, virtualMachineID string, params DiskParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateDisk", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-virtual-disk", data)
+	return s.postAction(ctx, virtualMachineID, "update-virtual-disk", data)
 }
 
 func (s *virtualMachineService) UpdateDisks(virtualMachineID string, params []DiskParams) (Task, error) {
+	return s.UpdateDisksContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateDisksContext(ctx context.Context, virtualMachineID string, params []DiskParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateDisks", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-virtual-disks", data)
+	return s.postAction(ctx, virtualMachineID, "update-virtual-disks", data)
 }
 
 func (s *virtualMachineService) DeleteDisk(virtualMachineID string, diskName string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vms/%s/disks/%s", virtualMachineID, diskName))
+	return s.DeleteDiskContext(context.Background(), virtualMachineID, diskName)
+}
+
+func (s *virtualMachineService) DeleteDiskContext(ctx context.Context, virtualMachineID string, diskName string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.DeleteDisk", virtualMachineID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vms/%s/disks/%s", virtualMachineID, diskName))
 	if err != nil {
 		return Task{}, err
 	}
@@ -412,10 +594,15 @@ func (s *virtualMachineService) DeleteDisk(virtualMachineID string, diskName str
 }
 
 func (s *virtualMachineService) GetRecommendedBusType(virtualMachineID string) (string, error) {
+	return s.GetRecommendedBusTypeContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetRecommendedBusTypeContext(ctx context.Context, virtualMachineID string) (string, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetRecommendedBusType", virtualMachineID)
 	schema := struct {
 		BusType string `json:"bus_type"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/recommended-disk-bus-type", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/recommended-disk-bus-type", virtualMachineID), &schema)
 	if err != nil {
 		return "", err
 	}
@@ -434,10 +621,15 @@ type Nic struct {
 }
 
 func (s *virtualMachineService) GetNics(virtualMachineID string) ([]Nic, error) {
+	return s.GetNicsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetNicsContext(ctx context.Context, virtualMachineID string) ([]Nic, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetNics", virtualMachineID)
 	schema := struct {
 		Nics []Nic `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/vnics", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/vnics", virtualMachineID), &schema)
 	if err != nil {
 		return []Nic{}, err
 	}
@@ -445,7 +637,12 @@ func (s *virtualMachineService) GetNics(virtualMachineID string) ([]Nic, error)
 }
 
 func (s *virtualMachineService) DeleteNic(virtualMachineID string, nicID int) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vms/%s/vnics/%d", virtualMachineID, nicID))
+	return s.DeleteNicContext(context.Background(), virtualMachineID, nicID)
+}
+
+func (s *virtualMachineService) DeleteNicContext(ctx context.Context, virtualMachineID string, nicID int) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.DeleteNic", virtualMachineID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vms/%s/vnics/%d", virtualMachineID, nicID))
 	if err != nil {
 		return Task{}, err
 	}
@@ -458,11 +655,16 @@ func (s *virtualMachineService) DeleteNic(virtualMachineID string, nicID int) (T
 }
 
 func (s *virtualMachineService) UpdateNics(virtualMachineID string, params []Nic) (Task, error) {
+	return s.UpdateNicsContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateNicsContext(ctx context.Context, virtualMachineID string, params []Nic) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateNics", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-vnics", data)
+	return s.postAction(ctx, virtualMachineID, "update-vnics", data)
 }
 
 type UpdateCPUParams struct {
@@ -471,19 +673,29 @@ type UpdateCPUParams struct {
 }
 
 func (s *virtualMachineService) UpdateCPU(virtualMachineID string, params UpdateCPUParams) (Task, error) {
+	return s.UpdateCPUContext(context.Background(), virtualMachineID, params)
+}
+
+func (s *virtualMachineService) UpdateCPUContext(ctx context.Context, virtualMachineID string, params UpdateCPUParams) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateCPU", virtualMachineID)
 	data, err := json.Marshal(&params)
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-cpu-count", data)
+	return s.postAction(ctx, virtualMachineID, "update-cpu-count", data)
 }
 
 func (s *virtualMachineService) UpdateCPUCount(virtualMachineID string, cpuCount int) (Task, error) {
+	return s.UpdateCPUCountContext(context.Background(), virtualMachineID, cpuCount)
+}
+
+func (s *virtualMachineService) UpdateCPUCountContext(ctx context.Context, virtualMachineID string, cpuCount int) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateCPUCount", virtualMachineID)
 	params := UpdateCPUParams{
 		CPUCount:       cpuCount,
 		CoresPerSocket: 1,
 	}
-	return s.UpdateCPU(virtualMachineID, params)
+	return s.UpdateCPUContext(ctx, virtualMachineID, params)
 }
 
 type UpdateMemoryParams struct {
@@ -491,6 +703,11 @@ type UpdateMemoryParams struct {
 }
 
 func (s *virtualMachineService) UpdateMemory(virtualMachineID string, memorySize int) (Task, error) {
+	return s.UpdateMemoryContext(context.Background(), virtualMachineID, memorySize)
+}
+
+func (s *virtualMachineService) UpdateMemoryContext(ctx context.Context, virtualMachineID string, memorySize int) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateMemory", virtualMachineID)
 	params := UpdateMemoryParams{
 		MemoryMB: memorySize,
 	}
@@ -498,7 +715,7 @@ func (s *virtualMachineService) UpdateMemory(virtualMachineID string, memorySize
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "update-memory-size", data)
+	return s.postAction(ctx, virtualMachineID, "update-memory-size", data)
 }
 
 type VirtualMachineBackup struct {
@@ -507,17 +724,32 @@ type VirtualMachineBackup struct {
 }
 
 func (s *virtualMachineService) GetBackups(virtualMachineID string) ([]VirtualMachineBackup, error) {
+	return s.GetBackupsContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetBackupsContext(ctx context.Context, virtualMachineID string) ([]VirtualMachineBackup, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetBackups", virtualMachineID)
 	schema := struct {
 		Backups []VirtualMachineBackup `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/backups", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/backups", virtualMachineID), &schema)
 	if err != nil {
 		return []VirtualMachineBackup{}, err
 	}
 	return schema.Backups, nil
 }
 
+func (s *virtualMachineService) ListBackups(ctx context.Context, virtualMachineID string, opts ListOptions) iter.Seq2[VirtualMachineBackup, error] {
+	ctx = withOperation(ctx, "VirtualMachine.ListBackups", virtualMachineID)
+	return listAll[VirtualMachineBackup](ctx, s.client, fmt.Sprintf("/v1/vms/%s/backups", virtualMachineID), opts)
+}
+
 func (s *virtualMachineService) RestoreBackup(virtualMachineID string, backupTimestamp int) (Task, error) {
+	return s.RestoreBackupContext(context.Background(), virtualMachineID, backupTimestamp)
+}
+
+func (s *virtualMachineService) RestoreBackupContext(ctx context.Context, virtualMachineID string, backupTimestamp int) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.RestoreBackup", virtualMachineID)
 	schema := struct {
 		Time int `json:"time"`
 	}{
@@ -527,10 +759,15 @@ func (s *virtualMachineService) RestoreBackup(virtualMachineID string, backupTim
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "restore", data)
+	return s.postAction(ctx, virtualMachineID, "restore", data)
 }
 
 func (s *virtualMachineService) RestoreBackupToVApp(virtualMachineID, vappID string, backupTimestamp int) (Task, error) {
+	return s.RestoreBackupToVAppContext(context.Background(), virtualMachineID, vappID, backupTimestamp)
+}
+
+func (s *virtualMachineService) RestoreBackupToVAppContext(ctx context.Context, virtualMachineID, vappID string, backupTimestamp int) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.RestoreBackupToVApp", virtualMachineID)
 	schema := struct {
 		Time   int    `json:"time"`
 		VAppID string `json:"vapp_uuid"`
@@ -542,14 +779,19 @@ func (s *virtualMachineService) RestoreBackupToVApp(virtualMachineID, vappID str
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "restore-into-vapp", data)
+	return s.postAction(ctx, virtualMachineID, "restore-into-vapp", data)
 }
 
 func (s *virtualMachineService) HasSnapshot(virtualMachineID string) (bool, error) {
+	return s.HasSnapshotContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) HasSnapshotContext(ctx context.Context, virtualMachineID string) (bool, error) {
+	ctx = withOperation(ctx, "VirtualMachine.HasSnapshot", virtualMachineID)
 	schema := struct {
 		HasSnapshot bool `json:"has_snapshot"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/has-snapshot", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/has-snapshot", virtualMachineID), &schema)
 	if err != nil {
 		return false, err
 	}
@@ -557,8 +799,13 @@ func (s *virtualMachineService) HasSnapshot(virtualMachineID string) (bool, erro
 }
 
 func (s *virtualMachineService) GetSnapshot(virtualMachineID string) (Snapshot, error) {
+	return s.GetSnapshotContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetSnapshotContext(ctx context.Context, virtualMachineID string) (Snapshot, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetSnapshot", virtualMachineID)
 	snapshot := Snapshot{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/snapshot", virtualMachineID), &snapshot)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/snapshot", virtualMachineID), &snapshot)
 	if err != nil {
 		return Snapshot{}, err
 	}
@@ -566,6 +813,11 @@ func (s *virtualMachineService) GetSnapshot(virtualMachineID string) (Snapshot,
 }
 
 func (s *virtualMachineService) CreateSnapshot(virtualMachineID string) (Task, error) {
+	return s.CreateSnapshotContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) CreateSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.CreateSnapshot", virtualMachineID)
 	params := struct {
 		Memory  bool `json:"memory"`
 		Quiesce bool `json:"quiesce"`
@@ -577,31 +829,56 @@ func (s *virtualMachineService) CreateSnapshot(virtualMachineID string) (Task, e
 	if err != nil {
 		return Task{}, err
 	}
-	return s.postAction(virtualMachineID, "create-snapshot", data)
+	return s.postAction(ctx, virtualMachineID, "create-snapshot", data)
 }
 
 func (s *virtualMachineService) RestoreSnapshot(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "restore-snapshot", []byte{})
+	return s.RestoreSnapshotContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) RestoreSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.RestoreSnapshot", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "restore-snapshot", []byte{})
 }
 
 func (s *virtualMachineService) RemoveSnapshot(virtualMachineID string) (Task, error) {
-	return s.postAction(virtualMachineID, "remove-snapshot", []byte{})
+	return s.RemoveSnapshotContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) RemoveSnapshotContext(ctx context.Context, virtualMachineID string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.RemoveSnapshot", virtualMachineID)
+	return s.postAction(ctx, virtualMachineID, "remove-snapshot", []byte{})
 }
 
 func (s *virtualMachineService) GetNetworks(virtualMachineID string) ([]VAppNetwork, error) {
+	return s.GetNetworksContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetNetworksContext(ctx context.Context, virtualMachineID string) ([]VAppNetwork, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetNetworks", virtualMachineID)
 	schema := struct {
 		Networks []VAppNetwork `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/networks", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/networks", virtualMachineID), &schema)
 	if err != nil {
 		return []VAppNetwork{}, err
 	}
 	return schema.Networks, nil
 }
 
+func (s *virtualMachineService) ListNetworks(ctx context.Context, virtualMachineID string, opts ListOptions) iter.Seq2[VAppNetwork, error] {
+	ctx = withOperation(ctx, "VirtualMachine.ListNetworks", virtualMachineID)
+	return listAll[VAppNetwork](ctx, s.client, fmt.Sprintf("/v1/vms/%s/networks", virtualMachineID), opts)
+}
+
 func (s *virtualMachineService) GetCurrentBill(virtualMachineID string) (Billing, error) {
+	return s.GetCurrentBillContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetCurrentBillContext(ctx context.Context, virtualMachineID string) (Billing, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetCurrentBill", virtualMachineID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/billing", virtualMachineID), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/billing", virtualMachineID), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -609,8 +886,13 @@ func (s *virtualMachineService) GetCurrentBill(virtualMachineID string) (Billing
 }
 
 func (s *virtualMachineService) GetBill(virtualMachineID string, month, year int) (Billing, error) {
+	return s.GetBillContext(context.Background(), virtualMachineID, month, year)
+}
+
+func (s *virtualMachineService) GetBillContext(ctx context.Context, virtualMachineID string, month, year int) (Billing, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetBill", virtualMachineID)
 	billing := Billing{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/billing?month=%d&year=%d", virtualMachineID, month, year), &billing)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/billing?month=%d&year=%d", virtualMachineID, month, year), &billing)
 	if err != nil {
 		return Billing{}, err
 	}
@@ -618,10 +900,15 @@ func (s *virtualMachineService) GetBill(virtualMachineID string, month, year int
 }
 
 func (s *virtualMachineService) GetMetadata(virtualMachineID string) ([]Metadata, error) {
+	return s.GetMetadataContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetMetadataContext(ctx context.Context, virtualMachineID string) ([]Metadata, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetMetadata", virtualMachineID)
 	schema := struct {
 		Metadata []Metadata `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/metadata", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/metadata", virtualMachineID), &schema)
 	if err != nil {
 		return []Metadata{}, err
 	}
@@ -629,11 +916,16 @@ func (s *virtualMachineService) GetMetadata(virtualMachineID string) ([]Metadata
 }
 
 func (s *virtualMachineService) UpdateMetadata(virtualMachineID string, metadata []Metadata) (Task, error) {
+	return s.UpdateMetadataContext(context.Background(), virtualMachineID, metadata)
+}
+
+func (s *virtualMachineService) UpdateMetadataContext(ctx context.Context, virtualMachineID string, metadata []Metadata) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.UpdateMetadata", virtualMachineID)
 	payload, err := json.Marshal(&metadata)
 	if err != nil {
 		return Task{}, err
 	}
-	resp, err := s.client.Put(fmt.Sprintf("/v1/vms/%s/metadata", virtualMachineID), payload)
+	resp, err := s.client.PutContext(ctx, fmt.Sprintf("/v1/vms/%s/metadata", virtualMachineID), payload)
 	if err != nil {
 		return Task{}, err
 	}
@@ -646,7 +938,12 @@ func (s *virtualMachineService) UpdateMetadata(virtualMachineID string, metadata
 }
 
 func (s *virtualMachineService) DeleteMetadata(virtualMachineID string, metadataKey string) (Task, error) {
-	resp, err := s.client.Delete(fmt.Sprintf("/v1/vms/%s/metadata/%s", virtualMachineID, metadataKey))
+	return s.DeleteMetadataContext(context.Background(), virtualMachineID, metadataKey)
+}
+
+func (s *virtualMachineService) DeleteMetadataContext(ctx context.Context, virtualMachineID string, metadataKey string) (Task, error) {
+	ctx = withOperation(ctx, "VirtualMachine.DeleteMetadata", virtualMachineID)
+	resp, err := s.client.DeleteContext(ctx, fmt.Sprintf("/v1/vms/%s/metadata/%s", virtualMachineID, metadataKey))
 	if err != nil {
 		return Task{}, err
 	}
@@ -659,10 +956,15 @@ func (s *virtualMachineService) DeleteMetadata(virtualMachineID string, metadata
 }
 
 func (s *virtualMachineService) GetPerformanceCounters(virtualMachineID string) ([]PerformanceCounter, error) {
+	return s.GetPerformanceCountersContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetPerformanceCountersContext(ctx context.Context, virtualMachineID string) ([]PerformanceCounter, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetPerformanceCounters", virtualMachineID)
 	schema := struct {
 		Counters []PerformanceCounter `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/performance-counters", virtualMachineID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/performance-counters", virtualMachineID), &schema)
 	if err != nil {
 		return []PerformanceCounter{}, err
 	}
@@ -670,10 +972,15 @@ func (s *virtualMachineService) GetPerformanceCounters(virtualMachineID string)
 }
 
 func (s *virtualMachineService) GetPerformance(virtualMachineID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	return s.GetPerformanceContext(context.Background(), virtualMachineID, counter, start, end)
+}
+
+func (s *virtualMachineService) GetPerformanceContext(ctx context.Context, virtualMachineID string, counter PerformanceCounter, start, end time.Time) (Performance, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetPerformance", virtualMachineID)
 	startNano := getUnixMilliseconds(start)
 	endNano := getUnixMilliseconds(end)
 	performance := Performance{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/performance/%s::%s::%s?start=%d&end=%d", virtualMachineID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/performance/%s::%s::%s?start=%d&end=%d", virtualMachineID, counter.Group, counter.Name, counter.Type, startNano, endNano), &performance)
 	if err != nil {
 		return Performance{}, err
 	}
@@ -688,8 +995,13 @@ type ConsoleSession struct {
 }
 
 func (s *virtualMachineService) GetConsoleSession(virtualMachineID string) (ConsoleSession, error) {
+	return s.GetConsoleSessionContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetConsoleSessionContext(ctx context.Context, virtualMachineID string) (ConsoleSession, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetConsoleSession", virtualMachineID)
 	session := ConsoleSession{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vms/%s/mks-screen-ticket", virtualMachineID), &session)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vms/%s/mks-screen-ticket", virtualMachineID), &session)
 	if err != nil {
 		return session, err
 	}
@@ -697,7 +1009,12 @@ func (s *virtualMachineService) GetConsoleSession(virtualMachineID string) (Cons
 }
 
 func (s *virtualMachineService) GetScreenThumbnail(virtualMachineID string) ([]byte, error) {
-	resp, err := s.client.Get(fmt.Sprintf("/v1/vms/%s/screen", virtualMachineID))
+	return s.GetScreenThumbnailContext(context.Background(), virtualMachineID)
+}
+
+func (s *virtualMachineService) GetScreenThumbnailContext(ctx context.Context, virtualMachineID string) ([]byte, error) {
+	ctx = withOperation(ctx, "VirtualMachine.GetScreenThumbnail", virtualMachineID)
+	resp, err := s.client.GetContext(ctx, fmt.Sprintf("/v1/vms/%s/screen", virtualMachineID))
 	if err != nil {
 		return nil, err
 	}
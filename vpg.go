@@ -1,7 +1,9 @@
 package iland
 
 import (
+	"context"
 	"fmt"
+	"iter"
 )
 
 type Vpg struct {
@@ -40,8 +42,13 @@ type vpgService struct {
 }
 
 func (s *vpgService) Get(vpgID string) (Vpg, error) {
+	return s.GetContext(context.Background(), vpgID)
+}
+
+func (s *vpgService) GetContext(ctx context.Context, vpgID string) (Vpg, error) {
+	ctx = withOperation(ctx, "Vpg.Get", vpgID)
 	vpg := Vpg{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vpgs/%s?expand=VPG_VM", vpgID), &vpg)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vpgs/%s?expand=VPG_VM", vpgID), &vpg)
 	if err != nil {
 		return Vpg{}, err
 	}
@@ -89,12 +96,22 @@ type VpgCheckpoint struct {
 }
 
 func (s *vpgService) GetCheckpoints(vpgID string) ([]VpgCheckpoint, error) {
+	return s.GetCheckpointsContext(context.Background(), vpgID)
+}
+
+func (s *vpgService) GetCheckpointsContext(ctx context.Context, vpgID string) ([]VpgCheckpoint, error) {
+	ctx = withOperation(ctx, "Vpg.GetCheckpoints", vpgID)
 	schema := struct {
 		Checkpoints []VpgCheckpoint `json:"data"`
 	}{}
-	err := s.client.getObject(fmt.Sprintf("/v1/vpgs/%s/checkpoints", vpgID), &schema)
+	err := s.client.getObject(ctx, fmt.Sprintf("/v1/vpgs/%s/checkpoints", vpgID), &schema)
 	if err != nil {
 		return []VpgCheckpoint{}, err
 	}
 	return schema.Checkpoints, nil
 }
+
+func (s *vpgService) ListCheckpoints(ctx context.Context, vpgID string, opts ListOptions) iter.Seq2[VpgCheckpoint, error] {
+	ctx = withOperation(ctx, "Vpg.ListCheckpoints", vpgID)
+	return listAll[VpgCheckpoint](ctx, s.client, fmt.Sprintf("/v1/vpgs/%s/checkpoints", vpgID), opts)
+}
@@ -0,0 +1,202 @@
+package iland
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256
+	// of the WebhookTimestampHeader value, a ".", and the request body, keyed
+	// with WebhookOptions.Secret.
+	WebhookSignatureHeader = "X-Iland-Signature"
+	// WebhookTimestampHeader carries the time the request was signed, in
+	// Unix seconds. Receivers reject requests signed too long ago, so that a
+	// captured request cannot be replayed.
+	WebhookTimestampHeader = "X-Iland-Timestamp"
+	// WebhookEventHeader carries the event type.
+	WebhookEventHeader = "X-Iland-Event"
+	// WebhookDeliveryHeader carries the event ID, which receivers can use to
+	// drop repeated deliveries.
+	WebhookDeliveryHeader = "X-Iland-Delivery"
+
+	// DefaultWebhookTolerance is how far the timestamp of a request may be
+	// from the receiver's clock when VerifyWebhook is given no tolerance.
+	DefaultWebhookTolerance = 5 * time.Minute
+)
+
+// Errors returned by VerifyWebhook.
+var (
+	ErrWebhookSignature = errors.New("iland: webhook signature does not match")
+	ErrWebhookTimestamp = errors.New("iland: webhook timestamp is missing or outside the tolerance")
+)
+
+// WebhookOptions configures a WebhookForwarder.
+type WebhookOptions struct {
+	// Secret signs each request; see WebhookSignatureHeader. Requests are
+	// unsigned if it is empty.
+	Secret string
+	Filter EventFilter
+	// Retry controls redelivery after a network error or a 429, 502, 503 or
+	// 504 response. It defaults to DefaultRetryPolicy.
+	Retry *RetryPolicy
+	// HTTPClient defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+	// OnError, if set, is called when an event could not be delivered to a
+	// URL after every attempt.
+	OnError func(url string, event Event, err error)
+}
+
+// WebhookForwarder POSTs events as JSON to webhook URLs.
+type WebhookForwarder struct {
+	urls  []string
+	opts  WebhookOptions
+	retry RetryPolicy
+	http  *http.Client
+}
+
+// NewWebhookForwarder returns a forwarder that delivers to every URL.
+func NewWebhookForwarder(urls []string, opts WebhookOptions) *WebhookForwarder {
+	retry := DefaultRetryPolicy
+	if opts.Retry != nil {
+		retry = *opts.Retry
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookForwarder{urls: urls, opts: opts, retry: retry, http: httpClient}
+}
+
+// Run forwards events, such as those of a Subscription, until the channel
+// is closed or ctx is done. Delivery failures are passed to OnError.
+//
+// Events are forwarded one at a time by calling Forward on Run's goroutine,
+// so an event waits until the previous one has been delivered to every URL,
+// retries included. The backlog is held by the events channel, so with
+// OverflowDrop a slow URL makes the subscription drop events once its
+// buffer is full, and with OverflowBlock it delays the other subscribers.
+func (f *WebhookForwarder) Run(ctx context.Context, events <-chan Event) {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			f.Forward(ctx, event)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Forward delivers event to every URL if it passes the filter, returning
+// the last delivery error.
+func (f *WebhookForwarder) Forward(ctx context.Context, event Event) error {
+	if !f.opts.Filter.Match(event) {
+		return nil
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for _, url := range f.urls {
+		err := f.deliver(ctx, url, event, body)
+		if err != nil {
+			lastErr = err
+			if f.opts.OnError != nil {
+				f.opts.OnError(url, event, err)
+			}
+		}
+	}
+	return lastErr
+}
+
+func (f *WebhookForwarder) deliver(ctx context.Context, url string, event Event, body []byte) error {
+	for attempt := 1; ; attempt++ {
+		err := f.post(ctx, url, event, body)
+		if err == nil || !isRetryable(err) || attempt >= f.retry.MaxAttempts {
+			return err
+		}
+		delay, ok := f.retry.delay(attempt, err)
+		if !ok {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (f *WebhookForwarder) post(ctx context.Context, url string, event Event, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event.Type)
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+	if f.opts.Secret != "" {
+		// Each attempt is signed afresh, so retries are not rejected as
+		// stale.
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(f.opts.Secret, timestamp, body))
+	}
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     http.MethodPost,
+		Path:       url,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// SignWebhook returns the WebhookSignatureHeader value for body sent with
+// the WebhookTimestampHeader value timestamp.
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks the WebhookSignatureHeader and WebhookTimestampHeader
+// values of a request against its body. It returns ErrWebhookTimestamp if
+// the timestamp is more than tolerance (DefaultWebhookTolerance if zero)
+// away from now, and ErrWebhookSignature if the signature does not match.
+func VerifyWebhook(secret string, body []byte, timestamp, signature string, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestamp
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrWebhookTimestamp
+	}
+	if !hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature)) {
+		return ErrWebhookSignature
+	}
+	return nil
+}
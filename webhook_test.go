@@ -0,0 +1,109 @@
+package iland_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	iland "github.com/ilanddev/go-sdk"
+)
+
+func TestWebhookForwarderHonoursRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter func() string
+	}{
+		{name: "seconds", retryAfter: func() string { return "1" }},
+		{name: "http date", retryAfter: func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter())
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer hook.Close()
+			// Without Retry-After the retry would wait an hour.
+			policy := iland.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Hour}
+			forwarder := iland.NewWebhookForwarder([]string{hook.URL}, iland.WebhookOptions{Retry: &policy})
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := forwarder.Forward(ctx, iland.Event{ID: "event", Type: iland.EventVmPowerOn}); err != nil {
+				t.Fatal(err)
+			}
+			if got := calls.Load(); got != 2 {
+				t.Errorf("made %d calls, want 2", got)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"uuid":"event"}`)
+	at := func(d time.Duration) string { return strconv.FormatInt(time.Now().Add(d).Unix(), 10) }
+	now := at(0)
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		timestamp string
+		// signedAt is the timestamp that was signed, if not timestamp.
+		signedAt  string
+		tolerance time.Duration
+		want      error
+	}{
+		{name: "valid", timestamp: now},
+		{name: "stale", timestamp: at(-10 * time.Minute), want: iland.ErrWebhookTimestamp},
+		{name: "from the future", timestamp: at(10 * time.Minute), want: iland.ErrWebhookTimestamp},
+		{name: "within a custom tolerance", timestamp: at(-10 * time.Minute), tolerance: time.Hour},
+		{name: "outside a custom tolerance", timestamp: at(-10 * time.Second), tolerance: time.Second, want: iland.ErrWebhookTimestamp},
+		{name: "missing timestamp", timestamp: "", signedAt: now, want: iland.ErrWebhookTimestamp},
+		{name: "replayed with a new timestamp", timestamp: now, signedAt: at(-10 * time.Minute), want: iland.ErrWebhookSignature},
+		{name: "tampered body", body: []byte(`{"uuid":"other"}`), timestamp: now, want: iland.ErrWebhookSignature},
+		{name: "wrong secret", secret: "other", timestamp: now, want: iland.ErrWebhookSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signedAt := tt.signedAt
+			if signedAt == "" {
+				signedAt = tt.timestamp
+			}
+			signature := iland.SignWebhook("s3cret", signedAt, body)
+			secret, received := tt.secret, tt.body
+			if secret == "" {
+				secret = "s3cret"
+			}
+			if received == nil {
+				received = body
+			}
+			if err := iland.VerifyWebhook(secret, received, tt.timestamp, signature, tt.tolerance); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookForwarderSigns(t *testing.T) {
+	verified := make(chan error, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified <- iland.VerifyWebhook("s3cret", body, r.Header.Get(iland.WebhookTimestampHeader), r.Header.Get(iland.WebhookSignatureHeader), 0)
+	}))
+	defer hook.Close()
+	forwarder := iland.NewWebhookForwarder([]string{hook.URL}, iland.WebhookOptions{Secret: "s3cret", Retry: &iland.NoRetryPolicy})
+	if err := forwarder.Forward(context.Background(), iland.Event{ID: "event", Type: iland.EventVmPowerOn}); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-verified; err != nil {
+		t.Errorf("receiver could not verify the request: %v", err)
+	}
+}